@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/cache"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/tui"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var cachePruneOlderThan string
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the download cache",
+	Long: `Manage the cache of downloaded runtime archives.
+
+Installs keep every archive they download in the cache, keyed by its
+manifest SHA256, and reuse it when the same version is installed again.
+Archives are re-verified before each use.
+
+The cache also makes offline installs possible:
+  dtvem install node 22.0.0 --offline
+
+Examples:
+  dtvem cache list                    # Show cached archives
+  dtvem cache prune                   # Remove archives for uninstalled versions
+  dtvem cache prune --older-than 30d  # Also remove archives unused for 30 days
+  dtvem cache clear                   # Remove every cached archive`,
+}
+
+var cacheListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List cached runtime archives",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		entries, err := cache.List()
+		if err != nil {
+			ui.Error("Failed to read download cache: %v", err)
+			os.Exit(1)
+		}
+
+		if len(entries) == 0 {
+			ui.Info("Download cache is empty")
+			return
+		}
+
+		table := tui.NewTable("Runtime", "Version", "Size", "Last Used", "File")
+		table.SetTitle("Download Cache")
+
+		var total int64
+		for _, entry := range entries {
+			total += entry.Size
+			table.AddRow(entry.Runtime, entry.Version, ui.FormatBytes(entry.Size), formatCacheTime(entry.LastUsed), entry.FileName)
+		}
+
+		fmt.Println(table.Render())
+		ui.Info("%d archive(s), %s total in %s", len(entries), ui.FormatBytes(total), config.ArchiveCacheDir())
+	},
+}
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove cached archives that are no longer needed",
+	Long: `Remove cached archives for runtime versions that are not installed,
+along with leftovers from interrupted downloads.
+
+With --older-than, archives that have not been used within the given
+duration are removed as well, even if their version is installed.
+Durations accept Go syntax (e.g., 720h) or a number of days (e.g., 30d).`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		var cutoff time.Time
+		if cachePruneOlderThan != "" {
+			age, err := parseCacheAge(cachePruneOlderThan)
+			if err != nil {
+				ui.Error("%v", err)
+				os.Exit(1)
+			}
+			cutoff = time.Now().Add(-age)
+		}
+
+		removed, freed, err := cache.Prune(func(entry cache.Entry) bool {
+			if !cutoff.IsZero() && entry.LastUsed.Before(cutoff) {
+				return true
+			}
+			return !isCachedVersionInstalled(entry)
+		})
+		if err != nil {
+			ui.Error("Failed to prune download cache: %v", err)
+			os.Exit(1)
+		}
+
+		for _, entry := range removed {
+			ui.Progress("Removed %s %s (%s)", entry.Runtime, entry.Version, ui.FormatBytes(entry.Size))
+		}
+
+		if freed == 0 {
+			ui.Info("Nothing to prune")
+			return
+		}
+		ui.Success("Freed %s", ui.FormatBytes(freed))
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove every cached runtime archive",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		freed, err := cache.Clear()
+		if err != nil {
+			ui.Error("Failed to clear download cache: %v", err)
+			os.Exit(1)
+		}
+		ui.Success("Cleared download cache (%s freed)", ui.FormatBytes(freed))
+	},
+}
+
+// isCachedVersionInstalled reports whether the runtime version a cached
+// archive belongs to is still installed
+func isCachedVersionInstalled(entry cache.Entry) bool {
+	for _, version := range config.InstalledVersions(entry.Runtime) {
+		if version == entry.Version {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCacheAge parses a duration, additionally accepting a number of days ("30d")
+func parseCacheAge(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return d, nil
+}
+
+// formatCacheTime renders a cache timestamp as a date
+func formatCacheTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func init() {
+	cachePruneCmd.Flags().StringVar(&cachePruneOlderThan, "older-than", "", "Also remove archives not used within this duration (e.g., 30d, 720h)")
+
+	cacheCmd.AddCommand(cacheListCmd)
+	cacheCmd.AddCommand(cachePruneCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
@@ -2,7 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/output"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/tui"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
@@ -16,21 +21,65 @@ var (
 
 // runtimeStatus holds the status of a configured runtime
 type runtimeStatus struct {
-	provider  runtime.Provider
-	version   string
-	installed bool
+	provider   runtime.Provider
+	version    string
+	source     string
+	sourcePath string
+	installed  bool
+}
+
+// CurrentOutput is the --output json|yaml result of "dtvem current"
+type CurrentOutput struct {
+	Runtimes []ActiveVersionInfo `json:"runtimes" yaml:"runtimes"`
+}
+
+// ActiveVersionInfo describes the active version of a configured runtime
+type ActiveVersionInfo struct {
+	Runtime     string `json:"runtime" yaml:"runtime"`
+	DisplayName string `json:"displayName" yaml:"displayName"`
+	// Version is the configured version, resolved to an installed version
+	// when the configuration is a range or alias
+	Version string `json:"version" yaml:"version"`
+	// Source is the absolute path of the file that configured the version,
+	// or the name of the DTVEM_<RUNTIME>_VERSION variable that overrides it
+	Source    string `json:"source" yaml:"source"`
+	Installed bool   `json:"installed" yaml:"installed"`
+}
+
+// currentOutput converts runtime statuses to the structured result
+func currentOutput(statuses []runtimeStatus) CurrentOutput {
+	result := CurrentOutput{Runtimes: make([]ActiveVersionInfo, 0, len(statuses))}
+	for _, rs := range statuses {
+		result.Runtimes = append(result.Runtimes, ActiveVersionInfo{
+			Runtime:     rs.provider.Name(),
+			DisplayName: rs.provider.DisplayName(),
+			Version:     rs.version,
+			Source:      rs.sourcePath,
+			Installed:   rs.installed,
+		})
+	}
+	return result
 }
 
 var currentCmd = &cobra.Command{
 	Use:   "current [runtime]",
 	Short: "Show the currently active version(s)",
 	Long: `Show the currently active version for a specific runtime or all runtimes.
-The active version is determined by checking local settings first, then global settings.
+The active version is determined by checking a DTVEM_<RUNTIME>_VERSION environment
+variable (e.g., DTVEM_NODE_VERSION) first, then local settings, then global settings.
+
+Local versions are read from the nearest directory containing a version file.
+Within one directory the precedence is .dtvem/runtimes.json, then runtime-specific
+files (.nvmrc, .node-version, .python-version, .ruby-version), then .tool-versions.
+The Source column shows which file or environment variable supplied each version.
+
+With --output json or yaml, install prompts are skipped.
 
 Examples:
   dtvem current           # Show all active versions
   dtvem current python    # Show active Python version
-  dtvem current node      # Show active Node.js version`,
+  dtvem current node      # Show active Node.js version
+  dtvem current -o json   # Show all active versions as JSON`,
 	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) == 0 {
@@ -55,40 +104,56 @@ func showAllVersions(yes, noInstall bool) {
 	// Collect status for all configured runtimes
 	var configured []runtimeStatus
 	for _, provider := range providers {
-		version, err := provider.CurrentVersion()
+		resolved, err := config.ResolveVersionSource(provider.Name())
 		if err != nil {
 			// Not configured - skip it
 			continue
 		}
+		version := activeInstalledVersion(provider, func() (string, error) {
+			return resolved.Version, nil
+		})
 		installed, _ := provider.IsInstalled(version)
 		configured = append(configured, runtimeStatus{
-			provider:  provider,
-			version:   version,
-			installed: installed,
+			provider:   provider,
+			version:    version,
+			source:     formatVersionSource(resolved.Source),
+			sourcePath: resolved.Source,
+			installed:  installed,
 		})
 	}
 
+	if output.IsStructured() {
+		printResult(currentOutput(configured))
+		return
+	}
+
 	if len(configured) == 0 {
 		ui.Info("No runtimes configured")
 		return
 	}
 
 	// Display all configured versions
-	table := tui.NewTable("Runtime", "Version", "Status")
+	table := tui.NewTable("Runtime", "Version", "Status", "Source")
 	table.SetTitle("Active Versions")
 	var missing []runtimeStatus
 
 	for _, rs := range configured {
 		if rs.installed {
-			table.AddActiveRow(rs.provider.DisplayName(), rs.version, tui.CheckMark+" installed")
+			table.AddActiveRow(rs.provider.DisplayName(), rs.version, tui.CheckMark+" installed", rs.source)
 		} else {
-			table.AddRow(rs.provider.DisplayName(), rs.version, tui.CrossMark+" not installed")
+			table.AddRow(rs.provider.DisplayName(), rs.version, tui.CrossMark+" not installed", rs.source)
 			missing = append(missing, rs)
 		}
 	}
 
 	fmt.Println(table.Render())
 
+	pins := make([]pinnedVersion, 0, len(configured))
+	for _, rs := range configured {
+		pins = append(pins, pinnedVersion{provider: rs.provider, version: rs.version, source: rs.source})
+	}
+	warnEOLPins(pins)
+
 	// Skip install prompts if --no-install flag is set
 	if noInstall {
 		return
@@ -100,12 +165,7 @@ func showAllVersions(yes, noInstall bool) {
 		shouldInstall := yes || ui.PromptInstallMissing(missing)
 		if shouldInstall {
 			for _, rs := range missing {
-				ui.Info("Installing %s %s...", rs.provider.DisplayName(), rs.version)
-				if err := rs.provider.Install(rs.version); err != nil {
-					ui.Error("Failed to install %s %s: %v", rs.provider.DisplayName(), rs.version, err)
-				} else {
-					ui.Success("%s %s installed successfully", rs.provider.DisplayName(), rs.version)
-				}
+				installVersionSpec(rs.provider, rs.version)
 			}
 		}
 	}
@@ -117,29 +177,45 @@ func showAllVersions(yes, noInstall bool) {
 func showSingleVersion(runtimeName string, yes, noInstall bool) {
 	provider, err := runtime.Get(runtimeName)
 	if err != nil {
-		ui.Error("%v", err)
-		ui.Info("Available runtimes: %v", runtime.List())
+		reportError(err.Error(), fmt.Sprintf("Available runtimes: %v", runtime.List()))
 		return
 	}
 
-	version, err := provider.CurrentVersion()
+	resolved, err := config.ResolveVersionSource(provider.Name())
 	if err != nil {
-		ui.Error("%v", err)
+		reportError(err.Error())
 		return
 	}
+	version := activeInstalledVersion(provider, func() (string, error) {
+		return resolved.Version, nil
+	})
+	source := formatVersionSource(resolved.Source)
 
 	installed, _ := provider.IsInstalled(version)
 
-	table := tui.NewTable("Runtime", "Version", "Status")
+	if output.IsStructured() {
+		printResult(currentOutput([]runtimeStatus{{
+			provider:   provider,
+			version:    version,
+			sourcePath: resolved.Source,
+			installed:  installed,
+		}}))
+		return
+	}
+
+	table := tui.NewTable("Runtime", "Version", "Status", "Source")
+	pins := []pinnedVersion{{provider: provider, version: version, source: source}}
 	if installed {
-		table.AddActiveRow(provider.DisplayName(), version, tui.CheckMark+" installed")
+		table.AddActiveRow(provider.DisplayName(), version, tui.CheckMark+" installed", source)
 		fmt.Println(table.Render())
+		warnEOLPins(pins)
 		return
 	}
 
 	// Not installed - show with warning and prompt
-	table.AddRow(provider.DisplayName(), version, tui.CrossMark+" not installed")
+	table.AddRow(provider.DisplayName(), version, tui.CrossMark+" not installed", source)
 	fmt.Println(table.Render())
+	warnEOLPins(pins)
 
 	// Skip install prompts if --no-install flag is set
 	if noInstall {
@@ -149,12 +225,43 @@ func showSingleVersion(runtimeName string, yes, noInstall bool) {
 	fmt.Println()
 	shouldInstall := yes || ui.PromptInstall(provider.DisplayName(), version)
 	if shouldInstall {
-		if err := provider.Install(version); err != nil {
-			ui.Error("Failed to install %s %s: %v", provider.DisplayName(), version, err)
-			return
-		}
-		ui.Success("%s %s installed successfully", provider.DisplayName(), version)
+		installVersionSpec(provider, version)
+	}
+}
+
+// installVersionSpec installs a configured version, resolving ranges and
+// aliases against the manifest first.
+func installVersionSpec(provider runtime.Provider, spec string) {
+	version, err := resolveVersionForProvider(provider, spec)
+	if err != nil {
+		ui.Error("%v", err)
+		return
+	}
+
+	ui.Info("Installing %s %s...", provider.DisplayName(), version)
+	if err := provider.Install(version); err != nil {
+		ui.Error("Failed to install %s %s: %v", provider.DisplayName(), version, err)
+		return
+	}
+	ui.Success("%s %s installed successfully", provider.DisplayName(), version)
+}
+
+// formatVersionSource shortens a version source path for display.
+// Files under the current directory are shown relative to it; environment
+// variable sources are shown as-is.
+func formatVersionSource(source string) string {
+	if !filepath.IsAbs(source) {
+		return source
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return source
+	}
+	rel, err := filepath.Rel(cwd, source)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return source
 	}
+	return rel
 }
 
 func init() {
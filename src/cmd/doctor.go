@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/doctor"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/output"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -36,13 +37,19 @@ Examples:
   dtvem doctor              # Report problems, don't change anything
   dtvem doctor --fix        # Prompt to fix each fixable finding
   dtvem doctor --fix --yes  # Apply every fixable finding non-interactively
-  dtvem doctor --no-fix     # Explicit read-only mode (for scripts)`,
+  dtvem doctor --no-fix     # Explicit read-only mode (for scripts)
+  dtvem doctor -o json      # Machine-readable report (exit code as above)`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if doctorFix && doctorNoFix {
 			ui.Error("--fix and --no-fix are mutually exclusive")
 			os.Exit(2)
 		}
 
+		if output.IsStructured() {
+			runDoctorStructured()
+			return
+		}
+
 		result := doctor.RunAll()
 		renderReport(result)
 
@@ -182,6 +189,106 @@ func applyFixes(r doctor.Result, yes bool) {
 	}
 }
 
+// DoctorOutput is the --output json|yaml result of "dtvem doctor"
+type DoctorOutput struct {
+	Checks  []DoctorCheck `json:"checks" yaml:"checks"`
+	Summary DoctorSummary `json:"summary" yaml:"summary"`
+	// Fixes lists the fixes applied with --fix --yes, in order
+	Fixes []DoctorFix `json:"fixes,omitempty" yaml:"fixes,omitempty"`
+}
+
+// DoctorCheck is the finding of one check
+type DoctorCheck struct {
+	// Name is the stable identifier of the check (e.g., "stale-shims-path")
+	Name  string `json:"name" yaml:"name"`
+	Title string `json:"title" yaml:"title"`
+	OK    bool   `json:"ok" yaml:"ok"`
+	// Severity is "info", "warning" or "error"; empty for passing checks
+	Severity   string         `json:"severity,omitempty" yaml:"severity,omitempty"`
+	Fixable    bool           `json:"fixable" yaml:"fixable"`
+	Details    []DoctorDetail `json:"details,omitempty" yaml:"details,omitempty"`
+	Resolution string         `json:"resolution,omitempty" yaml:"resolution,omitempty"`
+}
+
+// DoctorDetail is a key/value pair describing what a check found
+type DoctorDetail struct {
+	Key   string `json:"key" yaml:"key"`
+	Value string `json:"value" yaml:"value"`
+}
+
+// DoctorSummary counts the findings
+type DoctorSummary struct {
+	Passed    int  `json:"passed" yaml:"passed"`
+	Problems  int  `json:"problems" yaml:"problems"`
+	HasErrors bool `json:"hasErrors" yaml:"hasErrors"`
+}
+
+// DoctorFix is the outcome of applying one fix
+type DoctorFix struct {
+	Name    string `json:"name" yaml:"name"`
+	Title   string `json:"title" yaml:"title"`
+	Applied bool   `json:"applied" yaml:"applied"`
+	Error   string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// runDoctorStructured runs the checks and writes the report in the --output
+// format. Fixes can't be confirmed interactively here, so --fix needs --yes.
+func runDoctorStructured() {
+	if doctorFix && !doctorYes {
+		reportError("--fix requires --yes when --output is json or yaml")
+		return
+	}
+
+	result := doctor.RunAll()
+	report := doctorOutput(result)
+
+	if doctorFix {
+		for _, cr := range result.Fixable() {
+			fix := DoctorFix{Name: cr.Check.Name(), Title: cr.Finding.Title, Applied: true}
+			if err := cr.Finding.Fix(); err != nil {
+				fix.Applied = false
+				fix.Error = err.Error()
+			}
+			report.Fixes = append(report.Fixes, fix)
+		}
+	}
+
+	printResult(report)
+	if result.HasErrors() {
+		os.Exit(1)
+	}
+}
+
+// doctorOutput converts doctor results to the structured report
+func doctorOutput(r doctor.Result) DoctorOutput {
+	report := DoctorOutput{
+		Checks:  make([]DoctorCheck, 0, len(r.Results)),
+		Summary: DoctorSummary{HasErrors: r.HasErrors()},
+	}
+
+	for _, cr := range r.Results {
+		check := DoctorCheck{
+			Name:       cr.Check.Name(),
+			Title:      cr.Finding.Title,
+			OK:         cr.Finding.OK,
+			Fixable:    !cr.Finding.OK && cr.Finding.Fixable(),
+			Resolution: cr.Finding.Resolution,
+		}
+		if cr.Finding.OK {
+			report.Summary.Passed++
+		} else {
+			report.Summary.Problems++
+			check.Severity = cr.Finding.Severity.String()
+		}
+		for _, d := range cr.Finding.Details {
+			check.Details = append(check.Details, DoctorDetail{Key: d.Key, Value: d.Value})
+		}
+		report.Checks = append(report.Checks, check)
+	}
+
+	return report
+}
+
 func init() {
 	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Interactively apply fixes for fixable findings")
 	doctorCmd.Flags().BoolVarP(&doctorYes, "yes", "y", false, "Skip prompts when --fix is set; apply all fixable findings")
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/doctor"
+)
+
+// stubCheck is a doctor check with a fixed name
+type stubCheck struct{ name string }
+
+func (c stubCheck) Name() string        { return c.name }
+func (c stubCheck) Run() doctor.Finding { return doctor.Finding{} }
+
+func TestDoctorOutput(t *testing.T) {
+	result := doctor.Result{Results: []doctor.CheckResult{
+		{Check: stubCheck{"shims-in-path"}, Finding: doctor.Finding{OK: true, Title: "Shims directory is on PATH"}},
+		{Check: stubCheck{"stale-shims-path"}, Finding: doctor.Finding{
+			Severity:   doctor.SeverityError,
+			Title:      "Stale shims directory on PATH",
+			Details:    []doctor.Detail{{Key: "Found", Value: "/old/shims"}},
+			Resolution: "Remove the stale entry",
+			Fix:        func() error { return nil },
+		}},
+	}}
+
+	report := doctorOutput(result)
+
+	if report.Summary != (DoctorSummary{Passed: 1, Problems: 1, HasErrors: true}) {
+		t.Errorf("Summary = %+v, want 1 passed, 1 problem with errors", report.Summary)
+	}
+	if len(report.Checks) != 2 {
+		t.Fatalf("Checks = %d, want 2", len(report.Checks))
+	}
+
+	passed := report.Checks[0]
+	if passed.Name != "shims-in-path" || !passed.OK || passed.Severity != "" || passed.Fixable {
+		t.Errorf("passing check = %+v, want OK with no severity", passed)
+	}
+
+	failed := report.Checks[1]
+	if failed.OK || failed.Severity != "error" || !failed.Fixable {
+		t.Errorf("failing check = %+v, want a fixable error", failed)
+	}
+	if len(failed.Details) != 1 || failed.Details[0] != (DoctorDetail{Key: "Found", Value: "/old/shims"}) {
+		t.Errorf("Details = %+v, want the finding details", failed.Details)
+	}
+}
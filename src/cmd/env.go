@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/path"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var envShell string
+
+var envCmd = &cobra.Command{
+	Use:     "env",
+	Aliases: []string{"activate"},
+	Short:   "Print shell commands that activate the current versions",
+	Long: `Print the shell commands that put the versions configured for the current
+directory first on PATH and set the environment each runtime needs, as an
+alternative to shims.
+
+Evaluating the output lets commands such as node and python run directly from
+the installed version, without starting a shim process for every call. The
+output also undoes what the previous evaluation added, so it is safe to
+evaluate repeatedly; 'dtvem hook' installs a shell hook that does this before
+every prompt.
+
+Runtimes without a configured version, or whose version is not installed, are
+left to the shims.
+
+Examples:
+  eval "$(dtvem env --shell bash)"
+  dtvem env --shell fish | source
+  dtvem env --shell pwsh | Out-String | Invoke-Expression`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		// stdout is evaluated by the shell; keep messages off it
+		ui.SetStderrMode(true)
+
+		shell, err := resolveShellFlag(envShell)
+		if err != nil {
+			ui.Error("%v", err)
+			os.Exit(1)
+		}
+
+		additions, err := envAdditions(activeSelections())
+		if err != nil {
+			ui.Error("%v", err)
+			os.Exit(1)
+		}
+
+		script, err := path.FormatShellEnv(shell, path.ShellEnvChanges(os.LookupEnv, additions))
+		if err != nil {
+			ui.Error("%v", err)
+			os.Exit(1)
+		}
+		fmt.Print(script)
+	},
+}
+
+// activeSelections returns the installed version configured for the current
+// directory of each runtime. Runtimes that resolve to nothing are skipped
+// quietly, since this runs before every prompt. Selections are ordered by
+// runtime name so the output is stable between evaluations.
+func activeSelections() []execSelection {
+	providers := runtime.GetAll()
+	sort.Slice(providers, func(i, j int) bool { return providers[i].Name() < providers[j].Name() })
+
+	var selections []execSelection
+	for _, provider := range providers {
+		resolved, err := config.ResolveVersionSource(provider.Name())
+		if err != nil {
+			ui.Debug("No %s version configured: %v", provider.Name(), err)
+			continue
+		}
+
+		selection, err := resolveExecSelection(provider, resolved.Version)
+		if err != nil {
+			ui.Debug("Skipping %s: %v", provider.Name(), err)
+			continue
+		}
+		selections = append(selections, selection)
+	}
+	return selections
+}
+
+// resolveShellFlag returns the shell given with --shell, or the detected
+// shell if the flag is empty
+func resolveShellFlag(shell string) (string, error) {
+	if shell != "" {
+		return path.NormalizeShell(shell)
+	}
+
+	detected := path.DetectShell()
+	normalized, err := path.NormalizeShell(detected)
+	if err != nil {
+		return "", fmt.Errorf("could not detect a supported shell (found %s); use --shell", detected)
+	}
+	return normalized, nil
+}
+
+func init() {
+	envCmd.Flags().StringVar(&envShell, "shell", "", "Shell to print commands for: bash, zsh, fish or pwsh (default: detected)")
+	rootCmd.AddCommand(envCmd)
+}
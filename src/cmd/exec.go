@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/shim"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var execWith []string
+
+var execCmd = &cobra.Command{
+	Use:   "exec --with <runtime>@<version> [--with ...] -- <command> [args...]",
+	Short: "Run a command with specific runtime versions",
+	Long: `Run a single command with the given runtime versions, without changing any
+configuration.
+
+Each --with puts that version's executables first on PATH, sets the environment
+the runtime needs, and sets DTVEM_<RUNTIME>_VERSION so shims invoked by the
+command (e.g., from npm scripts) resolve to the same version. Versions may be
+exact, a range such as 18 or ^3.12, or an alias such as lts/iron, and must be
+installed.
+
+The same override is available without dtvem exec by setting the variable
+yourself, e.g. DTVEM_NODE_VERSION=18 npm test.
+
+Examples:
+  dtvem exec --with node@18 -- npm test
+  dtvem exec --with node@20 --with python@3.12 -- make build
+  dtvem exec --with ruby@3.3 -- bundle exec rake`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(execWith) == 0 {
+			execFail("At least one --with <runtime>@<version> is required",
+				"Example: dtvem exec --with node@18 -- npm test")
+		}
+
+		var selections []execSelection
+		seen := make(map[string]bool)
+		for _, with := range execWith {
+			runtimeName, spec, err := parseWithSpec(with)
+			if err != nil {
+				execFail(err.Error())
+			}
+			if seen[runtimeName] {
+				execFail(fmt.Sprintf("%s is given more than once with --with", runtimeName))
+			}
+			seen[runtimeName] = true
+
+			provider, err := runtime.Get(runtimeName)
+			if err != nil {
+				execFail(fmt.Sprintf("Unknown runtime: %s", runtimeName), fmt.Sprintf("Available runtimes: %v", runtime.List()))
+			}
+
+			selection, err := resolveExecSelection(provider, spec)
+			if err != nil {
+				execFail(err.Error(), fmt.Sprintf("To install, run: dtvem install %s %s", runtimeName, spec))
+			}
+			ui.Debug("Using %s %s", provider.DisplayName(), selection.version)
+			selections = append(selections, selection)
+		}
+
+		env, err := execEnvironment(os.Environ(), selections)
+		if err != nil {
+			execFail(err.Error())
+		}
+
+		os.Exit(runExecCommand(args[0], args[1:], env))
+	},
+}
+
+// execSelection is a runtime version chosen with --with
+type execSelection struct {
+	provider runtime.ShimProvider
+	version  string
+}
+
+// parseWithSpec splits a --with value of the form <runtime>@<version>
+func parseWithSpec(with string) (runtimeName, spec string, err error) {
+	runtimeName, spec, ok := strings.Cut(strings.TrimSpace(with), "@")
+	runtimeName = strings.ToLower(strings.TrimSpace(runtimeName))
+	spec = strings.TrimSpace(spec)
+	if !ok || runtimeName == "" || spec == "" {
+		return "", "", fmt.Errorf("invalid --with %q (expected <runtime>@<version>, e.g. node@18)", with)
+	}
+	return runtimeName, spec, nil
+}
+
+// resolveExecSelection resolves a version spec to an installed version
+func resolveExecSelection(provider runtime.ShimProvider, spec string) (execSelection, error) {
+	version, err := runtime.ResolveInstalledVersion(provider, spec)
+	if err != nil {
+		return execSelection{}, fmt.Errorf("no installed %s version satisfies %s", provider.DisplayName(), spec)
+	}
+
+	installed, err := provider.IsInstalled(version)
+	if err != nil {
+		return execSelection{}, fmt.Errorf("could not check if %s %s is installed: %w", provider.DisplayName(), version, err)
+	}
+	if !installed {
+		return execSelection{}, fmt.Errorf("%s %s is not installed", provider.DisplayName(), version)
+	}
+
+	return execSelection{provider: provider, version: version}, nil
+}
+
+// execEnvironment builds the child environment: each selection's executable
+// directories are prepended to PATH in --with order, provider environment
+// variables are applied, and the DTVEM_<RUNTIME>_VERSION overrides replace
+// any inherited value.
+func execEnvironment(baseEnv []string, selections []execSelection) ([]string, error) {
+	additions, err := envAdditions(selections)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := make(map[string]string, len(selections))
+	for _, s := range selections {
+		overrides[config.VersionEnvVar(s.provider.Name())] = s.version
+	}
+
+	// Drop inherited overrides so MergeEnvironment doesn't treat them as
+	// PATH-like lists
+	filtered := make([]string, 0, len(baseEnv))
+	for _, e := range baseEnv {
+		key, _, _ := strings.Cut(e, "=")
+		if _, ok := overrides[key]; !ok {
+			filtered = append(filtered, e)
+		}
+	}
+
+	providerEnv := make(map[string]string, len(additions))
+	for key, entries := range additions {
+		providerEnv[key] = strings.Join(entries, string(filepath.ListSeparator))
+	}
+
+	env := runtime.MergeEnvironment(filtered, providerEnv)
+	for key, value := range overrides {
+		env = append(env, key+"="+value)
+	}
+	return env, nil
+}
+
+// envAdditions returns the entries the selected versions prepend to each
+// list variable: their executable directories for PATH, followed by the
+// provider environment (e.g., LD_LIBRARY_PATH for Ruby), in selection order
+func envAdditions(selections []execSelection) (map[string][]string, error) {
+	additions := make(map[string][]string)
+	var binDirs []string
+
+	for _, s := range selections {
+		dirs := shim.VersionBinDirs(config.RuntimeVersionPath(s.provider.Name(), s.version))
+		if len(dirs) == 0 {
+			// Fall back to the directory of the main executable for
+			// layouts without a bin/ directory
+			execPath, err := s.provider.ExecutablePath(s.version)
+			if err != nil {
+				return nil, fmt.Errorf("could not find %s %s executable: %w", s.provider.DisplayName(), s.version, err)
+			}
+			dirs = []string{filepath.Dir(execPath)}
+		}
+		binDirs = append(binDirs, dirs...)
+
+		env, err := s.provider.GetEnvironment(s.version)
+		if err != nil {
+			ui.Debug("Failed to get %s environment: %v", s.provider.Name(), err)
+			continue
+		}
+		for key, value := range env {
+			additions[key] = append(additions[key], value)
+		}
+	}
+
+	if len(binDirs) > 0 {
+		additions["PATH"] = append(binDirs, additions["PATH"]...)
+	}
+	return additions, nil
+}
+
+// runExecCommand runs the command with the given environment and returns
+// its exit code. The command is looked up on the environment's PATH, so the
+// selected versions win over shims.
+func runExecCommand(name string, args []string, env []string) int {
+	for _, e := range env {
+		if key, value, _ := strings.Cut(e, "="); strings.EqualFold(key, "PATH") {
+			_ = os.Setenv(key, value)
+		}
+	}
+
+	execPath, err := exec.LookPath(name)
+	if err != nil {
+		ui.Error("Command not found: %s", name)
+		return 127
+	}
+	ui.Debug("Executing %s", execPath)
+
+	child := exec.Command(execPath, args...)
+	child.Env = env
+	child.Stdin = os.Stdin
+	child.Stdout = os.Stdout
+	child.Stderr = os.Stderr
+
+	// Interrupts reach the child directly from the terminal; keep dtvem
+	// alive until the child exits so its exit code is propagated
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	defer signal.Stop(signals)
+
+	if err := child.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode()
+		}
+		ui.Error("Failed to run %s: %v", name, err)
+		return 1
+	}
+	return 0
+}
+
+// execFail reports an error and exits with a non-zero code, since the exit
+// code of dtvem exec is normally the child's
+func execFail(message string, hints ...string) {
+	reportError(message, hints...)
+	os.Exit(1)
+}
+
+func init() {
+	execCmd.Flags().StringArrayVar(&execWith, "with", nil, "Runtime version to use, as <runtime>@<version> (repeatable)")
+	execCmd.Flags().SetInterspersed(false)
+	rootCmd.AddCommand(execCmd)
+}
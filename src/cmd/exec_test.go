@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/shim"
+)
+
+// execTestProvider is a mockProvider whose versions are all installed
+type execTestProvider struct {
+	mockProvider
+	env map[string]string
+}
+
+func (p *execTestProvider) IsInstalled(string) (bool, error) { return true, nil }
+func (p *execTestProvider) GetEnvironment(string) (map[string]string, error) {
+	return p.env, nil
+}
+
+func TestParseWithSpec(t *testing.T) {
+	tests := []struct {
+		input   string
+		runtime string
+		spec    string
+		wantErr bool
+	}{
+		{"node@18", "node", "18", false},
+		{"Python@3.12", "python", "3.12", false},
+		{"node@lts/iron", "node", "lts/iron", false},
+		{"node", "", "", true},
+		{"@18", "", "", true},
+		{"node@", "", "", true},
+	}
+
+	for _, tt := range tests {
+		runtimeName, spec, err := parseWithSpec(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseWithSpec(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if runtimeName != tt.runtime || spec != tt.spec {
+			t.Errorf("parseWithSpec(%q) = %q, %q; want %q, %q", tt.input, runtimeName, spec, tt.runtime, tt.spec)
+		}
+	}
+}
+
+func TestExecEnvironment(t *testing.T) {
+	t.Setenv("DTVEM_ROOT", t.TempDir())
+	config.ResetPathsCache()
+	t.Cleanup(config.ResetPathsCache)
+
+	nodeBin := filepath.Join(config.RuntimeVersionPath("node", "18.20.0"), "bin")
+	pythonBin := filepath.Join(config.RuntimeVersionPath("python", "3.12.1"), "bin")
+	for _, dir := range []string{nodeBin, pythonBin} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	node := &execTestProvider{mockProvider: mockProvider{name: "node", displayName: "Node.js"}}
+	python := &execTestProvider{
+		mockProvider: mockProvider{name: "python", displayName: "Python"},
+		env:          map[string]string{"PYTHONNOUSERSITE": "1"},
+	}
+
+	sep := string(filepath.ListSeparator)
+	base := []string{"PATH=/usr/bin", "DTVEM_NODE_VERSION=22", "HOME=/home/dev"}
+	env, err := execEnvironment(base, []execSelection{
+		{provider: node, version: "18.20.0"},
+		{provider: python, version: "3.12.1"},
+	})
+	if err != nil {
+		t.Fatalf("execEnvironment() error: %v", err)
+	}
+
+	vars := make(map[string][]string)
+	for _, e := range env {
+		key, value, _ := strings.Cut(e, "=")
+		vars[key] = append(vars[key], value)
+	}
+
+	dirs := append(shim.VersionBinDirs(config.RuntimeVersionPath("node", "18.20.0")),
+		shim.VersionBinDirs(config.RuntimeVersionPath("python", "3.12.1"))...)
+	wantPath := strings.Join(append(dirs, "/usr/bin"), sep)
+	if got := vars["PATH"]; len(got) != 1 || got[0] != wantPath {
+		t.Errorf("PATH = %v, want %q", got, wantPath)
+	}
+	if got := vars["DTVEM_NODE_VERSION"]; len(got) != 1 || got[0] != "18.20.0" {
+		t.Errorf("DTVEM_NODE_VERSION = %v, want the inherited value replaced by 18.20.0", got)
+	}
+	if got := vars["DTVEM_PYTHON_VERSION"]; len(got) != 1 || got[0] != "3.12.1" {
+		t.Errorf("DTVEM_PYTHON_VERSION = %v, want 3.12.1", got)
+	}
+	if got := vars["PYTHONNOUSERSITE"]; len(got) != 1 || got[0] != "1" {
+		t.Errorf("PYTHONNOUSERSITE = %v, want the provider environment applied", got)
+	}
+	if got := vars["HOME"]; len(got) != 1 || got[0] != "/home/dev" {
+		t.Errorf("HOME = %v, want it inherited", got)
+	}
+}
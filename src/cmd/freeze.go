@@ -10,6 +10,7 @@ import (
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/filelock"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
 	"github.com/spf13/cobra"
@@ -154,7 +155,7 @@ Examples:
 			return
 		}
 
-		if err := os.WriteFile(configPath, data, 0644); err != nil {
+		if err := filelock.WriteFile(configPath, data, 0644); err != nil {
 			ui.Error("Failed to write config file: %v", err)
 			return
 		}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/venv"
 	"github.com/spf13/cobra"
 )
 
@@ -15,17 +16,30 @@ func setRuntimeVersion(runtimeName, version, scope string, setter func(string) e
 		return
 	}
 
-	// Validate that the version is installed
-	installed, err := provider.IsInstalled(version)
-	if err != nil {
-		ui.Error("Failed to check if version is installed: %v", err)
-		return
-	}
-	if !installed {
-		ui.Error("%s %s is not installed", provider.DisplayName(), version)
-		ui.Info("Run 'dtvem list %s' to see installed versions", runtimeName)
-		ui.Info("Run 'dtvem install %s %s' to install it first", runtimeName, version)
-		return
+	// Ranges and aliases are stored as given, but must be satisfied by an
+	// installed version so the shim has something to run
+	if runtime.IsVersionSpec(version) {
+		resolved, err := runtime.ResolveInstalledVersion(provider, version)
+		if err != nil {
+			ui.Error("No installed %s version satisfies %s", provider.DisplayName(), version)
+			ui.Info("Run 'dtvem list %s' to see installed versions", runtimeName)
+			ui.Info("Run 'dtvem install %s %q' to install a matching version first", runtimeName, version)
+			return
+		}
+		ui.Info("%s currently resolves to %s", version, resolved)
+	} else {
+		// Validate that the version is installed
+		installed, err := provider.IsInstalled(version)
+		if err != nil {
+			ui.Error("Failed to check if version is installed: %v", err)
+			return
+		}
+		if !installed {
+			ui.Error("%s %s is not installed", provider.DisplayName(), version)
+			ui.Info("Run 'dtvem list %s' to see installed versions", runtimeName)
+			ui.Info("Run 'dtvem install %s %s' to install it first", runtimeName, version)
+			return
+		}
 	}
 
 	ui.Info("Setting %s %s version to %s...", scope, provider.DisplayName(), version)
@@ -36,6 +50,10 @@ func setRuntimeVersion(runtimeName, version, scope string, setter func(string) e
 	}
 
 	ui.Success("Successfully set %s %s version to %s", scope, provider.DisplayName(), version)
+
+	if runtimeName == venv.Runtime {
+		warnStaleVenv()
+	}
 }
 
 var globalCmd = &cobra.Command{
@@ -44,9 +62,14 @@ var globalCmd = &cobra.Command{
 	Long: `Set the global default version for a runtime.
 This version will be used when no local version is specified.
 
+The version may also be a range or alias, resolved against installed
+versions each time a shim runs.
+
 Examples:
   dtvem global python 3.11.0
-  dtvem global node 18.16.0`,
+  dtvem global node 18.16.0
+  dtvem global node "^20.10"
+  dtvem global node lts/iron`,
 	Args: cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		runtimeName := args[0]
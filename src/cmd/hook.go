@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/path"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	hookShell     string
+	hookPrint     bool
+	hookUninstall bool
+)
+
+var hookCmd = &cobra.Command{
+	Use:   "hook",
+	Short: "Install a shell hook that activates versions without shims",
+	Long: `Install a hook into your shell's startup file that evaluates 'dtvem env'
+before every prompt, so the versions configured for the current directory are
+first on PATH and run without a shim process.
+
+The shims directory can stay on PATH: the hook's entries come first, and the
+shims still serve runtimes with no version configured for the directory.
+
+Supported shells are bash, zsh, fish and pwsh. The hook is added to
+~/.bashrc, ~/.zshrc, ~/.config/fish/config.fish or your PowerShell profile,
+marked with a "# Added by dtvem" comment.
+
+Examples:
+  dtvem hook                        # Install for the detected shell
+  dtvem hook --shell zsh            # Install for zsh
+  dtvem hook --print --shell bash   # Print the hook script instead
+  dtvem hook --uninstall            # Remove the hook`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		shell, err := resolveShellFlag(hookShell)
+		if err != nil {
+			ui.Error("%v", err)
+			os.Exit(1)
+		}
+
+		dtvemPath, err := os.Executable()
+		if err != nil {
+			ui.Error("Could not determine the dtvem executable: %v", err)
+			os.Exit(1)
+		}
+
+		if hookPrint {
+			// stdout is evaluated by the shell; keep messages off it
+			ui.SetStderrMode(true)
+			script, err := path.HookScript(shell, dtvemPath)
+			if err != nil {
+				ui.Error("%v", err)
+				os.Exit(1)
+			}
+			fmt.Print(script)
+			return
+		}
+
+		configFile, err := path.HookConfigFile(shell)
+		if err != nil {
+			ui.Error("%v", err)
+			os.Exit(1)
+		}
+
+		if hookUninstall {
+			backup, err := path.UninstallShellHook(configFile)
+			if err != nil {
+				ui.Error("Failed to remove the shell hook: %v", err)
+				os.Exit(1)
+			}
+			if backup == "" {
+				ui.Info("No dtvem shell hook found in %s", configFile)
+				return
+			}
+			ui.Success("Removed the dtvem shell hook from %s", configFile)
+			ui.Info("Backup: %s (restart your shell for the change to take effect)", backup)
+			return
+		}
+
+		installed, err := path.InstallShellHook(configFile, shell, dtvemPath)
+		if err != nil {
+			ui.Error("Failed to install the shell hook: %v", err)
+			os.Exit(1)
+		}
+		if !installed {
+			ui.Info("The dtvem shell hook is already installed in %s", configFile)
+			return
+		}
+		ui.Success("Installed the dtvem shell hook in %s", configFile)
+		ui.Info("Restart your shell, or run: %s", ui.Highlight(sourceCommand(shell, configFile)))
+	},
+}
+
+// sourceCommand returns the command that loads configFile into the running shell
+func sourceCommand(shell, configFile string) string {
+	if shell == constants.ShellPwsh {
+		return ". " + configFile
+	}
+	return "source " + configFile
+}
+
+func init() {
+	hookCmd.Flags().StringVar(&hookShell, "shell", "", "Shell to install the hook for: bash, zsh, fish or pwsh (default: detected)")
+	hookCmd.Flags().BoolVar(&hookPrint, "print", false, "Print the hook script instead of installing it")
+	hookCmd.Flags().BoolVar(&hookUninstall, "uninstall", false, "Remove the hook from the shell's startup file")
+	hookCmd.MarkFlagsMutuallyExclusive("print", "uninstall")
+	rootCmd.AddCommand(hookCmd)
+}
@@ -74,8 +74,9 @@ Example:
 		if userInstall {
 			installType = config.InstallTypeUser
 		}
-		settings := &config.Settings{InstallType: installType}
-		if err := config.SaveSettings(settings); err != nil {
+		if err := config.UpdateSettings(func(settings *config.Settings) {
+			settings.InstallType = installType
+		}); err != nil {
 			ui.Warning("Failed to save settings: %v", err)
 		}
 
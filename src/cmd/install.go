@@ -3,18 +3,23 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/tui"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/version"
 	"github.com/spf13/cobra"
 )
 
 var (
-	installYesFlag bool
+	installYesFlag  bool
+	installJobsFlag int
 )
 
 var installCmd = &cobra.Command{
@@ -30,9 +35,27 @@ Single install (partial version - resolves to latest match):
   dtvem install node 22       # Installs latest 22.x.x (e.g., 22.15.0)
   dtvem install python 3.12   # Installs latest 3.12.x (e.g., 3.12.1)
 
+Single install (range or alias - resolves to latest match):
+  dtvem install node "^20.10"            # Latest 20.x.x at or above 20.10.0
+  dtvem install python ">=3.11,<3.13"    # Latest 3.11.x or 3.12.x
+  dtvem install node lts/iron            # Latest Node.js 20 (Iron) release
+  dtvem install ruby latest              # Newest available release
+
 Bulk install (reads .dtvem/runtimes.json):
   dtvem install
-  dtvem install --yes    # Skip confirmation prompt`,
+  dtvem install --yes    # Skip confirmation prompt
+  dtvem install -j 2     # Install at most 2 runtimes at a time
+
+Bulk installs download and extract runtimes concurrently and finish with
+a summary of each runtime's result.
+
+Downloaded archives are kept in the download cache (see 'dtvem cache') and
+reused by later installs. With --offline, only cached archives are used:
+  dtvem install --offline
+
+Shims can install a missing configured version on first use and then run the
+command, so fresh clones work without a separate install step. Enable this with
+"shimAutoInstall": true in settings.json or DTVEM_AUTO_INSTALL=true.`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) == 0 || len(args) == 2 {
 			return nil
@@ -53,6 +76,7 @@ Bulk install (reads .dtvem/runtimes.json):
 func init() {
 	rootCmd.AddCommand(installCmd)
 	installCmd.Flags().BoolVarP(&installYesFlag, "yes", "y", false, "Skip confirmation prompt")
+	installCmd.Flags().IntVarP(&installJobsFlag, "jobs", "j", defaultInstallJobs, "Maximum number of runtimes to install concurrently")
 }
 
 // installSingle installs a single runtime/version
@@ -113,12 +137,13 @@ func autoSetGlobalIfNeeded(provider runtime.Provider, ver string) {
 	ui.Info("Set as global version (first install)")
 }
 
-// resolveVersionForProvider resolves a partial version input to a full version.
+// resolveVersionForProvider resolves a version spec to a full version from the manifest.
 // If the input is already a full version (3 components), it's returned as-is.
-// For partial versions (1-2 components), it finds the highest matching version.
+// Partial versions ("22"), ranges ("^20.10", ">=3.11,<3.13") and aliases
+// ("lts/iron", "latest") resolve to the highest matching available version.
 func resolveVersionForProvider(provider runtime.Provider, input string) (string, error) {
 	// If it's already a full version, return as-is
-	if !version.IsPartialVersion(input) {
+	if version.IsExactVersion(input) {
 		return strings.TrimPrefix(input, "v"), nil
 	}
 
@@ -134,13 +159,7 @@ func resolveVersionForProvider(provider runtime.Provider, input string) (string,
 		versionStrings[i] = av.Version.Raw
 	}
 
-	// Resolve the partial version
-	resolved, err := version.ResolvePartialVersion(input, versionStrings)
-	if err != nil {
-		return "", fmt.Errorf("no %s version matching %q found", provider.DisplayName(), input)
-	}
-
-	return resolved, nil
+	return runtime.ResolveVersionSpec(provider, input, versionStrings)
 }
 
 // installBulk installs all runtimes from .dtvem/runtimes.json
@@ -152,19 +171,31 @@ type installTask struct {
 	alreadyInstalled bool
 }
 
-// buildInstallTasks creates a list of install tasks from the config
+// buildInstallTasks creates a list of install tasks from the config, sorted
+// by runtime name so the plan and summary are listed in a stable order
 func buildInstallTasks(runtimes map[string]string) []installTask {
 	var tasks []installTask
 
+	runtimeNames := make([]string, 0, len(runtimes))
+	for runtimeName := range runtimes {
+		runtimeNames = append(runtimeNames, runtimeName)
+	}
+	sort.Strings(runtimeNames)
+
 	ui.Info("Checking which versions need to be installed...")
-	for runtimeName, version := range runtimes {
+	for _, runtimeName := range runtimeNames {
+		spec := runtimes[runtimeName]
 		provider, err := runtime.Get(runtimeName)
 		if err != nil {
 			ui.Warning("Unknown runtime '%s', skipping", runtimeName)
 			continue
 		}
 
-		alreadyInstalled := isVersionInstalled(provider, version)
+		version, alreadyInstalled, err := resolveInstallTarget(provider, spec)
+		if err != nil {
+			ui.Warning("%v, skipping", err)
+			continue
+		}
 
 		tasks = append(tasks, installTask{
 			runtimeName:      runtimeName,
@@ -177,6 +208,29 @@ func buildInstallTasks(runtimes map[string]string) []installTask {
 	return tasks
 }
 
+// resolveInstallTarget resolves a configured version spec to a concrete version.
+// Ranges and aliases prefer an installed match, and otherwise resolve against
+// the manifest. Exact versions are used as-is.
+func resolveInstallTarget(provider runtime.Provider, spec string) (string, bool, error) {
+	if !runtime.IsVersionSpec(spec) {
+		version := strings.TrimPrefix(spec, "v")
+		return version, isVersionInstalled(provider, version), nil
+	}
+
+	if installed, err := runtime.ResolveInstalledVersion(provider, spec); err == nil {
+		return installed, true, nil
+	}
+
+	resolved, err := resolveVersionForProvider(provider, spec)
+	if err != nil {
+		return "", false, err
+	}
+	if resolved != spec {
+		ui.Info("Resolved %s %s to %s", provider.DisplayName(), spec, resolved)
+	}
+	return resolved, false, nil
+}
+
 // isVersionInstalled checks if a specific version is already installed
 func isVersionInstalled(provider runtime.Provider, version string) bool {
 	installedVersions, err := provider.ListInstalled()
@@ -226,52 +280,137 @@ func promptInstallConfirmation(toInstallCount, alreadyInstalledCount int) bool {
 	return response == "" || response == constants.ResponseY || response == constants.ResponseYes
 }
 
-// executeInstalls installs all tasks and returns counts and failures
-func executeInstalls(tasks []installTask) (success, failures int, failureList []string) {
+// defaultInstallJobs is the default number of runtimes installed concurrently
+const defaultInstallJobs = 4
+
+// installResult records the outcome of a single bulk install task
+type installResult struct {
+	task     installTask
+	err      error
+	duration time.Duration
+}
+
+// executeInstalls installs all pending tasks concurrently, at most jobs at a
+// time, showing one progress line per task. Returns a result for every task,
+// in the same order, including tasks that were already installed.
+func executeInstalls(tasks []installTask, jobs int) []installResult {
 	ui.Header("\nInstalling runtimes...")
 
-	for _, task := range tasks {
+	var pending []int
+	progress := ui.NewMultiProgress()
+	reporters := make([]*ui.ProgressTask, len(tasks))
+	for i, task := range tasks {
 		if task.alreadyInstalled {
 			continue
 		}
+		pending = append(pending, i)
+		reporters[i] = progress.AddTask(fmt.Sprintf("%s %s", task.provider.DisplayName(), task.version))
+	}
 
-		ui.Progress("Installing %s %s...", task.provider.DisplayName(), task.version)
+	results := make([]installResult, len(tasks))
+	for i, task := range tasks {
+		results[i].task = task
+	}
 
-		if err := task.provider.Install(task.version); err != nil {
-			ui.Error("Failed to install %s %s: %v", task.provider.DisplayName(), task.version, err)
-			failures++
-			failureList = append(failureList, fmt.Sprintf("%s %s", task.provider.DisplayName(), task.version))
+	progress.Start()
+	runConcurrently(len(pending), jobs, func(n int) {
+		i := pending[n]
+		task := tasks[i]
+		reporter := reporters[i]
+
+		start := time.Now()
+		err := installWithProgress(task, reporter)
+		results[i].err = err
+		results[i].duration = time.Since(start)
+
+		if err != nil {
+			reporter.Fail(err.Error())
 		} else {
-			ui.Success("Installed %s %s", task.provider.DisplayName(), task.version)
-			success++
-			// Auto-set global version if needed
-			autoSetGlobalIfNeeded(task.provider, task.version)
+			reporter.Done(fmt.Sprintf("Installed in %s", formatInstallDuration(results[i].duration)))
+		}
+	})
+	progress.Stop()
+
+	// Global config writes happen after all installs so concurrent tasks
+	// never race on the same file
+	for _, result := range results {
+		if !result.task.alreadyInstalled && result.err == nil {
+			autoSetGlobalIfNeeded(result.task.provider, result.task.version)
 		}
 	}
 
-	return success, failures, failureList
+	return results
+}
+
+// installWithProgress installs a task, reporting to reporter when the
+// provider supports it
+func installWithProgress(task installTask, reporter ui.ProgressReporter) error {
+	if installer, ok := task.provider.(runtime.ProgressInstaller); ok {
+		return installer.InstallWithProgress(task.version, reporter)
+	}
+	reporter.Status("Installing...")
+	return task.provider.Install(task.version)
 }
 
-// showInstallSummary displays the final installation summary
-func showInstallSummary(successCount, alreadyInstalledCount, failureCount int, failures []string) {
-	ui.Header("\nInstallation Summary:")
+// runConcurrently calls fn for every index in [0, n), running at most jobs
+// calls at a time, and returns once all calls have finished
+func runConcurrently(n, jobs int, fn func(i int)) {
+	if jobs < 1 {
+		jobs = 1
+	}
 
-	if successCount > 0 {
-		ui.Success("Successfully installed: %d runtime(s)", successCount)
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, jobs)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
 	}
-	if alreadyInstalledCount > 0 {
-		ui.Info("Already installed: %d runtime(s)", alreadyInstalledCount)
+	wg.Wait()
+}
+
+// formatInstallDuration rounds a duration for display in install output
+func formatInstallDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
 	}
-	if failureCount > 0 {
-		ui.Error("Failed to install: %d runtime(s)", failureCount)
-		for _, failure := range failures {
-			ui.Error("  - %s", failure)
+	return d.Round(100 * time.Millisecond).String()
+}
+
+// showInstallSummary displays a per-runtime summary table and returns the
+// number of failed installs
+func showInstallSummary(results []installResult) int {
+	table := tui.NewTable("Runtime", "Version", "Result")
+	table.SetTitle("Installation Summary")
+
+	failures := 0
+	for _, result := range results {
+		name := result.task.provider.DisplayName()
+		switch {
+		case result.task.alreadyInstalled:
+			table.AddRow(name, result.task.version, "already installed")
+		case result.err != nil:
+			failures++
+			table.AddRow(name, result.task.version, "failed: "+result.err.Error())
+		default:
+			table.AddActiveRow(name, result.task.version, "installed in "+formatInstallDuration(result.duration))
 		}
 	}
 
-	if failureCount == 0 {
-		ui.Success("\n✓ All runtimes installed successfully!")
+	fmt.Println()
+	fmt.Println(table.Render())
+
+	if failures == 0 {
+		ui.Success("All runtimes installed successfully!")
+	} else {
+		ui.Error("Failed to install: %d runtime(s)", failures)
 	}
+
+	return failures
 }
 
 func installBulk() {
@@ -321,13 +460,10 @@ func installBulk() {
 	}
 
 	// Execute installations
-	successCount, failureCount, failures := executeInstalls(tasks)
-
-	// Show final summary
-	showInstallSummary(successCount, alreadyInstalledCount, failureCount, failures)
+	results := executeInstalls(tasks, installJobsFlag)
 
-	// Exit with error if any installations failed
-	if failureCount > 0 {
+	// Show final summary, exiting with an error if any installations failed
+	if failures := showInstallSummary(results); failures > 0 {
 		os.Exit(1)
 	}
 }
@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
 )
@@ -17,15 +21,17 @@ type mockProvider struct {
 	setGlobalCalls    []string
 	availableVersions []runtime.AvailableVersion
 	listAvailableErr  error
+	installErr        error
+	installed         map[string]bool
 }
 
 func (m *mockProvider) Name() string                                          { return m.name }
 func (m *mockProvider) DisplayName() string                                   { return m.displayName }
 func (m *mockProvider) Shims() []string                                       { return []string{m.name} }
 func (m *mockProvider) ExecutablePath(version string) (string, error)         { return "", nil }
-func (m *mockProvider) IsInstalled(version string) (bool, error)              { return false, nil }
+func (m *mockProvider) IsInstalled(version string) (bool, error)              { return m.installed[version], nil }
 func (m *mockProvider) ShouldReshimAfter(shimName string, args []string) bool { return false }
-func (m *mockProvider) Install(version string) error                          { return nil }
+func (m *mockProvider) Install(version string) error                          { return m.installErr }
 func (m *mockProvider) Uninstall(version string) error                        { return nil }
 func (m *mockProvider) ListInstalled() ([]runtime.InstalledVersion, error) {
 	return nil, nil
@@ -277,3 +283,109 @@ func TestResolveVersionForProvider_PythonVersions(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveVersionForProvider_Ranges(t *testing.T) {
+	provider := &mockProvider{
+		name:        "python",
+		displayName: "Python",
+		availableVersions: []runtime.AvailableVersion{
+			makeAvailableVersion("3.10.13"),
+			makeAvailableVersion("3.11.7"),
+			makeAvailableVersion("3.12.1"),
+			makeAvailableVersion("3.13.0"),
+		},
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{">=3.11,<3.13", "3.12.1"},
+		{"~3.11", "3.11.7"},
+		{"^3.10", "3.13.0"},
+		{"latest", "3.13.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := resolveVersionForProvider(provider, tt.input)
+			if err != nil {
+				t.Fatalf("resolveVersionForProvider(%q) returned error: %v", tt.input, err)
+			}
+			if result != tt.expected {
+				t.Errorf("resolveVersionForProvider(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRunConcurrently_RespectsJobLimit(t *testing.T) {
+	var running, peak int32
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+
+	runConcurrently(10, 3, func(i int) {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+
+		mu.Lock()
+		seen[i] = true
+		mu.Unlock()
+	})
+
+	if len(seen) != 10 {
+		t.Errorf("runConcurrently ran %d tasks, want 10", len(seen))
+	}
+	if peak > 3 {
+		t.Errorf("runConcurrently peak concurrency = %d, want <= 3", peak)
+	}
+}
+
+func TestExecuteInstalls_ReportsEachResult(t *testing.T) {
+	node := &mockProvider{name: "node", displayName: "Node.js", globalVersion: "20.0.0"}
+	python := &mockProvider{name: "python", displayName: "Python", installErr: errors.New("download failed")}
+	ruby := &mockProvider{name: "ruby", displayName: "Ruby"}
+
+	tasks := []installTask{
+		{runtimeName: "node", version: "22.0.0", provider: node},
+		{runtimeName: "python", version: "3.12.1", provider: python},
+		{runtimeName: "ruby", version: "3.3.0", provider: ruby, alreadyInstalled: true},
+	}
+
+	results := executeInstalls(tasks, 2)
+
+	if len(results) != len(tasks) {
+		t.Fatalf("executeInstalls returned %d results, want %d", len(results), len(tasks))
+	}
+	if results[0].err != nil {
+		t.Errorf("node result error = %v, want nil", results[0].err)
+	}
+	if results[1].err == nil {
+		t.Error("python result error = nil, want failure")
+	}
+	if !results[2].task.alreadyInstalled {
+		t.Error("ruby result should be marked already installed")
+	}
+
+	// Global is only auto-set for successful installs with no global version
+	if len(node.setGlobalCalls) != 0 {
+		t.Errorf("node SetGlobalVersion calls = %v, want none", node.setGlobalCalls)
+	}
+	if len(python.setGlobalCalls) != 0 {
+		t.Errorf("python SetGlobalVersion calls = %v, want none after failure", python.setGlobalCalls)
+	}
+	if len(ruby.setGlobalCalls) != 0 {
+		t.Errorf("ruby SetGlobalVersion calls = %v, want none when already installed", ruby.setGlobalCalls)
+	}
+
+	if failures := showInstallSummary(results); failures != 1 {
+		t.Errorf("showInstallSummary failures = %d, want 1", failures)
+	}
+}
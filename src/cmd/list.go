@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/lifecycle"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/output"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/tui"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
@@ -21,10 +23,14 @@ var listCmd = &cobra.Command{
 	Short: "List installed versions",
 	Long: `List all installed versions of a specific runtime, or all runtimes if none specified.
 
+With --output json or yaml, every registered runtime is listed, including
+those with no versions installed.
+
 Examples:
   dtvem list           # List all installed versions
   dtvem list python    # List installed Python versions
-  dtvem list node      # List installed Node.js versions`,
+  dtvem list node      # List installed Node.js versions
+  dtvem list -o json   # List installed versions as JSON`,
 	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) == 0 {
@@ -35,6 +41,82 @@ Examples:
 	},
 }
 
+// ListOutput is the --output json|yaml result of "dtvem list"
+type ListOutput struct {
+	Runtimes []InstalledRuntime `json:"runtimes" yaml:"runtimes"`
+}
+
+// InstalledRuntime describes the installed versions of one runtime
+type InstalledRuntime struct {
+	Runtime     string `json:"runtime" yaml:"runtime"`
+	DisplayName string `json:"displayName" yaml:"displayName"`
+	// Global and Local are the configured versions, resolved to an
+	// installed version where possible; empty when not configured
+	Global   string                 `json:"global,omitempty" yaml:"global,omitempty"`
+	Local    string                 `json:"local,omitempty" yaml:"local,omitempty"`
+	Versions []InstalledVersionInfo `json:"versions" yaml:"versions"`
+	// Error is set when the installed versions could not be read
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// InstalledVersionInfo describes one installed version
+type InstalledVersionInfo struct {
+	Version string `json:"version" yaml:"version"`
+	Global  bool   `json:"global" yaml:"global"`
+	Local   bool   `json:"local" yaml:"local"`
+	// Active is true for the version that runs in the current directory
+	Active bool `json:"active" yaml:"active"`
+}
+
+// collectInstalledRuntime gathers the installed versions of a runtime
+func collectInstalledRuntime(provider runtime.Provider) (InstalledRuntime, error) {
+	result := InstalledRuntime{
+		Runtime:     provider.Name(),
+		DisplayName: provider.DisplayName(),
+		Versions:    []InstalledVersionInfo{},
+	}
+
+	versions, err := provider.ListInstalled()
+	if err != nil {
+		return result, err
+	}
+	ui.Debug("Found %d installed versions for %s", len(versions), provider.Name())
+
+	result.Global = activeInstalledVersion(provider, provider.GlobalVersion)
+	result.Local = activeInstalledVersion(provider, func() (string, error) {
+		return config.LocalVersion(provider.Name())
+	})
+
+	for _, v := range versions {
+		version := v.String()
+		result.Versions = append(result.Versions, InstalledVersionInfo{
+			Version: version,
+			Global:  version == result.Global,
+			Local:   version == result.Local,
+			Active:  isVersionActive(version, result.Global, result.Local),
+		})
+	}
+	return result, nil
+}
+
+// renderInstalledRuntime prints the installed versions of a runtime as a table
+func renderInstalledRuntime(rt InstalledRuntime) {
+	// Create table for this runtime with title
+	table := tui.NewTable("Version", "Status")
+	table.SetTitle(rt.DisplayName)
+
+	for _, v := range rt.Versions {
+		status := getVersionStatus(v.Version, rt.Global, rt.Local)
+		if v.Active {
+			table.AddActiveRow(v.Version, status)
+		} else {
+			table.AddRow(v.Version, status)
+		}
+	}
+
+	fmt.Println(table.Render())
+}
+
 // listAllRuntimes lists installed versions for all runtimes
 func listAllRuntimes() {
 	ui.Debug("Listing installed versions for all runtimes")
@@ -42,95 +124,98 @@ func listAllRuntimes() {
 	providers := runtime.GetAll()
 	ui.Debug("Found %d registered providers", len(providers))
 
+	if output.IsStructured() {
+		result := ListOutput{Runtimes: []InstalledRuntime{}}
+		for _, provider := range providers {
+			rt, err := collectInstalledRuntime(provider)
+			if err != nil {
+				rt.Error = err.Error()
+			}
+			result.Runtimes = append(result.Runtimes, rt)
+		}
+		printResult(result)
+		return
+	}
+
 	if len(providers) == 0 {
 		ui.Info("No runtime providers registered")
 		return
 	}
 
 	hasAny := false
+	var pins []pinnedVersion
 	for _, provider := range providers {
 		ui.Debug("Checking provider: %s", provider.Name())
-		versions, err := provider.ListInstalled()
+		rt, err := collectInstalledRuntime(provider)
 		if err != nil {
 			ui.Debug("Error listing versions for %s: %v", provider.Name(), err)
 			ui.Error("  %s: %v", provider.DisplayName(), err)
 			continue
 		}
-		ui.Debug("Found %d installed versions for %s", len(versions), provider.Name())
 
-		if len(versions) == 0 {
+		if len(rt.Versions) == 0 {
 			continue
 		}
 
 		hasAny = true
-		runtimeName := provider.Name()
-		globalVersion, _ := provider.GlobalVersion()
-		localVersion, _ := config.LocalVersion(runtimeName)
-
-		// Create table for this runtime with title
-		table := tui.NewTable("Version", "Status")
-		table.SetTitle(provider.DisplayName())
-
-		for _, v := range versions {
-			version := v.String()
-			status := getVersionStatus(version, globalVersion, localVersion)
-			isActive := isVersionActive(version, globalVersion, localVersion)
-
-			if isActive {
-				table.AddActiveRow(version, status)
-			} else {
-				table.AddRow(version, status)
-			}
-		}
-
-		fmt.Println(table.Render())
+		renderInstalledRuntime(rt)
+		pins = append(pins, installedRuntimePins(provider, rt)...)
 	}
 
 	if !hasAny {
 		ui.Info("No versions installed")
 	}
+	warnEOLPins(pins)
 }
 
 // listSingleRuntime lists installed versions for a specific runtime
 func listSingleRuntime(runtimeName string) {
 	provider, err := runtime.Get(runtimeName)
 	if err != nil {
-		ui.Error("%v", err)
-		ui.Info("Available runtimes: %v", runtime.List())
+		reportError(err.Error(), fmt.Sprintf("Available runtimes: %v", runtime.List()))
 		return
 	}
 
-	versions, err := provider.ListInstalled()
+	rt, err := collectInstalledRuntime(provider)
 	if err != nil {
-		ui.Error("%v", err)
+		reportError(err.Error())
 		return
 	}
 
-	if len(versions) == 0 {
-		ui.Info("No versions installed")
+	if output.IsStructured() {
+		printResult(ListOutput{Runtimes: []InstalledRuntime{rt}})
 		return
 	}
 
-	globalVersion, _ := provider.GlobalVersion()
-	localVersion, _ := config.LocalVersion(runtimeName)
-
-	// Create table with title
-	table := tui.NewTable("Version", "Status")
-	table.SetTitle(provider.DisplayName())
+	if len(rt.Versions) == 0 {
+		ui.Info("No versions installed")
+		return
+	}
 
-	for _, v := range versions {
-		version := v.String()
-		status := getVersionStatus(version, globalVersion, localVersion)
-		isActive := isVersionActive(version, globalVersion, localVersion)
+	renderInstalledRuntime(rt)
+	warnEOLPins(installedRuntimePins(provider, rt))
+}
 
-		if isActive {
-			table.AddActiveRow(version, status)
-		} else {
-			table.AddRow(version, status)
-		}
+// installedRuntimePins returns the global and local versions of a runtime
+func installedRuntimePins(provider runtime.Provider, rt InstalledRuntime) []pinnedVersion {
+	return []pinnedVersion{
+		{provider: provider, version: rt.Global, source: "global"},
+		{provider: provider, version: rt.Local, source: "local"},
 	}
+}
 
-	fmt.Println(table.Render())
+// activeInstalledVersion reads a configured version spec and resolves ranges
+// and aliases to the installed version they select. Returns the spec unchanged
+// when nothing installed matches, or "" when no version is configured.
+func activeInstalledVersion(provider runtime.Provider, read func() (string, error)) string {
+	spec, err := read()
+	if err != nil || spec == "" {
+		return ""
+	}
+	if resolved, err := runtime.ResolveInstalledVersion(provider, spec); err == nil {
+		return resolved
+	}
+	return spec
 }
 
 // getVersionStatus returns a status string for a version (global, local, or empty)
@@ -174,6 +259,56 @@ func getVersionStatusWithLifecycle(version, globalVersion, localVersion, lifecyc
 	return base + " · " + colored
 }
 
+// pinnedVersion is a configured version and what configures it: "global",
+// "local", or a version file
+type pinnedVersion struct {
+	provider runtime.Provider
+	version  string
+	source   string
+}
+
+// warnEOLPins warns about configured versions that have reached end of life,
+// for runtimes that publish a release schedule. Nothing is printed for
+// structured output.
+func warnEOLPins(pins []pinnedVersion) {
+	if output.IsStructured() {
+		return
+	}
+
+	var eol []pinnedVersion
+	for _, pin := range pins {
+		if pin.version == "" || runtime.IsVersionSpec(pin.version) {
+			continue
+		}
+		statusProvider, ok := pin.provider.(lifecycle.StatusProvider)
+		if !ok || statusProvider.VersionStatus(pin.version) != string(lifecycle.EOL) {
+			continue
+		}
+
+		// The same version pinned globally and locally gets one warning
+		merged := false
+		for i := range eol {
+			if eol[i].provider.Name() == pin.provider.Name() && eol[i].version == pin.version {
+				eol[i].source += ", " + pin.source
+				merged = true
+			}
+		}
+		if !merged {
+			eol = append(eol, pin)
+		}
+	}
+	if len(eol) == 0 {
+		return
+	}
+
+	fmt.Println()
+	for _, pin := range eol {
+		ui.Warning("%s %s (%s) has reached end of life and no longer receives security fixes",
+			pin.provider.DisplayName(), pin.version, pin.source)
+	}
+	ui.Info("Run 'dtvem outdated' to see newer releases")
+}
+
 // isVersionActive returns true if this version is the currently active one
 func isVersionActive(version, globalVersion, localVersion string) bool {
 	isGlobal := version == globalVersion
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/output"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/tui"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
@@ -21,10 +22,14 @@ var listAllCmd = &cobra.Command{
 This command queries official sources to show all versions available for download.
 Installed versions are marked with a ✓ indicator.
 
+With --output json or yaml, every matching version is returned at once;
+--limit only controls paging of the table.
+
 Examples:
   dtvem list-all python
   dtvem list-all node
-  dtvem list-all python --filter 3.11`,
+  dtvem list-all python --filter 3.11
+  dtvem list-all node -o json`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		runtimeName := args[0]
@@ -34,8 +39,7 @@ Examples:
 		// Get the provider
 		provider, err := runtime.Get(runtimeName)
 		if err != nil {
-			ui.Error("Unknown runtime: %s", runtimeName)
-			ui.Info("Available runtimes: %v", runtime.List())
+			reportError(fmt.Sprintf("Unknown runtime: %s", runtimeName), fmt.Sprintf("Available runtimes: %v", runtime.List()))
 			return
 		}
 
@@ -44,11 +48,11 @@ Examples:
 		// Get available versions
 		available, err := provider.ListAvailable()
 		if err != nil {
-			ui.Error("Failed to fetch available versions: %v", err)
+			reportError(fmt.Sprintf("Failed to fetch available versions: %v", err))
 			return
 		}
 
-		if len(available) == 0 {
+		if len(available) == 0 && !output.IsStructured() {
 			ui.Warning("No versions found")
 			return
 		}
@@ -67,8 +71,10 @@ Examples:
 		}
 
 		// Get global and local versions for indicators
-		globalVersion, _ := provider.GlobalVersion()
-		localVersion, _ := config.LocalVersion(runtimeName)
+		globalVersion := activeInstalledVersion(provider, provider.GlobalVersion)
+		localVersion := activeInstalledVersion(provider, func() (string, error) {
+			return config.LocalVersion(runtimeName)
+		})
 
 		// Filter versions if requested
 		filteredVersions := available
@@ -81,6 +87,26 @@ Examples:
 			}
 		}
 
+		if output.IsStructured() {
+			result := ListAllOutput{
+				Runtime:     provider.Name(),
+				DisplayName: provider.DisplayName(),
+				Versions:    make([]AvailableVersionInfo, 0, len(filteredVersions)),
+			}
+			for _, v := range filteredVersions {
+				version := v.Version.Raw
+				result.Versions = append(result.Versions, AvailableVersionInfo{
+					Version:         version,
+					Installed:       installedMap[version],
+					Global:          version == globalVersion,
+					Local:           version == localVersion,
+					LifecycleStatus: v.LifecycleStatus,
+				})
+			}
+			printResult(result)
+			return
+		}
+
 		if len(filteredVersions) == 0 {
 			ui.Warning("No versions match filter: %s", filter)
 			return
@@ -140,11 +166,34 @@ Examples:
 			}
 		}
 
+		warnEOLPins([]pinnedVersion{
+			{provider: provider, version: globalVersion, source: "global"},
+			{provider: provider, version: localVersion, source: "local"},
+		})
+
 		fmt.Println()
 		ui.Info("Install a version with: dtvem install %s <version>", runtimeName)
 	},
 }
 
+// ListAllOutput is the --output json|yaml result of "dtvem list-all"
+type ListAllOutput struct {
+	Runtime     string                 `json:"runtime" yaml:"runtime"`
+	DisplayName string                 `json:"displayName" yaml:"displayName"`
+	Versions    []AvailableVersionInfo `json:"versions" yaml:"versions"`
+}
+
+// AvailableVersionInfo describes one version available for install
+type AvailableVersionInfo struct {
+	Version   string `json:"version" yaml:"version"`
+	Installed bool   `json:"installed" yaml:"installed"`
+	Global    bool   `json:"global" yaml:"global"`
+	Local     bool   `json:"local" yaml:"local"`
+	// LifecycleStatus is the upstream support status (e.g., "Active LTS"),
+	// when the runtime publishes one
+	LifecycleStatus string `json:"lifecycleStatus,omitempty" yaml:"lifecycleStatus,omitempty"`
+}
+
 func init() {
 	listAllCmd.Flags().StringP("filter", "f", "", "Filter versions by substring (e.g., '3.11' for Python 3.11.x)")
 	listAllCmd.Flags().IntP("limit", "l", 50, "Number of versions to show per page")
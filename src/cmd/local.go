@@ -12,9 +12,14 @@ var localCmd = &cobra.Command{
 	Long: `Set a runtime version for the current directory by creating a .dtvem/runtimes.json file.
 This version will be used when working in this directory or its subdirectories.
 
+The version may also be a range or alias, resolved against installed
+versions each time a shim runs.
+
 Examples:
   dtvem local python 3.11.0
-  dtvem local node 18.16.0`,
+  dtvem local node 18.16.0
+  dtvem local python ">=3.11,<3.13"
+  dtvem local ruby "~3.2"`,
 	Args: cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		runtimeName := args[0]
@@ -3,207 +3,226 @@ package cmd
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
+	goruntime "runtime"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/migration"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/output"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/path"
 	internalRuntime "github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/shim"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/tui"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
 	"github.com/spf13/cobra"
 )
 
+var (
+	migrateAdopt        bool
+	migrateLink         bool
+	migrateAll          bool
+	migrateVersions     []string
+	migrateFrom         []string
+	migrateSetGlobal    string
+	migrateSkipPackages bool
+	migrateDryRun       bool
+	migrateCleanup      bool
+)
+
 var migrateCmd = &cobra.Command{
 	Use:   "migrate <runtime>",
 	Short: "Migrate existing runtime installations to dtvem",
 	Long: `Detect existing installations of a runtime and migrate them to dtvem.
 
 This command scans your system for existing installations (from system packages,
-nvm, pyenv, etc.), lets you select which versions to migrate, and installs them
-via dtvem's normal installation process.
+nvm, fnm, n, nodenv, volta, pyenv, conda, rbenv, rvm, chruby, uru, asdf and
+mise), lets you select which versions to migrate, and installs them via dtvem's
+normal installation process.
+
+With --adopt, the selected installations are copied into dtvem instead of
+being downloaded again, so versions dtvem doesn't offer and builds with custom
+compile flags (e.g., from pyenv) carry over as they are. Script shebangs are
+rewritten to the new location, and each version is run before it is accepted.
+Add --link to reference the original installations instead of copying them;
+they must then stay in place. System installations can't be adopted.
+
+If the current project pins the runtime in another version manager's file
+(.tool-versions, mise.toml, or the "volta" key of package.json), migrate offers
+to record the pin in .dtvem/runtimes.json next to that file. The original file
+is left in place.
+
+To script a migration, select versions with --all or --versions instead of
+the prompt; --from limits either to some version managers. Nothing is asked
+then: --set-global picks the global version, project pins are not converted,
+and old installations are left in place unless --cleanup is given. The
+command exits non-zero if any selected version fails to migrate. --dry-run
+prints the plan (the versions, the global packages that would be reinstalled,
+and the commands that remove the old installations) without changing
+anything; it supports --output json.
+
+Cleaning up is opt-in: after migrating, migrate asks whether to remove the old
+installations, and --cleanup does so without asking. Each old installation is
+removed with its version manager's uninstall command, and once a version
+manager has no versions left, the lines that load it are removed from your
+shell startup files (.bashrc, .zshrc, config.fish, the PowerShell profile,
+...). A backup is written next to each edited file, and the commands, their
+output and every edit are recorded in a log under ~/.dtvem/logs.
 
 Examples:
   dtvem migrate node     # Detect and migrate Node.js installations
-  dtvem migrate python   # Detect and migrate Python installations`,
+  dtvem migrate python   # Detect and migrate Python installations
+  dtvem migrate python --adopt          # Copy pyenv/conda builds into dtvem
+  dtvem migrate ruby --adopt --link     # Use rbenv builds where they are
+  dtvem migrate node --from nvm --all --set-global 20.11.1 --cleanup
+  dtvem migrate node --versions 18.20.0,20.11.1 --skip-packages
+  dtvem migrate python --all --dry-run --output json`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		runtimeName := args[0]
+		if migrateLink {
+			migrateAdopt = true
+		}
+		if output.IsStructured() && !migrateDryRun {
+			reportError("--output json|yaml is only supported with --dry-run")
+			os.Exit(1)
+		}
+		// Selection flags make the whole run non-interactive
+		interactive := !migrateAll && len(migrateVersions) == 0 && !migrateDryRun
 
 		// Get the runtime provider
 		provider, err := internalRuntime.Get(runtimeName)
 		if err != nil {
-			ui.Error("%v", err)
-			ui.Info("Available runtimes: %v", internalRuntime.List())
-			return
+			reportError(err.Error(), fmt.Sprintf("Available runtimes: %v", internalRuntime.List()))
+			os.Exit(1)
 		}
 
 		spinner := ui.NewSpinner(fmt.Sprintf("Scanning for %s installations...", provider.DisplayName()))
 		spinner.Start()
 
-		// Get migration providers for this runtime
-		migrationProviders := migration.GetByRuntime(runtimeName)
-
-		// Collect all detected versions from all migration providers
-		detected := make([]detectedVersionWithProvider, 0)
-		for _, mp := range migrationProviders {
-			versions, err := mp.DetectVersions()
-			if err != nil {
-				continue // Skip providers that fail
-			}
-			for _, v := range versions {
-				detected = append(detected, detectedVersionWithProvider{
-					DetectedVersion:   v,
-					MigrationProvider: mp,
-				})
-			}
-		}
-
-		// Deduplicate by path
-		detected = deduplicateByPath(detected)
+		detected := filterBySource(detectMigrationCandidates(runtimeName), migrateFrom)
+		reader := bufio.NewReader(os.Stdin)
 
 		if len(detected) == 0 {
 			spinner.Warning("No installations found")
+			if migrateDryRun {
+				if output.IsStructured() {
+					printResult(MigratePlan{Runtime: runtimeName, DisplayName: provider.DisplayName(), Versions: []PlannedMigration{}})
+				}
+				return
+			}
 			ui.Info("Use 'dtvem install %s <version>' to install a version", runtimeName)
+			if interactive {
+				convertProjectPin(provider, reader)
+			} else {
+				os.Exit(1)
+			}
 			return
 		}
 
 		spinner.Success(fmt.Sprintf("Found %d installation(s)", len(detected)))
-		fmt.Println()
 
-		// Display detected installations
-		for i, dv := range detected {
-			validatedMark := ""
-			if dv.Validated {
-				validatedMark = " " + ui.Highlight("\u2713")
+		var selectedVersions []detectedVersionWithProvider
+		switch {
+		case len(migrateVersions) > 0:
+			selectedVersions, err = selectVersions(detected, migrateVersions)
+			if err != nil {
+				reportError(err.Error(), "Run 'dtvem migrate "+runtimeName+" --dry-run' to see the detected versions")
+				os.Exit(1)
+			}
+		case !interactive:
+			selectedVersions = detected
+		default:
+			selectedVersions = promptMigrationSelection(detected, reader)
+			if len(selectedVersions) == 0 {
+				return
 			}
-			fmt.Printf("  [%d] %s  (%s) %s%s\n",
-				i+1,
-				ui.HighlightVersion("v"+dv.Version),
-				ui.Highlight(dv.Source),
-				dv.Path,
-				validatedMark)
-		}
-
-		// Prompt user for selection
-		fmt.Printf("\nSelect versions to migrate:\n")
-		fmt.Printf("  Enter numbers separated by commas, or 'all' (e.g., 1,3 or all): ")
-
-		reader := bufio.NewReader(os.Stdin)
-		input, err := reader.ReadString('\n')
-		if err != nil {
-			fmt.Printf("Error reading input: %v\n", err)
-			return
 		}
 
-		input = strings.TrimSpace(input)
-		if input == "" {
-			ui.Info("No versions selected. Exiting")
-			return
+		if migrateSetGlobal != "" && findSelected(selectedVersions, migrateSetGlobal) == nil {
+			reportError(fmt.Sprintf("--set-global %s is not one of the versions being migrated", migrateSetGlobal))
+			os.Exit(1)
 		}
 
-		// Parse selection
-		selectedIndices := parseSelection(input, len(detected))
-		if len(selectedIndices) == 0 {
-			ui.Warning("No valid selections. Exiting")
+		if migrateDryRun {
+			plan := buildMigrationPlan(provider, selectedVersions)
+			if output.IsStructured() {
+				printResult(plan)
+			} else {
+				renderMigrationPlan(plan)
+			}
 			return
 		}
 
-		// Get selected versions
-		selectedVersions := make([]detectedVersionWithProvider, 0)
-		for _, idx := range selectedIndices {
-			selectedVersions = append(selectedVersions, detected[idx])
-		}
-
-		fmt.Println()
-
 		// Migrate each selected version
-		successCount := 0
+		succeeded := make([]detectedVersionWithProvider, 0, len(selectedVersions))
 		fmt.Println()
 		for _, dv := range selectedVersions {
 			ui.Header("Migrating %s v%s...", provider.DisplayName(), dv.Version)
-
-			// Detect global packages from the existing installation
-			var globalPackages []string
-			ui.Progress("Detecting global packages...")
-			packages, err := provider.GlobalPackages(dv.Path)
-			if err != nil {
-				ui.Warning("Could not detect global packages: %v", err)
-				globalPackages = []string{}
-			} else {
-				globalPackages = packages
-				if len(globalPackages) > 0 {
-					ui.Info("Found %d global package(s): %s", len(globalPackages), strings.Join(globalPackages, ", "))
-				} else {
-					ui.Info("No global packages found")
-				}
-			}
-
-			// Call the provider's Install method
-			if err := provider.Install(dv.Version); err != nil {
+			if err := migrateVersion(provider, dv); err != nil {
 				ui.Error("%v", err)
 			} else {
-				successCount++
-
-				// Reinstall global packages
-				if len(globalPackages) > 0 {
-					ui.Progress("Reinstalling %d global package(s)...", len(globalPackages))
-					if err := provider.InstallGlobalPackages(dv.Version, globalPackages); err != nil {
-						ui.Warning("Failed to reinstall some packages: %v", err)
-						if cmd := provider.ManualPackageInstallCommand(globalPackages); cmd != "" {
-							ui.Info("You can manually reinstall with:")
-							ui.Info("  %s", cmd)
-						}
-					} else {
-						ui.Success("Reinstalled %d global package(s)", len(globalPackages))
-					}
-				}
+				succeeded = append(succeeded, dv)
 			}
 			fmt.Println()
 		}
+		successCount := len(succeeded)
 
 		if successCount == len(selectedVersions) {
-			ui.Success("Migration complete! %d/%d version(s) installed", successCount, len(selectedVersions))
+			ui.Success("Migration complete! %d/%d version(s) migrated", successCount, len(selectedVersions))
 		} else if successCount > 0 {
-			ui.Warning("Migration partially complete: %d/%d version(s) installed", successCount, len(selectedVersions))
+			ui.Warning("Migration partially complete: %d/%d version(s) migrated", successCount, len(selectedVersions))
 		} else {
-			ui.Error("Migration failed: 0/%d version(s) installed", len(selectedVersions))
+			ui.Error("Migration failed: 0/%d version(s) migrated", len(selectedVersions))
+		}
+
+		if interactive {
+			convertProjectPin(provider, reader)
 		}
 
 		if successCount == 0 {
+			if !interactive {
+				os.Exit(1)
+			}
 			return
 		}
 
-		// Optionally set global version
-		if successCount > 0 {
+		// Set the global version
+		if migrateSetGlobal != "" {
 			fmt.Println()
-			ui.Header("Set global version?")
-			for i, dv := range selectedVersions {
-				fmt.Printf("  [%d] %s\n", i+1, ui.HighlightVersion("v"+dv.Version))
-			}
-			fmt.Printf("  [0] None\n")
-			fmt.Printf("Select [0]: ")
-
-			input, err = reader.ReadString('\n')
-			if err == nil {
-				input = strings.TrimSpace(input)
-				if input != "" && input != "0" {
-					if choice, err := strconv.Atoi(input); err == nil && choice > 0 && choice <= len(selectedVersions) {
-						version := selectedVersions[choice-1].Version
-						if err := provider.SetGlobalVersion(version); err != nil {
-							ui.Error("Error setting global version: %v", err)
-						} else {
-							ui.Success("Global version set to v%s", version)
-						}
-					}
-				}
+			if dv := findSelected(succeeded, migrateSetGlobal); dv == nil {
+				ui.Error("v%s was not migrated; the global version was not changed", strings.TrimPrefix(migrateSetGlobal, "v"))
+			} else if err := provider.SetGlobalVersion(dv.Version); err != nil {
+				ui.Error("Error setting global version: %v", err)
+			} else {
+				ui.Success("Global version set to v%s", dv.Version)
 			}
+		} else if interactive {
+			promptGlobalVersion(provider, selectedVersions, reader)
 		}
 
-		// Prompt to cleanup old installations
-		if successCount > 0 {
+		// Cleanup of old installations is opt-in. Linked versions still use
+		// theirs, so removing them would break the adopted versions.
+		fmt.Println()
+		cleanedUp := false
+		switch {
+		case migrateLink:
+			ui.Info("The migrated versions link to their original installations; keep those in place")
+		case migrateCleanup:
+			cleanupOldInstallations(succeeded, provider.DisplayName(), reader, interactive)
+			cleanedUp = true
+		case interactive && confirmCleanup(reader, "Clean up the old installations now?"):
 			fmt.Println()
-			promptCleanupOldInstallations(selectedVersions, provider.DisplayName())
+			cleanupOldInstallations(succeeded, provider.DisplayName(), reader, true)
+			cleanedUp = true
+		default:
+			ui.Info("Old installations were left in place")
 		}
 
 		// Show next steps
@@ -211,10 +230,445 @@ Examples:
 		ui.Header("Next steps:")
 		ui.Info("1. Add ~/.dtvem/shims to your PATH (if not already)")
 		ui.Info("2. Run: %s --version", runtimeName)
-		ui.Info("3. Consider removing old installations to avoid conflicts")
+		if !cleanedUp {
+			ui.Info("3. Consider removing old installations to avoid conflicts")
+		}
+
+		if !interactive && successCount < len(selectedVersions) {
+			os.Exit(1)
+		}
 	},
 }
 
+// findProjectPin returns the version another version manager pins for the
+// runtime in the nearest project file above dir, or nil if there is none.
+// When several tools' files pin it, the file closest to dir wins.
+func findProjectPin(runtimeName, dir string) *migration.ProjectPin {
+	var nearest *migration.ProjectPin
+	for _, mp := range migration.GetByRuntime(runtimeName) {
+		pin := migration.FindProjectPin(mp, dir)
+		if pin != nil && (nearest == nil || len(filepath.Dir(pin.File)) > len(filepath.Dir(nearest.File))) {
+			nearest = pin
+		}
+	}
+	return nearest
+}
+
+// convertProjectPin offers to record the version another version manager
+// pins for the current project in .dtvem/runtimes.json
+func convertProjectPin(provider internalRuntime.Provider, reader *bufio.Reader) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+	pin := findProjectPin(provider.Name(), cwd)
+	if pin == nil {
+		return
+	}
+
+	fmt.Println()
+	ui.Header("Convert project version file?")
+	ui.Info("%s pins %s %s", formatVersionSource(pin.File), provider.DisplayName(), pin.Version)
+	fmt.Printf("Record it in %s? [y/N]: ", formatVersionSource(pin.RuntimesFile()))
+
+	input, err := reader.ReadString('\n')
+	if err != nil || strings.ToLower(strings.TrimSpace(input)) != "y" {
+		ui.Info("Skipped")
+		if filepath.Base(pin.File) != ".tool-versions" {
+			ui.Info("dtvem does not read %s; pin the version with 'dtvem local %s %s'", filepath.Base(pin.File), provider.Name(), pin.Version)
+		}
+		return
+	}
+
+	existing, converted, err := migration.ConvertProjectPin(*pin)
+	switch {
+	case err != nil:
+		ui.Error("Failed to update %s: %v", pin.RuntimesFile(), err)
+	case !converted:
+		ui.Info("%s already pins %s %s; leaving it unchanged", formatVersionSource(pin.RuntimesFile()), provider.DisplayName(), existing)
+	default:
+		ui.Success("Pinned %s %s in %s", provider.DisplayName(), pin.Version, formatVersionSource(pin.RuntimesFile()))
+	}
+}
+
+// MigratePlan is the --dry-run result of "dtvem migrate"
+type MigratePlan struct {
+	Runtime     string             `json:"runtime" yaml:"runtime"`
+	DisplayName string             `json:"displayName" yaml:"displayName"`
+	Versions    []PlannedMigration `json:"versions" yaml:"versions"`
+	SetGlobal   string             `json:"setGlobal,omitempty" yaml:"setGlobal,omitempty"`
+}
+
+// PlannedMigration is one version dtvem migrate would migrate
+type PlannedMigration struct {
+	Version string `json:"version" yaml:"version"`
+	Source  string `json:"source" yaml:"source"`
+	Path    string `json:"path" yaml:"path"`
+	// Action is install (download), adopt (copy) or link
+	Action string `json:"action" yaml:"action"`
+	// Packages are the global packages that would be reinstalled. Adopted
+	// versions keep theirs.
+	Packages      []string `json:"packages" yaml:"packages"`
+	PackagesError string   `json:"packagesError,omitempty" yaml:"packagesError,omitempty"`
+	// UninstallCommand removes the old installation afterwards; it is empty
+	// when the installation has to be removed by hand
+	UninstallCommand string `json:"uninstallCommand,omitempty" yaml:"uninstallCommand,omitempty"`
+	// Error is set when the version can't be migrated as planned
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// migrateAction returns the action the migrate flags select
+func migrateAction() string {
+	switch {
+	case migrateLink:
+		return "link"
+	case migrateAdopt:
+		return "adopt"
+	default:
+		return "install"
+	}
+}
+
+// buildMigrationPlan describes what migrating the selected versions would do,
+// without changing anything
+func buildMigrationPlan(provider internalRuntime.Provider, selected []detectedVersionWithProvider) MigratePlan {
+	plan := MigratePlan{
+		Runtime:     provider.Name(),
+		DisplayName: provider.DisplayName(),
+		Versions:    make([]PlannedMigration, 0, len(selected)),
+	}
+	if dv := findSelected(selected, migrateSetGlobal); dv != nil {
+		plan.SetGlobal = dv.Version
+	}
+
+	for _, dv := range selected {
+		planned := PlannedMigration{
+			Version:          dv.Version,
+			Source:           dv.Source,
+			Path:             dv.Path,
+			Action:           migrateAction(),
+			Packages:         []string{},
+			UninstallCommand: dv.MigrationProvider.UninstallCommand(dv.Version),
+		}
+		if !dv.MigrationProvider.CanAutoUninstall() {
+			planned.UninstallCommand = ""
+		}
+
+		if installed, _ := provider.IsInstalled(dv.Version); installed {
+			planned.Error = fmt.Sprintf("%s %s is already installed", provider.DisplayName(), dv.Version)
+		} else if migrateAdopt {
+			if _, err := migration.InstallRoot(dv.MigrationProvider, dv.DetectedVersion); err != nil {
+				planned.Error = err.Error()
+			}
+		}
+
+		if !migrateAdopt && !migrateSkipPackages {
+			packages, err := provider.GlobalPackages(dv.Path)
+			if err != nil {
+				planned.PackagesError = err.Error()
+			} else if packages != nil {
+				planned.Packages = packages
+			}
+		}
+		plan.Versions = append(plan.Versions, planned)
+	}
+	return plan
+}
+
+// renderMigrationPlan prints a migration plan as a table
+func renderMigrationPlan(plan MigratePlan) {
+	table := tui.NewTable("Version", "Source", "Action", "Packages", "Uninstall Afterwards")
+	table.SetTitle(fmt.Sprintf("%s Migration Plan", plan.DisplayName))
+	for _, planned := range plan.Versions {
+		action := planned.Action
+		if planned.Error != "" {
+			action = ui.Highlight("skip")
+		}
+
+		var packages string
+		switch {
+		case migrateAdopt:
+			packages = "kept"
+		case migrateSkipPackages:
+			packages = "skipped"
+		case planned.PackagesError != "":
+			packages = "unknown"
+		case len(planned.Packages) == 0:
+			packages = "-"
+		default:
+			packages = strings.Join(planned.Packages, ", ")
+		}
+
+		table.AddRow("v"+planned.Version, planned.Source, action, packages, orDash(planned.UninstallCommand))
+	}
+	fmt.Println()
+	fmt.Println(table.Render())
+
+	for _, planned := range plan.Versions {
+		if planned.Error != "" {
+			ui.Warning("v%s (%s): %s", planned.Version, planned.Source, planned.Error)
+		}
+	}
+	if plan.SetGlobal != "" {
+		ui.Info("The global version would be set to v%s", plan.SetGlobal)
+	}
+	ui.Info("Dry run: nothing was changed")
+}
+
+// detectMigrationCandidates collects the versions every migration provider
+// for the runtime detects. An installation found by several providers is
+// listed once, for the first provider by name.
+func detectMigrationCandidates(runtimeName string) []detectedVersionWithProvider {
+	detected := make([]detectedVersionWithProvider, 0)
+	for _, mp := range migration.GetByRuntime(runtimeName) {
+		versions, err := mp.DetectVersions()
+		if err != nil {
+			continue // Skip providers that fail
+		}
+		for _, v := range versions {
+			detected = append(detected, detectedVersionWithProvider{
+				DetectedVersion:   v,
+				MigrationProvider: mp,
+			})
+		}
+	}
+	return deduplicateByPath(detected)
+}
+
+// filterBySource keeps the versions detected by one of the named version
+// managers, matched against the version's source (e.g., "nvm") or its
+// migration provider's name (e.g., "asdf-node"). No names keeps them all.
+func filterBySource(versions []detectedVersionWithProvider, sources []string) []detectedVersionWithProvider {
+	if len(sources) == 0 {
+		return versions
+	}
+
+	result := make([]detectedVersionWithProvider, 0)
+	for _, v := range versions {
+		for _, source := range sources {
+			if strings.EqualFold(source, v.Source) || strings.EqualFold(source, v.MigrationProvider.Name()) {
+				result = append(result, v)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// selectVersions returns the detected installation of each requested
+// version. A version detected more than once is taken from the first source;
+// use --from to choose another. Returns an error naming requested versions
+// that were not detected.
+func selectVersions(detected []detectedVersionWithProvider, versions []string) ([]detectedVersionWithProvider, error) {
+	selected := make([]detectedVersionWithProvider, 0, len(versions))
+	var missing []string
+	for _, version := range versions {
+		dv := findSelected(detected, version)
+		if dv == nil {
+			missing = append(missing, version)
+			continue
+		}
+		if findSelected(selected, version) == nil {
+			selected = append(selected, *dv)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("no installation found for version(s): %s", strings.Join(missing, ", "))
+	}
+	return selected, nil
+}
+
+// findSelected returns the first of versions that is version, ignoring a
+// leading "v", or nil
+func findSelected(versions []detectedVersionWithProvider, version string) *detectedVersionWithProvider {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if version == "" {
+		return nil
+	}
+	for i := range versions {
+		if versions[i].Version == version {
+			return &versions[i]
+		}
+	}
+	return nil
+}
+
+// promptMigrationSelection lists the detected versions and asks which to
+// migrate. Returns nothing if the user selects nothing.
+func promptMigrationSelection(detected []detectedVersionWithProvider, reader *bufio.Reader) []detectedVersionWithProvider {
+	fmt.Println()
+	for i, dv := range detected {
+		validatedMark := ""
+		if dv.Validated {
+			validatedMark = " " + ui.Highlight("\u2713")
+		}
+		fmt.Printf("  [%d] %s  (%s) %s%s\n",
+			i+1,
+			ui.HighlightVersion("v"+dv.Version),
+			ui.Highlight(dv.Source),
+			dv.Path,
+			validatedMark)
+	}
+
+	// Prompt user for selection
+	fmt.Printf("\nSelect versions to migrate:\n")
+	fmt.Printf("  Enter numbers separated by commas, or 'all' (e.g., 1,3 or all): ")
+
+	input, err := reader.ReadString('\n')
+	if err != nil {
+		fmt.Printf("Error reading input: %v\n", err)
+		return nil
+	}
+
+	input = strings.TrimSpace(input)
+	if input == "" {
+		ui.Info("No versions selected. Exiting")
+		return nil
+	}
+
+	// Parse selection
+	selectedIndices := parseSelection(input, len(detected))
+	if len(selectedIndices) == 0 {
+		ui.Warning("No valid selections. Exiting")
+		return nil
+	}
+
+	selected := make([]detectedVersionWithProvider, 0, len(selectedIndices))
+	for _, idx := range selectedIndices {
+		selected = append(selected, detected[idx])
+	}
+	fmt.Println()
+	return selected
+}
+
+// promptGlobalVersion offers to set one of the migrated versions as the
+// global version
+func promptGlobalVersion(provider internalRuntime.Provider, versions []detectedVersionWithProvider, reader *bufio.Reader) {
+	fmt.Println()
+	ui.Header("Set global version?")
+	for i, dv := range versions {
+		fmt.Printf("  [%d] %s\n", i+1, ui.HighlightVersion("v"+dv.Version))
+	}
+	fmt.Printf("  [0] None\n")
+	fmt.Printf("Select [0]: ")
+
+	input, err := reader.ReadString('\n')
+	if err != nil {
+		return
+	}
+	input = strings.TrimSpace(input)
+	if input == "" || input == "0" {
+		return
+	}
+	if choice, err := strconv.Atoi(input); err == nil && choice > 0 && choice <= len(versions) {
+		version := versions[choice-1].Version
+		if err := provider.SetGlobalVersion(version); err != nil {
+			ui.Error("Error setting global version: %v", err)
+		} else {
+			ui.Success("Global version set to v%s", version)
+		}
+	}
+}
+
+// migrateVersion installs or adopts one detected version. Installed versions
+// get the global packages of the old installation reinstalled unless
+// --skip-packages is set. A version dtvem already has is skipped, as the
+// migration plan shows, and counts as migrated.
+func migrateVersion(provider internalRuntime.Provider, dv detectedVersionWithProvider) error {
+	if installed, _ := provider.IsInstalled(dv.Version); installed {
+		ui.Info("%s v%s is already installed, skipping", provider.DisplayName(), dv.Version)
+		return nil
+	}
+
+	if migrateAdopt {
+		return adoptVersion(provider, dv, migrateLink)
+	}
+
+	// Detect global packages from the existing installation
+	var globalPackages []string
+	if !migrateSkipPackages {
+		ui.Progress("Detecting global packages...")
+		packages, err := provider.GlobalPackages(dv.Path)
+		if err != nil {
+			ui.Warning("Could not detect global packages: %v", err)
+		} else {
+			globalPackages = packages
+			if len(globalPackages) > 0 {
+				ui.Info("Found %d global package(s): %s", len(globalPackages), strings.Join(globalPackages, ", "))
+			} else {
+				ui.Info("No global packages found")
+			}
+		}
+	}
+
+	// Call the provider's Install method
+	if err := provider.Install(dv.Version); err != nil {
+		return err
+	}
+
+	// Reinstall global packages
+	if len(globalPackages) > 0 {
+		ui.Progress("Reinstalling %d global package(s)...", len(globalPackages))
+		if err := provider.InstallGlobalPackages(dv.Version, globalPackages); err != nil {
+			ui.Warning("Failed to reinstall some packages: %v", err)
+			if cmd := provider.ManualPackageInstallCommand(globalPackages); cmd != "" {
+				ui.Info("You can manually reinstall with:")
+				ui.Info("  %s", cmd)
+			}
+		} else {
+			ui.Success("Reinstalled %d global package(s)", len(globalPackages))
+		}
+	}
+	return nil
+}
+
+// adoptVersion installs a detected version from its existing installation
+// (see runtime.Adopt) instead of downloading it, and registers its shims
+func adoptVersion(provider internalRuntime.Provider, dv detectedVersionWithProvider, link bool) error {
+	if err := config.EnsureDirectories(); err != nil {
+		return fmt.Errorf("failed to create dtvem directories: %w", err)
+	}
+
+	root, err := migration.InstallRoot(dv.MigrationProvider, dv.DetectedVersion)
+	if err != nil {
+		return err
+	}
+	if link {
+		ui.Progress("Linking %s...", root)
+	} else {
+		ui.Progress("Copying %s...", root)
+	}
+
+	inst, err := internalRuntime.Adopt(provider, dv.Version, root, link)
+	if err != nil {
+		return err
+	}
+	defer inst.Rollback()
+
+	manager, err := shim.NewManager()
+	if err != nil {
+		return err
+	}
+	shimNames := shim.DiscoverShimsForVersion(inst.InstallPath)
+	if len(shimNames) == 0 {
+		return fmt.Errorf("no executables found in %s", inst.InstallPath)
+	}
+
+	// Shims are created before the commit, so a failure rolls the adoption back
+	if err := manager.CreateShimsForRuntime(provider.Name(), dv.Version, shimNames); err != nil {
+		_, _ = shim.UnregisterVersion(provider.Name(), dv.Version)
+		return fmt.Errorf("failed to create shims: %w", err)
+	}
+	if err := inst.Commit(); err != nil {
+		_, _ = shim.UnregisterVersion(provider.Name(), dv.Version)
+		return fmt.Errorf("failed to complete install: %w", err)
+	}
+	ui.Success("Adopted %s v%s from %s", provider.DisplayName(), dv.Version, dv.Source)
+	ui.Info("Location: %s", inst.InstallPath)
+	return nil
+}
+
 // detectedVersionWithProvider pairs a detected version with its migration provider.
 type detectedVersionWithProvider struct {
 	migration.DetectedVersion
@@ -262,14 +716,64 @@ func parseSelection(input string, maxCount int) []int {
 	return indices
 }
 
-// promptCleanupOldInstallations prompts the user to clean up old installations after successful migration
-func promptCleanupOldInstallations(versions []detectedVersionWithProvider, runtimeDisplayName string) {
+// cleanupLog records everything the post-migration cleanup runs and
+// changes, so it can be reviewed and rolled back later
+type cleanupLog struct {
+	file *os.File
+	path string
+}
+
+// openCleanupLog creates a new log file in dtvem's logs directory
+func openCleanupLog() (*cleanupLog, error) {
+	if err := os.MkdirAll(config.LogsDir(), 0755); err != nil {
+		return nil, err
+	}
+	logPath := filepath.Join(config.LogsDir(), fmt.Sprintf("migrate-cleanup-%s.log", time.Now().Format("20060102-150405")))
+	f, err := os.Create(logPath)
+	if err != nil {
+		return nil, err
+	}
+	return &cleanupLog{file: f, path: logPath}, nil
+}
+
+// Printf writes a timestamped line to the log
+func (l *cleanupLog) Printf(format string, args ...interface{}) {
+	_, _ = fmt.Fprintf(l.file, "[%s] %s\n", time.Now().Format(time.RFC3339), fmt.Sprintf(format, args...))
+}
+
+// Close closes the log file
+func (l *cleanupLog) Close() {
+	_ = l.file.Close()
+}
+
+// confirmCleanup asks a yes/no question; anything but "y" or "yes" is no
+func confirmCleanup(reader *bufio.Reader, format string, args ...interface{}) bool {
+	fmt.Printf(format+" [y/N]: ", args...)
+	input, err := reader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+	input = strings.ToLower(strings.TrimSpace(input))
+	return input == constants.ResponseY || input == constants.ResponseYes
+}
+
+// cleanupOldInstallations removes the old installations of migrated versions
+// with their version managers' uninstall commands, then takes the managers'
+// init lines out of shell startup files once they have nothing left to
+// manage. With confirm, each step is confirmed first. Every command, its
+// output and each file edit, with the backup made of the file, is logged.
+func cleanupOldInstallations(versions []detectedVersionWithProvider, runtimeDisplayName string, reader *bufio.Reader, confirm bool) {
 	ui.Header("Cleanup Old Installations")
-	ui.Info("You have successfully migrated to dtvem. Would you like to clean up the old installations?")
-	ui.Info("This helps prevent PATH conflicts and version confusion.")
+	ui.Info("Removing the old installations prevents PATH conflicts and version confusion.")
 	fmt.Println()
 
-	reader := bufio.NewReader(os.Stdin)
+	log, err := openCleanupLog()
+	if err != nil {
+		ui.Error("Failed to create the cleanup log: %v", err)
+		return
+	}
+	defer log.Close()
+
 	removedCount := 0
 	skippedCount := 0
 
@@ -278,41 +782,45 @@ func promptCleanupOldInstallations(versions []detectedVersionWithProvider, runti
 		fmt.Printf("  Location: %s\n", dv.Path)
 
 		mp := dv.MigrationProvider
-		canAuto := mp.CanAutoUninstall()
 		command := mp.UninstallCommand(dv.Version)
-		instructions := mp.ManualInstructions()
-
-		if canAuto && command != "" {
-			fmt.Printf("\nRemove this installation? [y/N]: ")
-			input, err := reader.ReadString('\n')
-			if err != nil || strings.ToLower(strings.TrimSpace(input)) != "y" {
-				skippedCount++
-				ui.Warning("Skipped. You can manually remove it later with:")
-				ui.Info("  %s", command)
-				fmt.Println()
-				continue
-			}
-
-			// Attempt to execute the uninstall command
-			ui.Progress("Removing %s v%s from %s...", runtimeDisplayName, dv.Version, dv.Source)
-			if err := executeUninstallCommand(command); err != nil {
-				ui.Error("Failed to remove: %v", err)
-				ui.Info("You can manually remove it with:")
-				ui.Info("  %s", command)
-				skippedCount++
-			} else {
-				ui.Success("Removed %s v%s from %s", runtimeDisplayName, dv.Version, dv.Source)
-				removedCount++
-			}
-		} else {
+		if !mp.CanAutoUninstall() || command == "" {
 			// System installs or version managers without auto-uninstall - provide instructions only
 			ui.Warning("Manual removal required")
-			ui.Info("%s", instructions)
+			ui.Info("%s", mp.ManualInstructions())
+			log.Printf("%s v%s (%s): manual removal required", runtimeDisplayName, dv.Version, dv.Path)
 			skippedCount++
+			fmt.Println()
+			continue
+		}
+
+		if confirm && !confirmCleanup(reader, "\nRemove this installation?") {
+			ui.Warning("Skipped. You can manually remove it later with:")
+			ui.Info("  %s", command)
+			log.Printf("%s v%s (%s): skipped %q", runtimeDisplayName, dv.Version, dv.Path, command)
+			skippedCount++
+			fmt.Println()
+			continue
+		}
+
+		// Attempt to execute the uninstall command
+		ui.Progress("Removing %s v%s from %s...", runtimeDisplayName, dv.Version, dv.Source)
+		log.Printf("$ %s", command)
+		if err := executeUninstallCommand(command, log.file); err != nil {
+			ui.Error("Failed to remove: %v", err)
+			ui.Info("You can manually remove it with:")
+			ui.Info("  %s", command)
+			log.Printf("failed: %v", err)
+			skippedCount++
+		} else {
+			ui.Success("Removed %s v%s from %s", runtimeDisplayName, dv.Version, dv.Source)
+			log.Printf("removed %s v%s", runtimeDisplayName, dv.Version)
+			removedCount++
 		}
 		fmt.Println()
 	}
 
+	cleanupShellInit(versions, reader, confirm, log)
+
 	// Summary
 	if removedCount > 0 {
 		ui.Success("Removed %d old installation(s)", removedCount)
@@ -323,10 +831,84 @@ func promptCleanupOldInstallations(versions []detectedVersionWithProvider, runti
 		ui.Info("These may conflict with dtvem-managed versions if they appear earlier in your PATH")
 		ui.Info("Consider removing them manually to avoid confusion")
 	}
+	ui.Info("Cleanup log: %s", log.path)
+}
+
+// cleanupShellInit removes the init lines of the migrated versions' version
+// managers from the shell startup files, keeping a backup of each file it
+// edits. Managers that still manage other versions or tools are left
+// alone, and so are lines that also run unrelated commands.
+func cleanupShellInit(versions []detectedVersionWithProvider, reader *bufio.Reader, confirm bool, log *cleanupLog) {
+	seen := make(map[string]bool)
+	for _, dv := range versions {
+		sp, ok := dv.MigrationProvider.(migration.ShellInitProvider)
+		if !ok {
+			continue
+		}
+		shellInit := sp.ShellInit()
+		if seen[shellInit.Manager] {
+			continue
+		}
+		seen[shellInit.Manager] = true
+
+		if migration.ManagerInUse(shellInit.Manager) {
+			ui.Info("%s still manages installed versions or tools; leaving its shell setup in place", shellInit.Manager)
+			log.Printf("%s: still in use, shell startup files left unchanged", shellInit.Manager)
+			continue
+		}
+
+		for _, file := range path.ShellStartupFiles() {
+			preview, err := path.FindShellInit(file, shellInit)
+			if err != nil {
+				continue
+			}
+			for _, line := range preview.Skipped {
+				ui.Warning("%s: left in place, also runs other commands: %s", formatVersionSource(file), line)
+				log.Printf("%s: left %s line in place: %s", file, shellInit.Manager, line)
+			}
+			if !preview.Changed() {
+				continue
+			}
+
+			fmt.Printf("%s loads %s:\n", formatVersionSource(file), shellInit.Manager)
+			printShellInitEdit(preview, os.Stdout)
+			if confirm && !confirmCleanup(reader, "Apply these changes?") {
+				log.Printf("%s: skipped removing %s lines", file, shellInit.Manager)
+				fmt.Println()
+				continue
+			}
+
+			edit, err := path.RemoveShellInit(file, shellInit)
+			if err != nil {
+				ui.Error("Failed to update %s: %v", file, err)
+				log.Printf("%s: failed to remove %s lines: %v", file, shellInit.Manager, err)
+				continue
+			}
+			log.Printf("%s: removed %s lines, backup at %s", file, shellInit.Manager, edit.Backup)
+			printShellInitEdit(edit, log.file)
+			ui.Success("Updated %s", formatVersionSource(file))
+			ui.Info("Backup: %s (restart your shell to apply)", edit.Backup)
+			fmt.Println()
+		}
+	}
 }
 
-// executeUninstallCommand executes the uninstall command for automated cleanup
-func executeUninstallCommand(command string) error {
+// printShellInitEdit lists the lines a shell init edit removes or rewrites
+func printShellInitEdit(edit path.ShellInitEdit, w io.Writer) {
+	for _, line := range edit.Removed {
+		_, _ = fmt.Fprintf(w, "  - %s\n", line)
+	}
+	for _, rewrite := range edit.Rewritten {
+		_, _ = fmt.Fprintf(w, "  - %s\n  + %s\n", rewrite.Old, rewrite.New)
+	}
+}
+
+// executeUninstallCommand executes the uninstall command for automated
+// cleanup, copying its output to the terminal and to log. Version managers
+// such as nvm and rvm are shell functions rather than executables; their
+// commands run in an interactive shell, which loads them from the user's
+// startup files.
+func executeUninstallCommand(command string, log io.Writer) error {
 	// Parse the command into parts
 	parts := strings.Fields(command)
 	if len(parts) == 0 {
@@ -335,12 +917,26 @@ func executeUninstallCommand(command string) error {
 
 	// Execute the command
 	cmd := exec.Command(parts[0], parts[1:]...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	if _, err := exec.LookPath(parts[0]); err != nil && goruntime.GOOS != constants.OSWindows {
+		if shell := os.Getenv("SHELL"); shell != "" {
+			cmd = exec.Command(shell, "-i", "-c", command)
+		}
+	}
+	cmd.Stdout = io.MultiWriter(os.Stdout, log)
+	cmd.Stderr = io.MultiWriter(os.Stderr, log)
 
 	return cmd.Run()
 }
 
 func init() {
+	migrateCmd.Flags().BoolVar(&migrateAdopt, "adopt", false, "Copy the existing installations into dtvem instead of downloading them")
+	migrateCmd.Flags().BoolVar(&migrateLink, "link", false, "With --adopt, link to the existing installations instead of copying them")
+	migrateCmd.Flags().BoolVar(&migrateAll, "all", false, "Migrate every detected version without prompting")
+	migrateCmd.Flags().StringSliceVar(&migrateVersions, "versions", nil, "Migrate these versions without prompting (e.g., 18.20.0,20.11.1)")
+	migrateCmd.Flags().StringSliceVar(&migrateFrom, "from", nil, "Only consider versions from these version managers (e.g., nvm)")
+	migrateCmd.Flags().StringVar(&migrateSetGlobal, "set-global", "", "Set this migrated version as the global version")
+	migrateCmd.Flags().BoolVar(&migrateSkipPackages, "skip-packages", false, "Don't reinstall global packages")
+	migrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "Print the migration plan without changing anything")
+	migrateCmd.Flags().BoolVar(&migrateCleanup, "cleanup", false, "After migrating, uninstall the old installations and remove their version managers from shell startup files")
 	rootCmd.AddCommand(migrateCmd)
 }
@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"errors"
 	"reflect"
 	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/migration"
 )
 
 func TestParseSelection(t *testing.T) {
@@ -179,3 +182,124 @@ func TestParseSelection_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+// migrationMock is a minimal migration.Provider for selection tests
+type migrationMock struct {
+	name    string
+	canAuto bool
+}
+
+func (m *migrationMock) Name() string                                         { return m.name }
+func (m *migrationMock) DisplayName() string                                  { return m.name }
+func (m *migrationMock) Runtime() string                                      { return "node" }
+func (m *migrationMock) IsPresent() bool                                      { return true }
+func (m *migrationMock) DetectVersions() ([]migration.DetectedVersion, error) { return nil, nil }
+func (m *migrationMock) CanAutoUninstall() bool                               { return m.canAuto }
+func (m *migrationMock) UninstallCommand(version string) string {
+	return m.name + " uninstall " + version
+}
+func (m *migrationMock) ManualInstructions() string { return "" }
+
+// migrationCandidates returns 20.11.1 from nvm, and 18.20.0 and 20.11.1
+// from asdf
+func migrationCandidates() []detectedVersionWithProvider {
+	nvm := &migrationMock{name: "nvm", canAuto: true}
+	asdf := &migrationMock{name: "asdf-node", canAuto: true}
+	return []detectedVersionWithProvider{
+		{DetectedVersion: migration.DetectedVersion{Version: "20.11.1", Source: "nvm", Path: "/nvm/v20.11.1/bin/node"}, MigrationProvider: nvm},
+		{DetectedVersion: migration.DetectedVersion{Version: "18.20.0", Source: "asdf", Path: "/asdf/18.20.0/bin/node"}, MigrationProvider: asdf},
+		{DetectedVersion: migration.DetectedVersion{Version: "20.11.1", Source: "asdf", Path: "/asdf/20.11.1/bin/node"}, MigrationProvider: asdf},
+	}
+}
+
+func TestFilterBySource(t *testing.T) {
+	tests := []struct {
+		name     string
+		sources  []string
+		expected []string // paths
+	}{
+		{name: "no filter", sources: nil, expected: []string{"/nvm/v20.11.1/bin/node", "/asdf/18.20.0/bin/node", "/asdf/20.11.1/bin/node"}},
+		{name: "by source", sources: []string{"nvm"}, expected: []string{"/nvm/v20.11.1/bin/node"}},
+		{name: "by provider name", sources: []string{"ASDF-node"}, expected: []string{"/asdf/18.20.0/bin/node", "/asdf/20.11.1/bin/node"}},
+		{name: "unknown", sources: []string{"volta"}, expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]string, 0)
+			for _, dv := range filterBySource(migrationCandidates(), tt.sources) {
+				got = append(got, dv.Path)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("filterBySource(%v) = %v, want %v", tt.sources, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSelectVersions(t *testing.T) {
+	selected, err := selectVersions(migrationCandidates(), []string{"v20.11.1", "18.20.0", "20.11.1"})
+	if err != nil {
+		t.Fatalf("selectVersions() error = %v", err)
+	}
+	got := make([]string, 0)
+	for _, dv := range selected {
+		got = append(got, dv.Path)
+	}
+	// The first source of a version wins, and each version is selected once
+	expected := []string{"/nvm/v20.11.1/bin/node", "/asdf/18.20.0/bin/node"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("selectVersions() = %v, want %v", got, expected)
+	}
+
+	if _, err := selectVersions(migrationCandidates(), []string{"20.11.1", "22.0.0"}); err == nil {
+		t.Error("selectVersions() should fail for a version that was not detected")
+	}
+}
+
+func TestBuildMigrationPlan(t *testing.T) {
+	t.Cleanup(func() {
+		migrateAdopt, migrateSkipPackages, migrateSetGlobal = false, false, ""
+	})
+	provider := &mockProvider{name: "node", displayName: "Node.js"}
+	candidates := migrationCandidates()
+	candidates[1].MigrationProvider = &migrationMock{name: "asdf-node"} // no auto uninstall
+
+	migrateSetGlobal = "v18.20.0"
+	plan := buildMigrationPlan(provider, candidates[:2])
+	if plan.Runtime != "node" || plan.SetGlobal != "18.20.0" || len(plan.Versions) != 2 {
+		t.Fatalf("buildMigrationPlan() = %+v", plan)
+	}
+	first, second := plan.Versions[0], plan.Versions[1]
+	if first.Action != "install" || first.UninstallCommand != "nvm uninstall 20.11.1" || first.Packages == nil {
+		t.Errorf("first planned migration = %+v", first)
+	}
+	if second.UninstallCommand != "" {
+		t.Errorf("UninstallCommand = %q, want empty for manual removal", second.UninstallCommand)
+	}
+
+	migrateAdopt = true
+	plan = buildMigrationPlan(provider, candidates[:1])
+	if plan.Versions[0].Action != "adopt" || plan.Versions[0].Error != "" {
+		t.Errorf("adopt planned migration = %+v", plan.Versions[0])
+	}
+}
+
+func TestMigrateVersion_SkipsInstalled(t *testing.T) {
+	provider := &mockProvider{
+		name:        "node",
+		displayName: "Node.js",
+		installErr:  errors.New("already installed"),
+		installed:   map[string]bool{"20.11.1": true},
+	}
+	candidates := migrationCandidates()
+
+	if err := migrateVersion(provider, candidates[0]); err != nil {
+		t.Errorf("migrateVersion() error = %v for an installed version, want it skipped", err)
+	}
+
+	plan := buildMigrationPlan(provider, candidates[:1])
+	if plan.Versions[0].Error == "" {
+		t.Errorf("planned migration = %+v, want it marked as skipped", plan.Versions[0])
+	}
+}
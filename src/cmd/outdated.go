@@ -0,0 +1,348 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/lifecycle"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/output"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/tui"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/version"
+	"github.com/spf13/cobra"
+)
+
+// Conditions accepted by dtvem outdated --fail-on
+const (
+	failOnEOL   = "eol"
+	failOnPatch = "patch"
+	failOnMinor = "minor"
+	failOnMajor = "major"
+)
+
+var outdatedFailOn []string
+
+var outdatedCmd = &cobra.Command{
+	Use:   "outdated [runtime]",
+	Short: "Show installed and configured versions with newer releases",
+	Long: `List every installed version, and every version configured globally or for
+the current directory, with the newest available release in each direction:
+
+  Patch   Newest release with the same major.minor, e.g. 20.11.0 → 20.11.1
+  Minor   Newest release with a higher minor and the same major, e.g. 20.18.0
+  Major   Newest release with a higher major, e.g. 22.12.0
+
+A "-" means there is no newer release of that kind. The Status column shows
+the upstream support status for runtimes that publish one (e.g. Node.js
+"Active LTS" or "EOL").
+
+With --fail-on, outdated exits non-zero when any listed version matches one
+of the conditions, or when the available versions of a runtime could not be
+fetched, so CI can block merges on unsupported runtimes:
+  eol     The version has reached end of life
+  patch   A newer patch release exists
+  minor   A newer minor release exists
+  major   A newer major release exists
+
+Examples:
+  dtvem outdated                       # Every runtime
+  dtvem outdated node                  # Only Node.js
+  dtvem outdated --fail-on eol         # Fail if anything is end of life
+  dtvem outdated --fail-on eol,major   # Also fail on newer majors
+  dtvem outdated -o json`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, condition := range outdatedFailOn {
+			if !isFailOnCondition(condition) {
+				reportError(fmt.Sprintf("Invalid --fail-on value: %s", condition),
+					fmt.Sprintf("Valid values: %s, %s, %s, %s", failOnEOL, failOnPatch, failOnMinor, failOnMajor))
+				os.Exit(1)
+			}
+		}
+
+		var providers []runtime.Provider
+		if len(args) == 1 {
+			provider, err := runtime.Get(args[0])
+			if err != nil {
+				reportError(fmt.Sprintf("Unknown runtime: %s", args[0]), fmt.Sprintf("Available runtimes: %v", runtime.List()))
+				os.Exit(1)
+			}
+			providers = []runtime.Provider{provider}
+		} else {
+			providers = runtime.GetAll()
+			sort.Slice(providers, func(i, j int) bool { return providers[i].Name() < providers[j].Name() })
+		}
+
+		if !output.IsStructured() {
+			ui.Info("Fetching available versions...")
+		}
+
+		result := OutdatedOutput{Runtimes: []OutdatedRuntime{}}
+		for _, provider := range providers {
+			if rt, ok := outdatedRuntime(provider); ok {
+				result.Runtimes = append(result.Runtimes, rt)
+			}
+		}
+
+		if output.IsStructured() {
+			printResult(result)
+		} else {
+			renderOutdated(result)
+		}
+
+		if failed := result.failing(outdatedFailOn); len(failed) > 0 {
+			if !output.IsStructured() {
+				ui.Error("Failing because of: %s", strings.Join(failed, ", "))
+			}
+			os.Exit(1)
+		}
+	},
+}
+
+// OutdatedOutput is the --output json|yaml result of "dtvem outdated"
+type OutdatedOutput struct {
+	Runtimes []OutdatedRuntime `json:"runtimes" yaml:"runtimes"`
+}
+
+// OutdatedRuntime lists the installed and configured versions of a runtime
+type OutdatedRuntime struct {
+	Runtime     string            `json:"runtime" yaml:"runtime"`
+	DisplayName string            `json:"displayName" yaml:"displayName"`
+	Versions    []OutdatedVersion `json:"versions" yaml:"versions"`
+	// Error is set when the available versions could not be fetched; the
+	// versions are listed without upgrades
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// OutdatedVersion is one installed or configured version and the newest
+// available releases it could move to
+type OutdatedVersion struct {
+	Version   string `json:"version" yaml:"version"`
+	Installed bool   `json:"installed" yaml:"installed"`
+	Global    bool   `json:"global" yaml:"global"`
+	Local     bool   `json:"local" yaml:"local"`
+	Patch     string `json:"patch,omitempty" yaml:"patch,omitempty"`
+	Minor     string `json:"minor,omitempty" yaml:"minor,omitempty"`
+	Major     string `json:"major,omitempty" yaml:"major,omitempty"`
+	// LifecycleStatus is the upstream support status (e.g., "EOL"), when the
+	// runtime publishes one
+	LifecycleStatus string `json:"lifecycleStatus,omitempty" yaml:"lifecycleStatus,omitempty"`
+}
+
+// matches reports whether the version meets a --fail-on condition
+func (v OutdatedVersion) matches(condition string) bool {
+	switch condition {
+	case failOnEOL:
+		return v.LifecycleStatus == string(lifecycle.EOL)
+	case failOnPatch:
+		return v.Patch != ""
+	case failOnMinor:
+		return v.Minor != ""
+	case failOnMajor:
+		return v.Major != ""
+	}
+	return false
+}
+
+// failing returns the "runtime version (condition)" entries that meet any
+// of the --fail-on conditions. A runtime whose available versions could not
+// be fetched fails as well, since its versions could not be checked.
+func (o OutdatedOutput) failing(conditions []string) []string {
+	if len(conditions) == 0 {
+		return nil
+	}
+
+	var failed []string
+	for _, rt := range o.Runtimes {
+		if rt.Error != "" {
+			failed = append(failed, fmt.Sprintf("%s (could not fetch available versions)", rt.DisplayName))
+		}
+		for _, v := range rt.Versions {
+			for _, condition := range conditions {
+				if v.matches(condition) {
+					failed = append(failed, fmt.Sprintf("%s %s (%s)", rt.DisplayName, v.Version, condition))
+					break
+				}
+			}
+		}
+	}
+	return failed
+}
+
+// isFailOnCondition reports whether s is a valid --fail-on value
+func isFailOnCondition(s string) bool {
+	switch s {
+	case failOnEOL, failOnPatch, failOnMinor, failOnMajor:
+		return true
+	}
+	return false
+}
+
+// outdatedRuntime collects the installed and configured versions of a
+// runtime with their upgrades. Returns false if the runtime has none.
+func outdatedRuntime(provider runtime.Provider) (OutdatedRuntime, bool) {
+	installed := config.InstalledVersions(provider.Name())
+	globalSpec, _ := provider.GlobalVersion()
+	localSpec, _ := config.LocalVersion(provider.Name())
+	if len(installed) == 0 && globalSpec == "" && localSpec == "" {
+		return OutdatedRuntime{}, false
+	}
+
+	rt := OutdatedRuntime{Runtime: provider.Name(), DisplayName: provider.DisplayName()}
+	available, err := provider.ListAvailable()
+	if err != nil {
+		rt.Error = err.Error()
+		if !output.IsStructured() {
+			ui.Warning("Failed to fetch available %s versions: %v", provider.DisplayName(), err)
+		}
+	}
+
+	rt.Versions = outdatedVersions(provider, installed, globalSpec, localSpec, available)
+	return rt, true
+}
+
+// outdatedVersions builds the rows of one runtime: the installed versions
+// plus the global and local versions, resolved to exact versions, newest
+// first
+func outdatedVersions(provider runtime.Provider, installed []string, globalSpec, localSpec string, available []runtime.AvailableVersion) []OutdatedVersion {
+	availableVersions := make([]string, len(available))
+	statuses := make(map[string]string, len(available))
+	for i, av := range available {
+		availableVersions[i] = av.Version.Raw
+		statuses[av.Version.Raw] = av.LifecycleStatus
+	}
+
+	globalVersion := resolveConfiguredVersion(provider, globalSpec, availableVersions)
+	localVersion := resolveConfiguredVersion(provider, localSpec, availableVersions)
+
+	rows := make(map[string]*OutdatedVersion)
+	add := func(v string) *OutdatedVersion {
+		if row, ok := rows[v]; ok {
+			return row
+		}
+		row := &OutdatedVersion{Version: v}
+		rows[v] = row
+		return row
+	}
+	for _, v := range installed {
+		add(v).Installed = true
+	}
+	if globalVersion != "" {
+		add(globalVersion).Global = true
+	}
+	if localVersion != "" {
+		add(localVersion).Local = true
+	}
+
+	statusProvider, hasStatusProvider := provider.(lifecycle.StatusProvider)
+	result := make([]OutdatedVersion, 0, len(rows))
+	for _, row := range rows {
+		row.Patch, row.Minor, row.Major = newerReleases(row.Version, availableVersions)
+		if hasStatusProvider {
+			row.LifecycleStatus = statusProvider.VersionStatus(row.Version)
+		} else {
+			row.LifecycleStatus = statuses[row.Version]
+		}
+		result = append(result, *row)
+	}
+	sort.Slice(result, func(i, j int) bool { return version.Compare(result[i].Version, result[j].Version) > 0 })
+	return result
+}
+
+// resolveConfiguredVersion turns a configured version, which may be a range
+// or alias, into an exact version: the installed version it selects, or else
+// the newest available one. Unresolvable specs are returned unchanged.
+func resolveConfiguredVersion(provider runtime.Provider, spec string, available []string) string {
+	if spec == "" {
+		return ""
+	}
+	if resolved, err := runtime.ResolveInstalledVersion(provider, spec); err == nil {
+		return resolved
+	}
+	if resolved, err := runtime.ResolveVersionSpec(provider, spec, available); err == nil {
+		return resolved
+	}
+	return spec
+}
+
+// newerReleases returns the newest available patch, minor and major
+// releases above current. Each is empty unless it moves that part of the
+// version: the minor must have a higher minor, the major a higher major.
+func newerReleases(current string, available []string) (patch, minor, major string) {
+	patch, _ = version.Upgrade(current, version.UpgradePatch, available)
+	if v, ok := version.Upgrade(current, version.UpgradeMinor, available); ok && v != patch {
+		minor = v
+	}
+	if v, ok := version.Upgrade(current, version.UpgradeMajor, available); ok && v != minor && v != patch {
+		major = v
+	}
+	return patch, minor, major
+}
+
+// renderOutdated prints one table per runtime followed by a summary
+func renderOutdated(result OutdatedOutput) {
+	if len(result.Runtimes) == 0 {
+		ui.Info("No versions installed or configured")
+		return
+	}
+
+	eol, upgradable := 0, 0
+	for _, rt := range result.Runtimes {
+		table := tui.NewTable("Version", "In Use", "Patch", "Minor", "Major", "Status")
+		table.SetTitle(rt.DisplayName)
+
+		for _, v := range rt.Versions {
+			global, local := "", ""
+			if v.Global {
+				global = v.Version
+			}
+			if v.Local {
+				local = v.Version
+			}
+			inUse := getVersionStatus(v.Version, global, local)
+			if !v.Installed {
+				inUse = strings.TrimPrefix(inUse+", not installed", ", ")
+			}
+
+			table.AddRow(v.Version, inUse, orDash(v.Patch), orDash(v.Minor), orDash(v.Major), tui.RenderLifecycleStatus(v.LifecycleStatus))
+
+			if v.matches(failOnEOL) {
+				eol++
+			}
+			if v.Patch != "" || v.Minor != "" || v.Major != "" {
+				upgradable++
+			}
+		}
+
+		fmt.Println()
+		fmt.Println(table.Render())
+	}
+
+	fmt.Println()
+	if eol > 0 {
+		ui.Warning("%d version(s) have reached end of life", eol)
+	}
+	if upgradable == 0 {
+		ui.Success("All versions are up to date")
+		return
+	}
+	ui.Info("%d version(s) have newer releases", upgradable)
+	ui.Info("Move pinned versions with: dtvem upgrade [--minor|--major]")
+}
+
+// orDash returns s, or "-" if it is empty
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func init() {
+	outdatedCmd.Flags().StringSliceVar(&outdatedFailOn, "fail-on", nil, "Exit non-zero if any version matches: eol, patch, minor, major")
+	rootCmd.AddCommand(outdatedCmd)
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
+)
+
+func TestNewerReleases(t *testing.T) {
+	available := []string{"20.11.0", "20.11.1", "20.18.0", "22.12.0"}
+
+	tests := []struct {
+		current             string
+		patch, minor, major string
+	}{
+		{"20.11.0", "20.11.1", "20.18.0", "22.12.0"},
+		{"20.18.0", "", "", "22.12.0"},
+		{"20.11.1", "", "20.18.0", "22.12.0"},
+		{"22.12.0", "", "", ""},
+	}
+	for _, tt := range tests {
+		patch, minor, major := newerReleases(tt.current, available)
+		if patch != tt.patch || minor != tt.minor || major != tt.major {
+			t.Errorf("newerReleases(%s) = %q, %q, %q; want %q, %q, %q", tt.current, patch, minor, major, tt.patch, tt.minor, tt.major)
+		}
+	}
+}
+
+func TestOutdatedVersions(t *testing.T) {
+	provider := &mockProvider{name: "outdated-test", displayName: "Outdated Test"}
+	available := []runtime.AvailableVersion{
+		{Version: runtime.NewVersion("18.20.4"), LifecycleStatus: "EOL"},
+		{Version: runtime.NewVersion("20.11.0"), LifecycleStatus: "Maintenance LTS"},
+		{Version: runtime.NewVersion("20.18.0"), LifecycleStatus: "Maintenance LTS"},
+	}
+
+	rows := outdatedVersions(provider, []string{"18.20.4", "20.18.0"}, "20.18.0", "20.11.0", available)
+	if len(rows) != 3 {
+		t.Fatalf("outdatedVersions returned %d rows, want 3: %+v", len(rows), rows)
+	}
+
+	// Newest first; the local version is configured but not installed
+	if rows[0].Version != "20.18.0" || !rows[0].Installed || !rows[0].Global {
+		t.Errorf("rows[0] = %+v, want installed global 20.18.0", rows[0])
+	}
+	if rows[1].Version != "20.11.0" || rows[1].Installed || !rows[1].Local || rows[1].Minor != "20.18.0" {
+		t.Errorf("rows[1] = %+v, want local 20.11.0 with minor 20.18.0", rows[1])
+	}
+	if rows[2].Version != "18.20.4" || rows[2].Major != "20.18.0" || rows[2].LifecycleStatus != "EOL" {
+		t.Errorf("rows[2] = %+v, want EOL 18.20.4 with major 20.18.0", rows[2])
+	}
+
+	result := OutdatedOutput{Runtimes: []OutdatedRuntime{{DisplayName: "Outdated Test", Versions: rows}}}
+	if failed := result.failing([]string{failOnEOL}); len(failed) != 1 {
+		t.Errorf("failing(eol) = %v, want only 18.20.4", failed)
+	}
+	if failed := result.failing([]string{failOnPatch}); len(failed) != 0 {
+		t.Errorf("failing(patch) = %v, want none", failed)
+	}
+	if failed := result.failing([]string{failOnMinor, failOnMajor}); len(failed) != 2 {
+		t.Errorf("failing(minor, major) = %v, want 20.11.0 and 18.20.4", failed)
+	}
+}
+
+func TestOutdatedFailing_FetchError(t *testing.T) {
+	result := OutdatedOutput{Runtimes: []OutdatedRuntime{{
+		DisplayName: "Outdated Test",
+		Versions:    []OutdatedVersion{{Version: "20.11.0", Installed: true}},
+		Error:       "connection refused",
+	}}}
+
+	if failed := result.failing([]string{failOnPatch}); len(failed) != 1 {
+		t.Errorf("failing(patch) = %v, want the runtime that could not be checked", failed)
+	}
+	if failed := result.failing(nil); len(failed) != 0 {
+		t.Errorf("failing() without conditions = %v, want none", failed)
+	}
+}
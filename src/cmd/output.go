@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/output"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+)
+
+// reportError reports a command failure. In text mode the message and hints
+// are printed and the caller returns as usual; with --output json|yaml an
+// error object is written to stdout and the process exits non-zero.
+func reportError(message string, hints ...string) {
+	if output.IsStructured() {
+		_ = output.PrintError(message, hints...)
+		os.Exit(1)
+	}
+
+	ui.Error("%s", message)
+	for _, hint := range hints {
+		ui.Info("%s", hint)
+	}
+}
+
+// printResult writes a command's result in the --output format
+func printResult(v interface{}) {
+	if err := output.Print(v); err != nil {
+		ui.Error("Failed to encode output: %v", err)
+		os.Exit(1)
+	}
+}
@@ -19,7 +19,14 @@ var reshimCmd = &cobra.Command{
 	Long: `Regenerate shim binaries for all installed runtime versions.
 
 This command scans all installed runtimes and creates shims for their executables.
-Run this command after installing new versions or if shims become corrupted.
+Shims whose executables no installed version provides anymore (e.g., after
+'npm uninstall -g') are removed. Run this command after installing new versions
+or if shims become corrupted.
+
+On Linux and macOS, shims are copies of the dtvem-shim binary by default. Set
+"shimStrategy" in settings.json (or DTVEM_SHIM_STRATEGY) to "symlink" or
+"hardlink" to link them to it instead, then run dtvem reshim. Windows always
+uses copies.
 
 Example:
   dtvem reshim`,
@@ -88,8 +95,13 @@ Example:
 			table.AddRow(displayName, shimList)
 		}
 
-		fmt.Println(table.Render())
-		fmt.Println()
+		if len(runtimeNames) > 0 {
+			fmt.Println(table.Render())
+			fmt.Println()
+		}
+		if len(result.Removed) > 0 {
+			ui.Info("Removed %d orphaned shim(s): %s", len(result.Removed), strings.Join(result.Removed, ", "))
+		}
 		ui.Success("Created %d shims for %d runtime(s)", result.TotalShims, len(result.ShimsByRuntime))
 	},
 }
@@ -3,33 +3,77 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/output"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/tui"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
-var verbose bool
+var (
+	verbose      bool
+	offline      bool
+	outputFormat output.Format
+)
 
 var rootCmd = &cobra.Command{
 	Use:   "dtvem",
 	Short: "Developer Tools Virtual Environment Manager",
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		ui.SetVerbose(verbose)
+		config.SetOffline(offline)
 	},
 }
 
+// applyOutputFormat selects the --output format. It runs before argument
+// validation so that usage errors are also reported in that format.
+func applyOutputFormat() {
+	output.SetFormat(outputFormat)
+	ui.SetStderrMode(output.IsStructured())
+	if output.IsStructured() {
+		// Errors are written as an error object by Execute instead
+		rootCmd.SilenceErrors = true
+		rootCmd.SilenceUsage = true
+	}
+}
+
+// presetOutputFormat reads --output from the command line before Cobra
+// does. Cobra reports some errors, such as an unknown command, before it
+// parses any flags; this way they are written in the --output format too.
+// Invalid values are left for Cobra to report.
+func presetOutputFormat(args []string) {
+	flags := pflag.NewFlagSet("dtvem", pflag.ContinueOnError)
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	flags.SetOutput(io.Discard)
+	flags.VarP(&outputFormat, "output", "o", "")
+	_ = flags.Parse(args)
+	applyOutputFormat()
+}
+
 func Execute() {
 	// Check for --version or -v flag before Cobra parses
-	for _, arg := range os.Args[1:] {
+	args := os.Args[1:]
+	for i, arg := range args {
+		// Arguments after "--" or "exec" belong to another program
+		if arg == "--" || arg == execCmd.Name() {
+			args = args[:i]
+			break
+		}
 		if arg == "--version" || arg == "-v" {
 			versionCmd.Run(versionCmd, []string{})
 			return
 		}
 	}
+	presetOutputFormat(args)
 
 	if err := rootCmd.Execute(); err != nil {
+		if output.IsStructured() {
+			_ = output.PrintError(err.Error())
+		}
 		// Error already printed by Cobra, just exit with error code
 		os.Exit(1)
 	}
@@ -42,6 +86,17 @@ func init() {
 	// Add global verbose flag
 	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Enable verbose output for debugging")
 
+	// Add global offline flag
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false, "Use only cached downloads and embedded manifests (no network access)")
+
+	// Add global output format flag
+	rootCmd.PersistentFlags().VarP(&outputFormat, "output", "o", "Output format for query commands: text, json or yaml")
+	cobra.OnInitialize(applyOutputFormat)
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		applyOutputFormat()
+		return err
+	})
+
 	// Set custom usage and help functions with TUI table for commands
 	rootCmd.SetUsageFunc(customUsage)
 	rootCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/output"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+)
+
+func TestPresetOutputFormat(t *testing.T) {
+	t.Cleanup(func() {
+		outputFormat = ""
+		output.SetFormat(output.FormatText)
+		ui.SetStderrMode(false)
+		rootCmd.SilenceErrors, rootCmd.SilenceUsage = false, false
+	})
+
+	tests := []struct {
+		name       string
+		args       []string
+		structured bool
+	}{
+		{"unknown command", []string{"bogus", "-o", "json"}, true},
+		{"before the command", []string{"--output=yaml", "bogus"}, true},
+		{"among unknown flags", []string{"install", "--bogus", "node", "-o", "json"}, true},
+		{"invalid format", []string{"list", "-o", "xml"}, false},
+		{"no format", []string{"list"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputFormat = ""
+			output.SetFormat(output.FormatText)
+
+			presetOutputFormat(tt.args)
+			if output.IsStructured() != tt.structured {
+				t.Errorf("IsStructured() = %v, want %v", output.IsStructured(), tt.structured)
+			}
+			if tt.structured && !rootCmd.SilenceErrors {
+				t.Error("Cobra errors are not silenced for structured output")
+			}
+		})
+	}
+}
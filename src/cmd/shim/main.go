@@ -16,6 +16,7 @@ import (
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/shim"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/venv"
 
 	// Import runtime providers to register them
 	_ "github.com/CodingWithCalvin/dtvem.cli/src/runtimes/node"
@@ -51,12 +52,28 @@ func runShim() error {
 	}
 
 	// Resolve which version to use
-	version, err := config.ResolveVersion(runtimeName)
+	resolved, err := config.ResolveVersionSource(runtimeName)
 	if err != nil {
 		ui.Debug("Version resolution failed: %v", err)
 		// No dtvem version configured - try to fallback to system PATH
 		return handleNoConfiguredVersion(shimName, runtimeName, provider)
 	}
+	ui.Debug("Configured version: %s (from %s)", resolved.Version, resolved.Source)
+
+	// Ranges and aliases (e.g. "^20.10", "lts/iron") are matched against
+	// installed versions at run time
+	version, err := runtime.ResolveInstalledVersion(provider, resolved.Version)
+	if err != nil && config.ShimAutoInstallEnabled() {
+		ui.Debug("Version spec resolution failed: %v", err)
+		version, err = autoInstall(provider, resolved.Version)
+	}
+	if err != nil {
+		ui.Debug("Version spec resolution failed: %v", err)
+		ui.Error("No installed %s version satisfies %s", provider.DisplayName(), resolved.Version)
+		ui.Info("To install, run: dtvem install %s %q", runtimeName, resolved.Version)
+		autoInstallHint()
+		return fmt.Errorf("version not installed")
+	}
 	ui.Debug("Resolved version: %s", version)
 
 	// If this is a secondary executable (e.g. uv mapped to python) and the
@@ -79,10 +96,18 @@ func runShim() error {
 		return fmt.Errorf("could not check if %s %s is installed: %w", runtimeName, version, err)
 	}
 
+	if !installed && config.ShimAutoInstallEnabled() {
+		ui.Debug("Version %s is not installed", version)
+		if _, err := autoInstall(provider, version); err == nil {
+			installed = true
+		}
+	}
+
 	if !installed {
 		ui.Debug("Version %s is not installed", version)
 		ui.Error("%s %s is configured but not installed", provider.DisplayName(), version)
 		ui.Info("To install, run: dtvem install %s %s", runtimeName, version)
+		autoInstallHint()
 		return fmt.Errorf("version not installed")
 	}
 
@@ -111,21 +136,33 @@ func runShim() error {
 		ui.Debug("Failed to get provider environment: %v", err)
 		providerEnv = map[string]string{}
 	}
+
+	// Inside a project with a venv, its executables are preferred. Packages
+	// installed there don't get shims, so no reshim is needed.
+	needsReshim := provider.ShouldReshimAfter(shimName, os.Args[1:])
+	if runtimeName == venv.Runtime {
+		if venvPath, venvDir, ok := projectVenvExecutable(shimName, version); ok {
+			execPath = venvPath
+			if providerEnv == nil {
+				providerEnv = map[string]string{}
+			}
+			providerEnv["VIRTUAL_ENV"] = venvDir
+			needsReshim = false
+			ui.Debug("Using project venv: %s", execPath)
+		}
+	}
 	for k, v := range providerEnv {
 		ui.Debug("Provider env: %s=%s", k, v)
 	}
 
-	// Check if this command should trigger a reshim after execution
-	needsReshim := provider.ShouldReshimAfter(shimName, os.Args[1:])
-
 	// Execute the actual binary
 	if needsReshim {
 		// Need to run code after execution, so use exec.Command
 		exitCode := executeCommandWithWait(execPath, os.Args[1:], providerEnv)
 
-		// If command succeeded, prompt for reshim
+		// If command succeeded, add shims for any new executables
 		if exitCode == 0 {
-			promptReshim()
+			reshimAfterCommand(provider, version)
 		}
 
 		os.Exit(exitCode)
@@ -139,6 +176,54 @@ func runShim() error {
 	return nil
 }
 
+// projectVenvExecutable finds the executable for a shim in the venv the
+// current project declares, along with the venv directory. A venv built
+// with another Python version than the resolved one is not used; a
+// warning, shown once per venv state, asks for a rebuild instead.
+// Executables the venv lacks (e.g., tools installed into the base Python)
+// come from the base installation.
+func projectVenvExecutable(shimName, version string) (string, string, bool) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", "", false
+	}
+	// The command's stdout may be piped; keep warnings off it
+	ui.SetStderrMode(true)
+
+	project, err := venv.Find(cwd)
+	if err != nil {
+		if !errors.Is(err, venv.ErrNotDeclared) {
+			ui.Warning("Ignoring the project venv: %v", err)
+		}
+		return "", "", false
+	}
+
+	status := project.Check(version)
+	warn := project.ShouldWarn(status)
+	switch status.State {
+	case venv.Missing:
+		if warn {
+			ui.Warning("Project venv %s has not been created; run 'dtvem venv create'", project.Dir())
+		}
+		return "", "", false
+	case venv.Stale:
+		if warn {
+			ui.Warning("Project venv %s needs a rebuild (%s); run 'dtvem venv sync'", project.Dir(), status.Reason)
+		}
+		return "", "", false
+	}
+
+	execPath := project.Interpreter()
+	if shimName != venv.Runtime {
+		execPath, err = shim.FindSecondaryExecutable(project.Interpreter(), shimName)
+		if err != nil {
+			ui.Debug("%s not in project venv: %v", shimName, err)
+			return "", "", false
+		}
+	}
+	return execPath, project.Dir(), true
+}
+
 // handleNoConfiguredVersion handles the case when no dtvem version is configured
 // It attempts to fallback to system PATH or prompts for installation
 func handleNoConfiguredVersion(shimName, runtimeName string, provider runtime.ShimProvider) error {
@@ -175,6 +260,66 @@ func handleNoConfiguredVersion(shimName, runtimeName string, provider runtime.Sh
 	return fmt.Errorf("no version configured")
 }
 
+// autoInstall installs a missing version matching spec by running the full
+// dtvem binary, then returns the installed version the command should run
+// with. The runtime's auto-install lock is held throughout, so parallel
+// invocations (e.g., from a build tool) wait for one install and then find
+// the version installed instead of installing it again.
+func autoInstall(provider runtime.ShimProvider, spec string) (string, error) {
+	// The command's stdout may be piped; keep install output off it
+	ui.SetStderrMode(true)
+
+	lock, err := config.AcquireLock(config.AutoInstallLockName(provider.Name()))
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = lock.Release() }()
+
+	if version, err := installedVersion(provider, spec); err == nil {
+		ui.Debug("%s %s was installed while waiting", provider.DisplayName(), version)
+		return version, nil
+	}
+
+	dtvemPath, err := findDtvemExecutable()
+	if err != nil {
+		return "", err
+	}
+
+	ui.Info("Installing %s %s...", provider.DisplayName(), spec)
+	cmd := exec.Command(dtvemPath, "install", provider.Name(), spec)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		ui.Error("Failed to install %s %s", provider.DisplayName(), spec)
+		return "", err
+	}
+
+	return installedVersion(provider, spec)
+}
+
+// autoInstallHint tells the user how to let shims install missing versions
+func autoInstallHint() {
+	if !config.ShimAutoInstallEnabled() {
+		ui.Info("To install missing versions automatically, set %s=true", config.AutoInstallEnvVar)
+	}
+}
+
+// installedVersion resolves spec to an installed version
+func installedVersion(provider runtime.ShimProvider, spec string) (string, error) {
+	version, err := runtime.ResolveInstalledVersion(provider, spec)
+	if err != nil {
+		return "", err
+	}
+	installed, err := provider.IsInstalled(version)
+	if err != nil {
+		return "", err
+	}
+	if !installed {
+		return "", fmt.Errorf("%s %s is not installed", provider.DisplayName(), version)
+	}
+	return version, nil
+}
+
 // getShimName returns the name of this shim binary based on os.Args[0].
 func getShimName() string {
 	return shimNameFromPath(os.Args[0])
@@ -291,7 +436,7 @@ func executeCommand(execPath string, args []string, providerEnv map[string]strin
 	fullArgs := append([]string{execPath}, args...)
 
 	// Get current environment and apply provider overrides
-	env := mergeEnvironment(os.Environ(), providerEnv)
+	env := runtime.MergeEnvironment(os.Environ(), providerEnv)
 
 	// On Unix systems, use Exec to replace the current process
 	// On Windows, Exec is not available, so we use StartProcess
@@ -327,7 +472,7 @@ func executeCommandWithWait(execPath string, args []string, providerEnv map[stri
 	fullArgs := append([]string{execPath}, args...)
 
 	// Get current environment and apply provider overrides
-	env := mergeEnvironment(os.Environ(), providerEnv)
+	env := runtime.MergeEnvironment(os.Environ(), providerEnv)
 
 	// Use exec.Command to run the command and wait for completion
 	cmd := &exec.Cmd{
@@ -352,82 +497,69 @@ func executeCommandWithWait(execPath string, args []string, providerEnv map[stri
 	return 0
 }
 
-// mergeEnvironment merges provider environment variables into the base environment.
-// Provider variables are prepended to existing values (for PATH-like variables) or set directly.
-func mergeEnvironment(baseEnv []string, providerEnv map[string]string) []string {
-	if len(providerEnv) == 0 {
-		return baseEnv
+// reshimAfterCommand creates shims for executables a package manager
+// command added to the active version (e.g., tsc after npm install -g
+// typescript), following the configured reshim policy. Prompting needs a
+// terminal; without one (CI, editors, piped input) the shims are created
+// without asking, so the shim never blocks on or consumes stdin meant for
+// another process.
+func reshimAfterCommand(provider runtime.ShimProvider, version string) {
+	// The command's stdout may be piped; keep reshim messages off it
+	ui.SetStderrMode(true)
+
+	policy := config.GetReshimPolicy()
+	if policy == config.ReshimPolicyNever {
+		ui.Debug("Reshim policy is %s; not updating shims", policy)
+		return
 	}
 
-	// Build a map of existing environment variables for easy lookup
-	envMap := make(map[string]string)
-	for _, e := range baseEnv {
-		if idx := strings.Index(e, "="); idx != -1 {
-			key := e[:idx]
-			value := e[idx+1:]
-			envMap[key] = value
-		}
+	pending := shim.PendingShimsForVersion(provider.Name(), version)
+	if len(pending) == 0 {
+		ui.Debug("No new executables in %s %s", provider.DisplayName(), version)
+		return
 	}
 
-	// Apply provider environment variables
-	// For PATH-like variables (LD_LIBRARY_PATH, DYLD_LIBRARY_PATH), prepend the new value
-	for key, value := range providerEnv {
-		if existing, ok := envMap[key]; ok && existing != "" {
-			// Prepend new value to existing (for PATH-like variables)
-			envMap[key] = value + string(filepath.ListSeparator) + existing
-		} else {
-			envMap[key] = value
-		}
+	if policy == config.ReshimPolicyPrompt && isInteractive() && !confirmReshim(pending) {
+		ui.Info("Remember to run 'dtvem reshim' when you want to use the new executables")
+		return
 	}
 
-	// Convert back to slice format
-	result := make([]string, 0, len(envMap))
-	for key, value := range envMap {
-		result = append(result, key+"="+value)
+	shimSource, err := os.Executable()
+	if err != nil {
+		ui.Warning("Could not update shims: %v", err)
+		ui.Info("Please run manually: dtvem reshim")
+		return
 	}
 
-	return result
+	created, err := shim.NewManagerFromSource(shimSource).ReshimVersion(provider.Name(), version)
+	if err != nil {
+		ui.Warning("Could not update shims: %v", err)
+		ui.Info("Please run manually: dtvem reshim")
+		return
+	}
+	if len(created) > 0 {
+		ui.Success("Created shims: %s", strings.Join(created, ", "))
+	}
 }
 
-// promptReshim prompts the user to run reshim after installing global packages
-func promptReshim() {
+// confirmReshim asks whether to create shims for the new executables
+func confirmReshim(pending []string) bool {
 	fmt.Fprintln(os.Stderr) // Empty line for spacing
-	ui.Info("Global packages were installed/removed")
-	fmt.Fprintf(os.Stderr, "Run 'dtvem reshim' to update shims? [Y/n]: ")
+	ui.Info("New executables: %s", strings.Join(pending, ", "))
+	fmt.Fprintf(os.Stderr, "Create shims for them? [Y/n]: ")
 
 	var response string
 	_, _ = fmt.Scanln(&response)
 	response = strings.ToLower(strings.TrimSpace(response))
 
 	// Default to "yes" if empty response
-	if response == "" || response == constants.ResponseY || response == constants.ResponseYes {
-		// Run reshim
-		if err := runReshim(); err != nil {
-			ui.Error("Failed to run reshim: %v", err)
-			ui.Info("Please run manually: dtvem reshim")
-		} else {
-			ui.Success("Shims updated successfully")
-		}
-	} else {
-		ui.Info("Remember to run 'dtvem reshim' when you want to use the new executables")
-	}
+	return response == "" || response == constants.ResponseY || response == constants.ResponseYes
 }
 
-// runReshim executes the reshim operation
-func runReshim() error {
-	// Find dtvem executable
-	dtvemPath, err := findDtvemExecutable()
-	if err != nil {
-		return err
-	}
-
-	// Run: dtvem reshim
-	cmd := exec.Command(dtvemPath, "reshim")
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	return cmd.Run()
+// isInteractive reports whether the user can answer a prompt: both stdin and
+// stderr (where the prompt is written) must be terminals
+func isInteractive() bool {
+	return ui.IsTerminal(os.Stdin) && ui.IsTerminal(os.Stderr)
 }
 
 // findDtvemExecutable locates the dtvem executable
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
@@ -24,9 +25,11 @@ This command removes a specific version of a runtime from your system.
 The version directory and all its contents will be deleted.
 
 Safety features:
-  - Cannot uninstall the currently active global version
+  - Warns if the version is the global version or is pinned by the local
+    .dtvem/runtimes.json
   - Prompts for confirmation before deletion
-  - Automatically regenerates shims after uninstall
+  - Removes shims that no other installed version provides and updates the
+    shim map
 
 Examples:
   dtvem uninstall python 3.11.0
@@ -58,21 +61,12 @@ Examples:
 			return
 		}
 
-		// Check if this is the currently active global version
-		globalVersion, err := provider.GlobalVersion()
-		if err == nil && globalVersion == version {
-			ui.Error("Cannot uninstall the currently active global version")
-			ui.Info("Current global version: v%s", globalVersion)
-			ui.Info("Set a different global version first: dtvem global %s <version>", runtimeName)
-			return
-		}
-
-		// Check if this is the currently active local version
-		localVersion, err := provider.LocalVersion()
-		if err == nil && localVersion == version {
-			ui.Warning("This is the currently active local version in this directory")
-			ui.Info("Local version file: dtvem.config.json")
-			ui.Info("You may need to update or remove it after uninstalling")
+		// Warn if configuration still selects this version
+		if warnings := pinnedVersionWarnings(provider, version); len(warnings) > 0 {
+			for _, warning := range warnings {
+				ui.Warning("%s", warning)
+			}
+			ui.Info("Commands that use it will fail until you select another version with 'dtvem global' or 'dtvem local'")
 		}
 
 		// Prompt for confirmation (unless --yes flag is provided)
@@ -92,11 +86,13 @@ Examples:
 			}
 		}
 
-		// Remove the version directory
+		shimsBefore, _ := shim.LoadShimMap()
+
+		// Remove the version directory and its shims
 		spinner := ui.NewSpinner(fmt.Sprintf("Removing %s v%s...", provider.DisplayName(), version))
 		spinner.Start()
 
-		if err := os.RemoveAll(versionPath); err != nil {
+		if err := provider.Uninstall(version); err != nil {
 			spinner.Error("Failed to remove version")
 			ui.Error("Error: %v", err)
 			return
@@ -104,27 +100,55 @@ Examples:
 
 		spinner.Success(fmt.Sprintf("%s v%s removed", provider.DisplayName(), version))
 
-		// Regenerate shims
-		shimSpinner := ui.NewSpinner("Regenerating shims...")
-		shimSpinner.Start()
-
-		manager, err := shim.NewManager()
-		if err != nil {
-			shimSpinner.Warning("Could not regenerate shims")
-			ui.Warning("You may need to run 'dtvem reshim' manually")
-		} else {
-			if _, err := manager.Rehash(); err != nil {
-				shimSpinner.Warning("Could not regenerate shims")
-				ui.Warning("You may need to run 'dtvem reshim' manually")
-			} else {
-				shimSpinner.Success("Shims regenerated")
-			}
+		if removed := removedShims(shimsBefore, runtimeName); len(removed) > 0 {
+			ui.Info("Removed shims no other version provides: %s", strings.Join(removed, ", "))
 		}
 
 		ui.Success("Successfully uninstalled %s v%s", provider.DisplayName(), version)
 	},
 }
 
+// pinnedVersionWarnings returns a warning for each configuration that
+// selects version: the global version, and the version pinned for the
+// current directory. Ranges and aliases count when they resolve to it.
+func pinnedVersionWarnings(provider runtime.ShimProvider, version string) []string {
+	var warnings []string
+
+	if global, err := config.GlobalVersion(provider.Name()); err == nil && global != "" && selectsVersion(provider, global, version) {
+		warnings = append(warnings, fmt.Sprintf("%s v%s is the global version (%s)", provider.DisplayName(), version, config.GlobalConfigPath()))
+	}
+
+	if local, err := config.LocalVersionSource(provider.Name()); err == nil && selectsVersion(provider, local.Version, version) {
+		warnings = append(warnings, fmt.Sprintf("%s v%s is pinned by %s", provider.DisplayName(), version, local.Source))
+	}
+
+	return warnings
+}
+
+// selectsVersion reports whether a configured version spec resolves to version
+func selectsVersion(provider runtime.ShimProvider, spec, version string) bool {
+	resolved, err := runtime.ResolveInstalledVersion(provider, spec)
+	return err == nil && resolved == version
+}
+
+// removedShims returns the runtime's shims in the before map that are no
+// longer in the shim map
+func removedShims(before shim.ShimMap, runtimeName string) []string {
+	after, _ := shim.LoadShimMap()
+
+	var removed []string
+	for name, entry := range before {
+		if entry.Runtime != runtimeName {
+			continue
+		}
+		if _, ok := after[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
 func init() {
 	uninstallCmd.Flags().BoolVarP(&uninstallYes, "yes", "y", false, "Skip confirmation prompt")
 	rootCmd.AddCommand(uninstallCmd)
@@ -208,3 +208,44 @@ func TestUninstallCommand_ConfirmationResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestPinnedVersionWarnings(t *testing.T) {
+	t.Setenv("DTVEM_ROOT", t.TempDir())
+	config.ResetPathsCache()
+	t.Cleanup(config.ResetPathsCache)
+
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	projectDir := t.TempDir()
+	if err := os.Chdir(projectDir); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range []string{"18.20.0", "20.11.0"} {
+		if err := os.MkdirAll(config.RuntimeVersionPath("node", v), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	provider := &mockProvider{name: "node", displayName: "Node.js"}
+	if warnings := pinnedVersionWarnings(provider, "18.20.0"); len(warnings) != 0 {
+		t.Errorf("expected no warnings without configuration, got %v", warnings)
+	}
+
+	if err := config.SetGlobalVersion("node", "20.11.0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SetLocalVersion("node", "18"); err != nil {
+		t.Fatal(err)
+	}
+
+	warnings := pinnedVersionWarnings(provider, "18.20.0")
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "pinned by") {
+		t.Errorf("expected a local pin warning for a range, got %v", warnings)
+	}
+
+	warnings = pinnedVersionWarnings(provider, "20.11.0")
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "global version") {
+		t.Errorf("expected a global version warning, got %v", warnings)
+	}
+}
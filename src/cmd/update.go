@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/manifest"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/output"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/tui"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
 	"github.com/spf13/cobra"
@@ -20,7 +21,8 @@ back to the embedded manifests bundled with dtvem.
 
 Example:
   dtvem update           # Update all runtime manifests
-  dtvem update python    # Update only the Python manifest`,
+  dtvem update python    # Update only the Python manifest
+  dtvem update -o json   # Report the refreshed manifests as JSON`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Get list of runtimes to update
 		var runtimes []string
@@ -33,11 +35,20 @@ Example:
 			// Update all available runtimes
 			runtimes, err = manifest.ListAvailableRuntimes()
 			if err != nil {
-				ui.Error("Failed to list runtimes: %v", err)
+				reportError(fmt.Sprintf("Failed to list runtimes: %v", err))
 				return
 			}
 		}
 
+		if output.IsStructured() {
+			result := UpdateOutput{Manifests: make([]ManifestUpdate, 0, len(runtimes))}
+			for _, runtime := range runtimes {
+				result.Manifests = append(result.Manifests, refreshManifest(runtime))
+			}
+			printResult(result)
+			return
+		}
+
 		if len(runtimes) == 0 {
 			ui.Warning("No runtimes found to update")
 			return
@@ -52,19 +63,14 @@ Example:
 
 		hasErrors := false
 		for _, runtime := range runtimes {
-			m, fromRemote, err := manifest.ForceRefreshRuntime(runtime)
-			if err != nil {
-				ui.Error("  %s: %v", runtime, err)
+			update := refreshManifest(runtime)
+			if update.Error != "" {
+				ui.Error("  %s: %s", runtime, update.Error)
 				hasErrors = true
 				continue
 			}
 
-			source := "embedded"
-			if fromRemote {
-				source = "remote"
-			}
-
-			table.AddRow(runtime, fmt.Sprintf("%d versions", len(m.Versions)), source)
+			table.AddRow(runtime, fmt.Sprintf("%d versions", update.Versions), update.Source)
 		}
 
 		fmt.Println(table.Render())
@@ -78,6 +84,35 @@ Example:
 	},
 }
 
+// UpdateOutput is the --output json|yaml result of "dtvem update"
+type UpdateOutput struct {
+	Manifests []ManifestUpdate `json:"manifests" yaml:"manifests"`
+}
+
+// ManifestUpdate is the outcome of refreshing one runtime manifest
+type ManifestUpdate struct {
+	Runtime string `json:"runtime" yaml:"runtime"`
+	// Versions is the number of versions in the refreshed manifest
+	Versions int `json:"versions" yaml:"versions"`
+	// Source is "remote", or "embedded" when the remote was unavailable
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// refreshManifest force-refreshes the manifest for a runtime
+func refreshManifest(runtime string) ManifestUpdate {
+	m, fromRemote, err := manifest.ForceRefreshRuntime(runtime)
+	if err != nil {
+		return ManifestUpdate{Runtime: runtime, Error: err.Error()}
+	}
+
+	source := "embedded"
+	if fromRemote {
+		source = "remote"
+	}
+	return ManifestUpdate{Runtime: runtime, Versions: len(m.Versions), Source: source}
+}
+
 func init() {
 	rootCmd.AddCommand(updateCmd)
 }
@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/tui"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/venv"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/version"
+	"github.com/spf13/cobra"
+)
+
+var (
+	upgradePatch        bool
+	upgradeMinor        bool
+	upgradeMajor        bool
+	upgradeGlobal       bool
+	upgradeLocal        bool
+	upgradeUninstallOld bool
+	upgradeYes          bool
+)
+
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade [runtime]",
+	Short: "Move pinned versions to newer releases",
+	Long: `Upgrade the versions pinned in a runtimes.json file to the newest available
+release, for one runtime or for every runtime in the file.
+
+How far a version may move:
+  --patch   Newest release with the same major.minor (default), e.g. 20.11.0 → 20.11.1
+  --minor   Newest release with the same major, e.g. 20.11.0 → 20.18.0
+  --major   Newest release, e.g. 20.11.0 → 22.12.0
+
+Which file is upgraded:
+  --local   The project's .dtvem/runtimes.json, found by walking up from the
+            current directory
+  --global  The global runtimes.json
+Without either, the project's file is used if there is one, otherwise the
+global file.
+
+Each upgrade installs the new version if needed, reinstalls the old version's
+global packages (npm, pip, gem) into it, and rewrites the pin. Pins that are
+ranges or aliases (e.g. "^20", "lts/iron") already float and are left alone.
+
+With --uninstall-old, superseded versions are uninstalled afterwards, unless
+they are still the global version or pinned for the current directory. Other
+projects that pin an uninstalled version need it reinstalled.
+
+Examples:
+  dtvem upgrade                        # Patch upgrades for the current project
+  dtvem upgrade node --minor           # Newest Node.js with the same major
+  dtvem upgrade --global --major       # Newest releases for the global pins
+  dtvem upgrade --uninstall-old --yes  # Upgrade and clean up without prompting`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		configPath, scope, err := upgradeConfigFile()
+		if err != nil {
+			ui.Error("%v", err)
+			os.Exit(1)
+		}
+
+		pins, err := config.ReadAllRuntimes(configPath)
+		if err != nil && !os.IsNotExist(err) {
+			ui.Error("Failed to read %s: %v", configPath, err)
+			os.Exit(1)
+		}
+
+		var runtimeFilter string
+		if len(args) == 1 {
+			runtimeFilter = strings.ToLower(args[0])
+			if _, ok := pins[runtimeFilter]; !ok {
+				ui.Error("%s is not pinned in %s", runtimeFilter, configPath)
+				os.Exit(1)
+			}
+		}
+		if len(pins) == 0 {
+			ui.Info("No versions are pinned in %s", configPath)
+			return
+		}
+
+		level := upgradeLevel()
+		plans := planUpgrades(pins, runtimeFilter, level)
+		if len(plans) == 0 {
+			ui.Success("Pinned versions in %s are up to date (%s upgrades)", configPath, level)
+			return
+		}
+
+		ui.Info("Upgrading %s versions pinned in %s", scope, configPath)
+		table := tui.NewTable("Runtime", "Pinned", "Upgrade")
+		table.SetTitle(fmt.Sprintf("Available %s Upgrades", strings.ToUpper(string(level[:1]))+string(level[1:])))
+		for _, plan := range plans {
+			table.AddRow(plan.provider.DisplayName(), plan.from, plan.to)
+		}
+		fmt.Println(table.Render())
+
+		if !upgradeYes && !confirmUpgrade(len(plans)) {
+			ui.Info("Upgrade canceled")
+			return
+		}
+
+		failed := 0
+		for _, plan := range plans {
+			fmt.Println()
+			if err := applyUpgrade(plan, configPath); err != nil {
+				ui.Error("Failed to upgrade %s: %v", plan.provider.DisplayName(), err)
+				failed++
+			}
+		}
+
+		fmt.Println()
+		if failed > 0 {
+			ui.Error("%d of %d upgrade(s) failed", failed, len(plans))
+			os.Exit(1)
+		}
+		ui.Success("Upgraded %d runtime(s) in %s", len(plans), configPath)
+	},
+}
+
+// upgradePlan is a pinned version and the version it is upgraded to
+type upgradePlan struct {
+	provider runtime.Provider
+	from     string
+	to       string
+}
+
+// upgradeLevel returns the level selected with --patch, --minor or --major
+func upgradeLevel() version.UpgradeLevel {
+	switch {
+	case upgradeMajor:
+		return version.UpgradeMajor
+	case upgradeMinor:
+		return version.UpgradeMinor
+	default:
+		return version.UpgradePatch
+	}
+}
+
+// upgradeConfigFile returns the runtimes.json file to upgrade and its scope
+// ("local" or "global")
+func upgradeConfigFile() (string, string, error) {
+	if upgradeGlobal {
+		return config.GlobalConfigPath(), "global", nil
+	}
+
+	localPath, err := config.FindLocalRuntimesFile()
+	if err == nil {
+		return localPath, "local", nil
+	}
+	if upgradeLocal {
+		return "", "", fmt.Errorf("no %s found in this directory or its parents", filepath.Join(config.LocalConfigDirName, config.RuntimesFileName))
+	}
+	return config.GlobalConfigPath(), "global", nil
+}
+
+// planUpgrades finds the newest available version within level for each
+// exact pin, optionally for a single runtime. Plans are sorted by runtime.
+func planUpgrades(pins config.RuntimesConfig, runtimeFilter string, level version.UpgradeLevel) []upgradePlan {
+	names := make([]string, 0, len(pins))
+	for name := range pins {
+		if runtimeFilter == "" || name == runtimeFilter {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var plans []upgradePlan
+	for _, name := range names {
+		pinned := pins[name]
+		provider, err := runtime.Get(name)
+		if err != nil {
+			ui.Warning("Unknown runtime '%s', skipping", name)
+			continue
+		}
+
+		if runtime.IsVersionSpec(pinned) {
+			ui.Info("%s is pinned to %q, which already picks up new releases; skipping", provider.DisplayName(), pinned)
+			continue
+		}
+
+		available, err := provider.ListAvailable()
+		if err != nil {
+			ui.Warning("Failed to fetch available %s versions, skipping: %v", provider.DisplayName(), err)
+			continue
+		}
+		versions := make([]string, len(available))
+		for i, av := range available {
+			versions[i] = av.Version.Raw
+		}
+
+		target, ok := version.Upgrade(pinned, level, versions)
+		if !ok {
+			ui.Debug("%s %s is the newest %s release", provider.DisplayName(), pinned, level)
+			continue
+		}
+		plans = append(plans, upgradePlan{
+			provider: provider,
+			from:     strings.TrimPrefix(pinned, "v"),
+			to:       target,
+		})
+	}
+	return plans
+}
+
+// confirmUpgrade asks whether to apply the listed upgrades
+func confirmUpgrade(count int) bool {
+	fmt.Printf("\nUpgrade %d runtime(s)? [y/N]: ", count)
+
+	var response string
+	_, _ = fmt.Scanln(&response)
+	response = strings.ToLower(strings.TrimSpace(response))
+	return response == constants.ResponseY || response == constants.ResponseYes
+}
+
+// applyUpgrade installs the new version, carries the global packages over,
+// rewrites the pin and, with --uninstall-old, removes the old version
+func applyUpgrade(plan upgradePlan, configPath string) error {
+	provider := plan.provider
+	ui.Header("Upgrading %s %s → %s", provider.DisplayName(), plan.from, plan.to)
+
+	if config.IsVersionInstalled(provider.Name(), plan.to) {
+		ui.Info("%s %s is already installed", provider.DisplayName(), plan.to)
+	} else if err := provider.Install(plan.to); err != nil {
+		return err
+	}
+
+	migrateGlobalPackages(provider, plan.from, plan.to)
+
+	if err := config.SetRuntimesFileVersion(configPath, provider.Name(), plan.to); err != nil {
+		return fmt.Errorf("failed to update %s: %w", configPath, err)
+	}
+	ui.Success("Pinned %s %s", provider.DisplayName(), plan.to)
+	if provider.Name() == venv.Runtime {
+		warnStaleVenv()
+	}
+
+	if upgradeUninstallOld {
+		uninstallSuperseded(provider, plan.from)
+	}
+	return nil
+}
+
+// migrateGlobalPackages reinstalls the global packages of an installed
+// version into another. Failures are reported but don't fail the upgrade.
+func migrateGlobalPackages(provider runtime.Provider, from, to string) {
+	if !config.IsVersionInstalled(provider.Name(), from) {
+		ui.Debug("%s %s is not installed; no global packages to migrate", provider.DisplayName(), from)
+		return
+	}
+
+	packages, err := provider.GlobalPackages(config.RuntimeVersionPath(provider.Name(), from))
+	if err != nil {
+		ui.Warning("Could not detect global packages of %s %s: %v", provider.DisplayName(), from, err)
+		return
+	}
+	if len(packages) == 0 {
+		return
+	}
+
+	ui.Progress("Reinstalling %d global package(s): %s", len(packages), strings.Join(packages, ", "))
+	if err := provider.InstallGlobalPackages(to, packages); err != nil {
+		ui.Warning("Failed to reinstall some packages: %v", err)
+		if cmd := provider.ManualPackageInstallCommand(packages); cmd != "" {
+			ui.Info("You can manually reinstall with:")
+			ui.Info("  %s", cmd)
+		}
+		return
+	}
+	ui.Success("Reinstalled %d global package(s)", len(packages))
+}
+
+// uninstallSuperseded removes a version replaced by an upgrade, unless
+// configuration still selects it
+func uninstallSuperseded(provider runtime.Provider, old string) {
+	if !config.IsVersionInstalled(provider.Name(), old) {
+		return
+	}
+
+	if warnings := pinnedVersionWarnings(provider, old); len(warnings) > 0 {
+		ui.Info("Keeping %s v%s, which is still in use:", provider.DisplayName(), old)
+		for _, warning := range warnings {
+			ui.Info("  %s", warning)
+		}
+		return
+	}
+
+	if err := provider.Uninstall(old); err != nil {
+		ui.Warning("Failed to uninstall %s %s: %v", provider.DisplayName(), old, err)
+		return
+	}
+	ui.Success("Uninstalled %s %s", provider.DisplayName(), old)
+}
+
+func init() {
+	upgradeCmd.Flags().BoolVar(&upgradePatch, "patch", false, "Upgrade to the newest release with the same major.minor (default)")
+	upgradeCmd.Flags().BoolVar(&upgradeMinor, "minor", false, "Upgrade to the newest release with the same major")
+	upgradeCmd.Flags().BoolVar(&upgradeMajor, "major", false, "Upgrade to the newest release")
+	upgradeCmd.Flags().BoolVar(&upgradeGlobal, "global", false, "Upgrade the global runtimes.json")
+	upgradeCmd.Flags().BoolVar(&upgradeLocal, "local", false, "Upgrade the project's .dtvem/runtimes.json")
+	upgradeCmd.Flags().BoolVar(&upgradeUninstallOld, "uninstall-old", false, "Uninstall superseded versions that are no longer pinned")
+	upgradeCmd.Flags().BoolVarP(&upgradeYes, "yes", "y", false, "Skip confirmation prompt")
+	upgradeCmd.MarkFlagsMutuallyExclusive("patch", "minor", "major")
+	upgradeCmd.MarkFlagsMutuallyExclusive("global", "local")
+	rootCmd.AddCommand(upgradeCmd)
+}
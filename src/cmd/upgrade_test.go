@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/version"
+)
+
+func TestPlanUpgrades(t *testing.T) {
+	provider := &mockProvider{
+		name:        "upgrade-test",
+		displayName: "Upgrade Test",
+		availableVersions: []runtime.AvailableVersion{
+			{Version: runtime.NewVersion("20.11.0")},
+			{Version: runtime.NewVersion("20.11.1")},
+			{Version: runtime.NewVersion("20.18.0")},
+			{Version: runtime.NewVersion("22.12.0")},
+		},
+	}
+	if err := runtime.Register(provider); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = runtime.Unregister(provider.Name()) })
+
+	pins := config.RuntimesConfig{
+		"upgrade-test": "20.11.0",
+		"unknown":      "1.0.0",
+	}
+
+	tests := []struct {
+		level    version.UpgradeLevel
+		expected string
+	}{
+		{version.UpgradePatch, "20.11.1"},
+		{version.UpgradeMinor, "20.18.0"},
+		{version.UpgradeMajor, "22.12.0"},
+	}
+	for _, tt := range tests {
+		plans := planUpgrades(pins, "", tt.level)
+		if len(plans) != 1 || plans[0].from != "20.11.0" || plans[0].to != tt.expected {
+			t.Errorf("planUpgrades(%s) = %+v, want 20.11.0 -> %s", tt.level, plans, tt.expected)
+		}
+	}
+
+	// Ranges already float and are left alone
+	if plans := planUpgrades(config.RuntimesConfig{"upgrade-test": "^20"}, "", version.UpgradeMajor); len(plans) != 0 {
+		t.Errorf("planUpgrades for a range = %+v, want no plans", plans)
+	}
+
+	// Nothing newer within the level
+	if plans := planUpgrades(config.RuntimesConfig{"upgrade-test": "22.12.0"}, "upgrade-test", version.UpgradeMajor); len(plans) != 0 {
+		t.Errorf("planUpgrades for the newest version = %+v, want no plans", plans)
+	}
+}
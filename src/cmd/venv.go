@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/venv"
+	"github.com/spf13/cobra"
+)
+
+var (
+	venvPath         string
+	venvRequirements []string
+)
+
+var venvCmd = &cobra.Command{
+	Use:   "venv",
+	Short: "Manage the project's Python virtual environment",
+	Long: `Manage a Python virtual environment tied to the project's pinned Python version.
+
+The venv is declared in .dtvem/venv.json, next to the project's runtimes.json,
+and built from the Python version the project resolves to. Inside the project,
+the python, pip and other Python shims run the venv's executables.
+
+When the pinned Python version changes, the venv is flagged for a rebuild: the
+shims warn and fall back to the pinned Python until 'dtvem venv sync' rebuilds
+it. 'dtvem doctor' reports it as well.
+
+Examples:
+  dtvem venv create                               # Create .venv from the pinned Python
+  dtvem venv create --requirements requirements.txt
+  dtvem venv sync                                 # Rebuild if needed, reinstall requirements
+  dtvem venv remove                               # Delete the venv and its declaration`,
+}
+
+var venvCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Declare and create the project venv",
+	Long: `Declare a venv for the project in .dtvem/venv.json, if it doesn't have one,
+and create it from the project's Python version. Declared requirements files
+are installed into it with pip.
+
+The project is the nearest directory with a .dtvem directory, or the current
+directory. --path and --requirements update an existing declaration.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cwd, err := os.Getwd()
+		if err != nil {
+			ui.Error("%v", err)
+			os.Exit(1)
+		}
+
+		project, err := venv.Find(cwd)
+		switch {
+		case errors.Is(err, venv.ErrNotDeclared):
+			project, err = venv.Declare(venv.ProjectRoot(cwd), venv.Config{Path: venvPath, Requirements: venvRequirements})
+			if err != nil {
+				ui.Error("Failed to declare the project venv: %v", err)
+				os.Exit(1)
+			}
+			ui.Success("Declared project venv in %s", project.ConfigPath)
+		case err != nil:
+			ui.Error("%v", err)
+			os.Exit(1)
+		case cmd.Flags().Changed("path") || cmd.Flags().Changed("requirements"):
+			cfg := project.Config
+			if cmd.Flags().Changed("path") {
+				cfg.Path = venvPath
+			}
+			if cmd.Flags().Changed("requirements") {
+				cfg.Requirements = venvRequirements
+			}
+			if project, err = venv.Declare(project.Root, cfg); err != nil {
+				ui.Error("Failed to update the project venv declaration: %v", err)
+				os.Exit(1)
+			}
+			ui.Success("Updated %s", project.ConfigPath)
+		}
+
+		version, pythonPath := resolveVenvPython()
+		status := project.Check(version)
+		if status.State != venv.Missing {
+			ui.Info("Project venv already exists at %s", project.Dir())
+			if status.State == venv.Stale {
+				ui.Warning("It needs a rebuild: %s", status.Reason)
+			}
+			ui.Info("Run 'dtvem venv sync' to rebuild it or reinstall its requirements")
+			return
+		}
+
+		buildVenv(project, version, pythonPath)
+	},
+}
+
+var venvSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Rebuild the project venv if needed and reinstall its requirements",
+	Long: `Bring the project venv in line with its declaration.
+
+A venv that is missing, or was built with another Python version than the
+project now uses, is rebuilt from scratch. Otherwise the declared
+requirements files are installed into it again.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		project := declaredVenv()
+		version, pythonPath := resolveVenvPython()
+
+		status := project.Check(version)
+		switch status.State {
+		case venv.Missing:
+			buildVenv(project, version, pythonPath)
+		case venv.Stale:
+			ui.Warning("Rebuilding project venv: %s", status.Reason)
+			buildVenv(project, version, pythonPath)
+		default:
+			if len(project.Config.Requirements) > 0 {
+				ui.Progress("Installing requirements into %s...", project.Dir())
+				if err := project.InstallRequirements(os.Stdout); err != nil {
+					ui.Error("%v", err)
+					os.Exit(1)
+				}
+			}
+			ui.Success("Project venv is up to date (Python %s)", version)
+		}
+	},
+}
+
+var venvRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Delete the project venv and its declaration",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		project := declaredVenv()
+
+		if err := project.Remove(); err != nil {
+			ui.Error("Failed to remove the project venv: %v", err)
+			os.Exit(1)
+		}
+		if err := project.Undeclare(); err != nil {
+			ui.Error("Failed to remove %s: %v", project.ConfigPath, err)
+			os.Exit(1)
+		}
+		ui.Success("Removed project venv %s", project.Dir())
+	},
+}
+
+// declaredVenv returns the project venv declared for the current
+// directory, exiting if there is none
+func declaredVenv() *venv.Project {
+	cwd, err := os.Getwd()
+	if err != nil {
+		ui.Error("%v", err)
+		os.Exit(1)
+	}
+	project, err := venv.Find(cwd)
+	if err != nil {
+		if errors.Is(err, venv.ErrNotDeclared) {
+			ui.Error("This project does not declare a venv")
+			ui.Info("Run 'dtvem venv create' to create one")
+		} else {
+			ui.Error("%v", err)
+		}
+		os.Exit(1)
+	}
+	return project
+}
+
+// resolveVenvPython returns the installed Python version the project uses
+// and its interpreter, exiting if there is none
+func resolveVenvPython() (string, string) {
+	version, pythonPath, err := venv.ResolvePython()
+	if err != nil {
+		ui.Error("Cannot build the project venv: %v", err)
+		ui.Info("Pin an installed version with 'dtvem local %s <version>'", venv.Runtime)
+		os.Exit(1)
+	}
+	return version, pythonPath
+}
+
+// buildVenv (re)creates the project venv, exiting on failure
+func buildVenv(project *venv.Project, version, pythonPath string) {
+	ui.Progress("Creating %s with Python %s...", project.Dir(), version)
+	if err := project.Build(pythonPath, os.Stdout); err != nil {
+		ui.Error("%v", err)
+		os.Exit(1)
+	}
+	ui.Success("Created project venv %s (Python %s)", project.Dir(), version)
+}
+
+// warnStaleVenv flags the current project's venv for a rebuild once a new
+// Python version is set, if the venv no longer matches it
+func warnStaleVenv() {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+	project, err := venv.Find(cwd)
+	if err != nil {
+		return
+	}
+	version, _, err := venv.ResolvePython()
+	if err != nil {
+		return
+	}
+	if status := project.Check(version); status.State == venv.Stale {
+		ui.Warning("The project venv needs a rebuild: %s", status.Reason)
+		ui.Info("Run 'dtvem venv sync' to rebuild it")
+	}
+}
+
+func init() {
+	venvCreateCmd.Flags().StringVar(&venvPath, "path", venv.DefaultPath, "Venv directory, relative to the project")
+	venvCreateCmd.Flags().StringSliceVar(&venvRequirements, "requirements", nil, "Requirements files to install into the venv (repeatable)")
+
+	venvCmd.AddCommand(venvCreateCmd)
+	venvCmd.AddCommand(venvSyncCmd)
+	venvCmd.AddCommand(venvRemoveCmd)
+	rootCmd.AddCommand(venvCmd)
+}
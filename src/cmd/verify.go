@@ -0,0 +1,355 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/cache"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/output"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// Smoke test outcomes reported by dtvem verify
+const (
+	smokeTestPassed  = "passed"
+	smokeTestFailed  = "failed"
+	smokeTestSkipped = "skipped"
+)
+
+// verifyMaxListedFiles caps the missing and modified files printed per
+// version in text mode; --output json lists them all
+const verifyMaxListedFiles = 10
+
+var verifyRepair bool
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify [runtime] [version]",
+	Short: "Check that installed versions are intact",
+	Long: `Check installed runtime versions for missing or changed files and make sure
+they still run.
+
+Every install records a manifest of its files (paths, sizes and SHA256
+checksums). verify compares each version against it, then runs a quick smoke
+test: node --version, python -c "import ssl", ruby -e "require 'openssl'".
+Files added after install are not reported, and directories that package
+managers legitimately change (npm's node_modules, pip's site-packages,
+RubyGems' gems) are skipped. Versions installed before manifests were
+recorded get the smoke test only.
+
+With --repair, versions that fail are reinstalled. The archive is taken from
+the download cache when it is there (see 'dtvem cache list'), so repairs work
+offline. Global packages are reinstalled into the new install, and the old
+install is put back if the reinstall fails.
+
+verify exits non-zero if any version is still broken.
+
+Examples:
+  dtvem verify                   # Verify every installed version
+  dtvem verify node              # Verify all installed Node.js versions
+  dtvem verify python 3.12.1     # Verify one version
+  dtvem verify node 22 --repair  # Verify and repair the installed 22.x`,
+	Args: cobra.MaximumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		targets, err := verifyTargets(args)
+		if err != nil {
+			reportError(err.Error(), "Run 'dtvem list' to see installed versions")
+			os.Exit(1)
+		}
+		if len(targets) == 0 {
+			if output.IsStructured() {
+				printResult(VerifyOutput{Versions: []VerifiedVersion{}})
+				return
+			}
+			ui.Info("No versions installed")
+			return
+		}
+
+		result := VerifyOutput{Versions: make([]VerifiedVersion, 0, len(targets))}
+		for _, target := range targets {
+			verified := verifyVersion(target.provider, target.version)
+			if !output.IsStructured() {
+				renderVerifiedVersion(target.provider, verified)
+			}
+			if !verified.OK && verifyRepair {
+				verified = repairVersion(target.provider, target.version, verified)
+				if verified.Repaired && !output.IsStructured() {
+					renderVerifiedVersion(target.provider, verified)
+				}
+			}
+			result.Versions = append(result.Versions, verified)
+		}
+
+		if output.IsStructured() {
+			printResult(result)
+		} else {
+			summarizeVerify(result)
+		}
+
+		if result.broken() > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// VerifyOutput is the --output json|yaml result of "dtvem verify"
+type VerifyOutput struct {
+	Versions []VerifiedVersion `json:"versions" yaml:"versions"`
+}
+
+// broken returns the number of versions that failed verification
+func (o VerifyOutput) broken() int {
+	count := 0
+	for _, v := range o.Versions {
+		if !v.OK {
+			count++
+		}
+	}
+	return count
+}
+
+// VerifiedVersion is the verification result of one installed version
+type VerifiedVersion struct {
+	Runtime string `json:"runtime" yaml:"runtime"`
+	Version string `json:"version" yaml:"version"`
+	OK      bool   `json:"ok" yaml:"ok"`
+	// Manifest is false for versions installed before dtvem recorded file
+	// manifests; only the smoke test ran for them
+	Manifest     bool     `json:"manifest" yaml:"manifest"`
+	FilesChecked int      `json:"filesChecked" yaml:"filesChecked"`
+	Missing      []string `json:"missing,omitempty" yaml:"missing,omitempty"`
+	Modified     []string `json:"modified,omitempty" yaml:"modified,omitempty"`
+	// SmokeTest is passed, failed, or skipped for runtimes without one
+	SmokeTest      string `json:"smokeTest" yaml:"smokeTest"`
+	SmokeTestError string `json:"smokeTestError,omitempty" yaml:"smokeTestError,omitempty"`
+	// Error is set when the file manifest could not be read
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+	// Repaired is true when the version was reinstalled with --repair; the
+	// rest of the result describes the reinstalled version
+	Repaired    bool   `json:"repaired,omitempty" yaml:"repaired,omitempty"`
+	RepairError string `json:"repairError,omitempty" yaml:"repairError,omitempty"`
+}
+
+// verifyTarget is an installed version selected for verification
+type verifyTarget struct {
+	provider runtime.Provider
+	version  string
+}
+
+// verifyTargets returns the installed versions selected by the arguments:
+// every version of every runtime, every version of one runtime, or one
+// version. The version may be a range or alias of an installed version.
+func verifyTargets(args []string) ([]verifyTarget, error) {
+	var providers []runtime.Provider
+	if len(args) == 0 {
+		providers = runtime.GetAll()
+		sort.Slice(providers, func(i, j int) bool { return providers[i].Name() < providers[j].Name() })
+	} else {
+		provider, err := runtime.Get(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("%v (available runtimes: %v)", err, runtime.List())
+		}
+		providers = []runtime.Provider{provider}
+	}
+
+	if len(args) == 2 {
+		provider := providers[0]
+		version, err := runtime.ResolveInstalledVersion(provider, args[1])
+		if err != nil || !config.IsVersionInstalled(provider.Name(), version) {
+			return nil, fmt.Errorf("%s %s is not installed", provider.DisplayName(), args[1])
+		}
+		return []verifyTarget{{provider: provider, version: version}}, nil
+	}
+
+	var targets []verifyTarget
+	for _, provider := range providers {
+		for _, version := range config.InstalledVersions(provider.Name()) {
+			targets = append(targets, verifyTarget{provider: provider, version: version})
+		}
+	}
+	return targets, nil
+}
+
+// verifyVersion checks an installed version against its file manifest and
+// runs its smoke test
+func verifyVersion(provider runtime.Provider, version string) VerifiedVersion {
+	result := VerifiedVersion{
+		Runtime:   provider.Name(),
+		Version:   version,
+		Manifest:  true,
+		SmokeTest: smokeTestSkipped,
+	}
+
+	verifier, hasVerifier := provider.(runtime.Verifier)
+	var mutablePaths []string
+	if hasVerifier {
+		mutablePaths = verifier.MutablePaths()
+	}
+
+	check, err := runtime.CheckFiles(provider.Name(), version, mutablePaths)
+	switch {
+	case errors.Is(err, runtime.ErrNoFileManifest):
+		result.Manifest = false
+	case err != nil:
+		result.Error = err.Error()
+	default:
+		result.FilesChecked = check.Checked
+		for _, problem := range check.Problems {
+			if problem.Kind == runtime.FileMissing {
+				result.Missing = append(result.Missing, problem.Path)
+			} else {
+				result.Modified = append(result.Modified, problem.Path)
+			}
+		}
+	}
+
+	if hasVerifier {
+		if err := runtime.SmokeTest(provider, version, verifier.SmokeTestArgs()); err != nil {
+			result.SmokeTest = smokeTestFailed
+			result.SmokeTestError = err.Error()
+		} else {
+			result.SmokeTest = smokeTestPassed
+		}
+	}
+
+	result.OK = result.Error == "" && len(result.Missing) == 0 && len(result.Modified) == 0 &&
+		result.SmokeTest != smokeTestFailed
+	return result
+}
+
+// repairVersion reinstalls a version that failed verification, carrying its
+// global packages over, and verifies the new install. On failure the
+// original result is returned with the repair error.
+func repairVersion(provider runtime.Provider, version string, failed VerifiedVersion) VerifiedVersion {
+	ui.Info("Repairing %s v%s...", provider.DisplayName(), version)
+
+	if entry, ok := cachedArchive(provider.Name(), version); ok {
+		ui.Info("Reinstalling from cached archive %s", entry.FileName)
+	} else if config.IsOffline() {
+		failed.RepairError = fmt.Sprintf("%s %s is not in the download cache", provider.DisplayName(), version)
+		ui.Error("Repair failed: %s", failed.RepairError)
+		return failed
+	} else {
+		ui.Info("No cached archive for %s v%s; it will be downloaded", provider.DisplayName(), version)
+	}
+
+	packages, err := provider.GlobalPackages(config.RuntimeVersionPath(provider.Name(), version))
+	if err != nil {
+		ui.Warning("Could not list global packages of %s v%s; they will not be reinstalled: %v", provider.DisplayName(), version, err)
+		packages = nil
+	}
+
+	if err := runtime.Reinstall(provider, version); err != nil {
+		failed.RepairError = err.Error()
+		ui.Error("Repair failed: %v", err)
+		return failed
+	}
+
+	if len(packages) > 0 {
+		ui.Info("Reinstalling %d global package(s)...", len(packages))
+		if err := provider.InstallGlobalPackages(version, packages); err != nil {
+			ui.Warning("Failed to reinstall global packages: %v", err)
+			ui.Info("Install them manually with: %s", provider.ManualPackageInstallCommand(packages))
+		}
+	}
+
+	result := verifyVersion(provider, version)
+	result.Repaired = true
+	return result
+}
+
+// cachedArchive returns the cached archive of a runtime version, if any
+func cachedArchive(runtimeName, version string) (cache.Entry, bool) {
+	entries, err := cache.List()
+	if err != nil {
+		return cache.Entry{}, false
+	}
+	for _, entry := range entries {
+		if entry.Runtime == runtimeName && entry.Version == version {
+			return entry, true
+		}
+	}
+	return cache.Entry{}, false
+}
+
+// renderVerifiedVersion prints the verification result of one version
+func renderVerifiedVersion(provider runtime.Provider, v VerifiedVersion) {
+	name := fmt.Sprintf("%s v%s", provider.DisplayName(), v.Version)
+	if v.Repaired {
+		name += " (repaired)"
+	}
+
+	var parts []string
+	switch {
+	case v.Error != "":
+		parts = append(parts, "file manifest unreadable")
+	case !v.Manifest:
+		parts = append(parts, "no file manifest")
+	case len(v.Missing) == 0 && len(v.Modified) == 0:
+		parts = append(parts, fmt.Sprintf("%d files intact", v.FilesChecked))
+	default:
+		parts = append(parts, fmt.Sprintf("%d missing, %d modified of %d files", len(v.Missing), len(v.Modified), v.FilesChecked))
+	}
+	if v.SmokeTest != smokeTestSkipped {
+		parts = append(parts, "smoke test "+v.SmokeTest)
+	}
+	summary := fmt.Sprintf("%s: %s", name, strings.Join(parts, ", "))
+
+	if !v.OK {
+		ui.Error("%s", summary)
+	} else if !v.Manifest {
+		ui.Warning("%s", summary)
+	} else {
+		ui.Success("%s", summary)
+	}
+
+	if v.Error != "" {
+		ui.Info("  %s", v.Error)
+	}
+	listVerifyFiles("missing", v.Missing)
+	listVerifyFiles("modified", v.Modified)
+	if v.SmokeTestError != "" {
+		for _, line := range strings.Split(v.SmokeTestError, "\n") {
+			ui.Info("  %s", line)
+		}
+	}
+	if !v.Manifest && v.OK {
+		ui.Info("  Installed before dtvem recorded file manifests; only the smoke test ran")
+	}
+}
+
+// listVerifyFiles prints up to verifyMaxListedFiles paths
+func listVerifyFiles(kind string, paths []string) {
+	for i, p := range paths {
+		if i == verifyMaxListedFiles {
+			ui.Info("  ... and %d more %s", len(paths)-i, kind)
+			return
+		}
+		ui.Info("  %s: %s", kind, p)
+	}
+}
+
+// summarizeVerify prints the closing line of a text-mode verify
+func summarizeVerify(result VerifyOutput) {
+	fmt.Println()
+	broken := result.broken()
+	if broken == 0 {
+		ui.Success("All %d version(s) verified", len(result.Versions))
+		return
+	}
+
+	ui.Error("%d of %d version(s) failed verification", broken, len(result.Versions))
+	if !verifyRepair {
+		ui.Info("Run 'dtvem verify --repair' to reinstall them")
+	}
+}
+
+func init() {
+	verifyCmd.Flags().BoolVar(&verifyRepair, "repair", false, "Reinstall versions that fail verification")
+	rootCmd.AddCommand(verifyCmd)
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
+)
+
+func TestVerifyVersion(t *testing.T) {
+	t.Setenv("DTVEM_ROOT", t.TempDir())
+	config.ResetPathsCache()
+	t.Cleanup(config.ResetPathsCache)
+
+	installPath := config.RuntimeVersionPath("node", "22.0.0")
+	for _, name := range []string{"node", "npm"} {
+		if err := os.MkdirAll(filepath.Join(installPath, "bin"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(installPath, "bin", name), []byte(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	provider := &mockProvider{name: "node", displayName: "Node.js"}
+
+	// Installed before manifests were recorded
+	result := verifyVersion(provider, "22.0.0")
+	if !result.OK || result.Manifest || result.SmokeTest != smokeTestSkipped {
+		t.Errorf("verifyVersion without a manifest = %+v, want OK with no manifest and no smoke test", result)
+	}
+
+	if err := runtime.WriteFileManifest("node", "22.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	if result := verifyVersion(provider, "22.0.0"); !result.OK || result.FilesChecked != 2 {
+		t.Errorf("verifyVersion on an intact install = %+v, want OK with 2 files checked", result)
+	}
+
+	if err := os.Remove(filepath.Join(installPath, "bin", "npm")); err != nil {
+		t.Fatal(err)
+	}
+	result = verifyVersion(provider, "22.0.0")
+	if result.OK || len(result.Missing) != 1 || result.Missing[0] != "bin/npm" {
+		t.Errorf("verifyVersion with a missing file = %+v, want bin/npm missing", result)
+	}
+}
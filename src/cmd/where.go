@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/output"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/tui"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
@@ -21,7 +22,8 @@ If no version is specified, shows the location of the currently active version.
 Examples:
   dtvem where python 3.11.0
   dtvem where node 18.16.0
-  dtvem where python          # Shows current version location`,
+  dtvem where python          # Shows current version location
+  dtvem where node -o json    # Print the location as JSON`,
 	Args: cobra.RangeArgs(1, 2),
 	Run: func(cmd *cobra.Command, args []string) {
 		runtimeName := args[0]
@@ -29,8 +31,7 @@ Examples:
 		// Get the provider
 		provider, err := runtime.Get(runtimeName)
 		if err != nil {
-			ui.Error("Unknown runtime: %s", runtimeName)
-			ui.Info("Available runtimes: %v", runtime.List())
+			reportError(fmt.Sprintf("Unknown runtime: %s", runtimeName), fmt.Sprintf("Available runtimes: %v", runtime.List()))
 			return
 		}
 
@@ -40,13 +41,15 @@ Examples:
 		if len(args) == 1 {
 			version, err = provider.CurrentVersion()
 			if err != nil {
-				ui.Error("No version configured for %s", runtimeName)
-				ui.Info("Set a version with: dtvem global %s <version>", runtimeName)
-				ui.Info("Or specify a version: dtvem where %s <version>", runtimeName)
+				reportError(fmt.Sprintf("No version configured for %s", runtimeName),
+					fmt.Sprintf("Set a version with: dtvem global %s <version>", runtimeName),
+					fmt.Sprintf("Or specify a version: dtvem where %s <version>", runtimeName))
 				return
 			}
-			ui.Info("Using current version: %s", ui.HighlightVersion(version))
-			fmt.Println()
+			if !output.IsStructured() {
+				ui.Info("Using current version: %s", ui.HighlightVersion(version))
+				fmt.Println()
+			}
 		} else {
 			version = args[1]
 			// Strip 'v' prefix if present
@@ -58,12 +61,12 @@ Examples:
 		// Check if version is installed
 		installed, err := provider.IsInstalled(version)
 		if err != nil {
-			ui.Error("Failed to check if version is installed: %v", err)
+			reportError(fmt.Sprintf("Failed to check if version is installed: %v", err))
 			return
 		}
 		if !installed {
-			ui.Error("Version %s is not installed", version)
-			ui.Info("Install it with: dtvem install %s %s", runtimeName, version)
+			reportError(fmt.Sprintf("Version %s is not installed", version),
+				fmt.Sprintf("Install it with: dtvem install %s %s", runtimeName, version))
 			return
 		}
 
@@ -72,8 +75,13 @@ Examples:
 
 		// Verify the path exists
 		if _, err := os.Stat(installPath); os.IsNotExist(err) {
-			ui.Error("Installation directory not found: %s", installPath)
-			ui.Warning("Version may be corrupted or partially installed")
+			reportError(fmt.Sprintf("Installation directory not found: %s", installPath),
+				"Version may be corrupted or partially installed")
+			return
+		}
+
+		if output.IsStructured() {
+			printResult(WhereOutput{Runtime: runtimeName, Version: version, Path: installPath})
 			return
 		}
 
@@ -83,6 +91,13 @@ Examples:
 	},
 }
 
+// WhereOutput is the --output json|yaml result of "dtvem where"
+type WhereOutput struct {
+	Runtime string `json:"runtime" yaml:"runtime"`
+	Version string `json:"version" yaml:"version"`
+	Path    string `json:"path" yaml:"path"`
+}
+
 func init() {
 	rootCmd.AddCommand(whereCmd)
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/output"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/shim"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
@@ -28,7 +29,8 @@ This command shows:
 Examples:
   dtvem which python
   dtvem which node
-  dtvem which npm`,
+  dtvem which npm
+  dtvem which npm -o json`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		commandName := args[0]
@@ -36,15 +38,14 @@ Examples:
 		// Find which runtime this command belongs to
 		runtimeName := mapCommandToRuntime(commandName)
 		if runtimeName == "" {
-			ui.Error("Unknown command: %s", commandName)
-			ui.Info("This command is not managed by dtvem")
+			reportError(fmt.Sprintf("Unknown command: %s", commandName), "This command is not managed by dtvem")
 			return
 		}
 
 		// Get the provider for this runtime
 		provider, err := runtime.Get(runtimeName)
 		if err != nil {
-			ui.Error("Runtime provider not found: %s", runtimeName)
+			reportError(fmt.Sprintf("Runtime provider not found: %s", runtimeName))
 			return
 		}
 
@@ -58,16 +59,15 @@ Examples:
 
 		// Check if shim exists
 		if _, err := os.Stat(shimPath); os.IsNotExist(err) {
-			ui.Error("Shim not found: %s", commandName)
-			ui.Info("Run 'dtvem reshim' to regenerate shims")
+			reportError(fmt.Sprintf("Shim not found: %s", commandName), "Run 'dtvem reshim' to regenerate shims")
 			return
 		}
 
 		// Get the current version
 		version, err := provider.CurrentVersion()
 		if err != nil {
-			ui.Error("No version configured for %s", runtimeName)
-			ui.Info("Set a version with: dtvem global %s <version>", runtimeName)
+			reportError(fmt.Sprintf("No version configured for %s", runtimeName),
+				fmt.Sprintf("Set a version with: dtvem global %s <version>", runtimeName))
 			return
 		}
 
@@ -88,7 +88,7 @@ Examples:
 		// Get the base executable path
 		baseExecPath, err := provider.ExecutablePath(version)
 		if err != nil {
-			ui.Error("Failed to get executable path: %v", err)
+			reportError(fmt.Sprintf("Failed to get executable path: %v", err))
 			return
 		}
 
@@ -99,14 +99,25 @@ Examples:
 		if commandName != runtimeName {
 			resolved, err := shim.FindSecondaryExecutable(baseExecPath, commandName)
 			if err != nil {
-				ui.Error("'%s' is not available in %s %s", commandName, provider.DisplayName(), version)
-				ui.Info("This shim exists because another installed %s version provides it.", provider.DisplayName())
-				ui.Info("Install '%s' for the active version, or switch to a version that has it.", commandName)
+				reportError(fmt.Sprintf("'%s' is not available in %s %s", commandName, provider.DisplayName(), version),
+					fmt.Sprintf("This shim exists because another installed %s version provides it.", provider.DisplayName()),
+					fmt.Sprintf("Install '%s' for the active version, or switch to a version that has it.", commandName))
 				return
 			}
 			execPath = resolved
 		}
 
+		if output.IsStructured() {
+			printResult(WhichOutput{
+				Command:    commandName,
+				Shim:       shimPath,
+				Executable: execPath,
+				Runtime:    runtimeName,
+				Version:    version,
+			})
+			return
+		}
+
 		// Display the information
 		ui.Header("Command: %s", ui.Highlight(commandName))
 		fmt.Println()
@@ -147,23 +158,32 @@ func versionInList(version string, providingVersions []string) bool {
 	return false
 }
 
-// reportNotAvailableInVersion prints the user-facing "not available in this
+// reportNotAvailableInVersion reports the user-facing "not available in this
 // runtime version" error for `dtvem which`, including the list of versions
 // that DO provide the executable so the user can switch to one.
 func reportNotAvailableInVersion(commandName, runtimeName, displayName, activeVersion string, providingVersions []string) {
-	ui.Error("'%s' is not available in %s %s", commandName, displayName, activeVersion)
-
 	labeled := make([]string, len(providingVersions))
 	for i, v := range providingVersions {
 		labeled[i] = fmt.Sprintf("%s %s", displayName, v)
 	}
-	ui.Info("Available in: %s", strings.Join(labeled, ", "))
 
+	hints := []string{fmt.Sprintf("Available in: %s", strings.Join(labeled, ", "))}
 	if len(providingVersions) == 1 {
-		ui.Info("Switch with: dtvem global %s %s", runtimeName, providingVersions[0])
+		hints = append(hints, fmt.Sprintf("Switch with: dtvem global %s %s", runtimeName, providingVersions[0]))
 	} else {
-		ui.Info("Switch with 'dtvem global %s <version>' or set a local version.", runtimeName)
+		hints = append(hints, fmt.Sprintf("Switch with 'dtvem global %s <version>' or set a local version.", runtimeName))
 	}
+
+	reportError(fmt.Sprintf("'%s' is not available in %s %s", commandName, displayName, activeVersion), hints...)
+}
+
+// WhichOutput is the --output json|yaml result of "dtvem which"
+type WhichOutput struct {
+	Command    string `json:"command" yaml:"command"`
+	Shim       string `json:"shim" yaml:"shim"`
+	Executable string `json:"executable" yaml:"executable"`
+	Runtime    string `json:"runtime" yaml:"runtime"`
+	Version    string `json:"version" yaml:"version"`
 }
 
 func init() {
@@ -0,0 +1,372 @@
+// Package cache manages the content-addressed cache of downloaded runtime archives.
+//
+// Archives are stored under ~/.dtvem/cache/archives, one directory per
+// archive named after its manifest SHA256:
+//
+//	archives/
+//	  <sha256>/
+//	    node-v22.0.0-linux-x64.tar.gz
+//	    entry.json
+//
+// Keying by checksum lets identical archives be shared between reinstalls
+// and between machines or containers that mount the same dtvem root, and
+// means a cached file can always be re-verified before it is used.
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/download"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/filelock"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+)
+
+// entryFileName holds the metadata for a cached archive
+const entryFileName = "entry.json"
+
+// ErrNotCached is returned in offline mode when an archive is not in the cache
+var ErrNotCached = errors.New("archive is not in the download cache")
+
+// Request describes a runtime archive to fetch
+type Request struct {
+	Runtime  string // Runtime name (e.g., "node")
+	Version  string // Runtime version
+	URL      string // Download URL from the manifest
+	SHA256   string // Expected checksum from the manifest; may be empty
+	FileName string // Archive file name, used to pick the extractor
+}
+
+// Entry describes a cached archive
+type Entry struct {
+	SHA256   string    `json:"sha256"`
+	Runtime  string    `json:"runtime"`
+	Version  string    `json:"version"`
+	URL      string    `json:"url"`
+	FileName string    `json:"fileName"`
+	Size     int64     `json:"size"`
+	CachedAt time.Time `json:"cachedAt"`
+	LastUsed time.Time `json:"lastUsed"`
+}
+
+// Path returns the path of the cached archive
+func (e Entry) Path() string {
+	return filepath.Join(entryDir(e.SHA256), e.FileName)
+}
+
+// Fetch returns the path of the archive described by req, downloading it
+// into the cache first if needed. Cached archives are re-verified against
+// the checksum before use.
+//
+// Archives without a manifest checksum cannot be content-addressed; they are
+// downloaded into fallbackDir (subject to the checksum policy) and not cached.
+//
+// In offline mode, Fetch never touches the network and returns ErrNotCached
+// when the archive is missing.
+func Fetch(req Request, fallbackDir string, out ui.TaskOutput) (string, error) {
+	sha := normalizeSHA256(req.SHA256)
+
+	if !isSHA256(sha) {
+		if config.IsOffline() {
+			return "", fmt.Errorf("%w: %s %s has no checksum to look it up by", ErrNotCached, req.Runtime, req.Version)
+		}
+		out.Progress("Downloading from %s", req.URL)
+		path := filepath.Join(fallbackDir, req.FileName)
+		if err := download.FileWithPolicyProgress(req.URL, path, req.SHA256, config.GetChecksumPolicy(), out.Reporter()); err != nil {
+			return "", err
+		}
+		return path, nil
+	}
+
+	entry := Entry{
+		SHA256:   sha,
+		Runtime:  req.Runtime,
+		Version:  req.Version,
+		URL:      req.URL,
+		FileName: req.FileName,
+	}
+
+	// Processes fetching the same archive take turns, so the second one
+	// finds the first one's download instead of resuming the same partial file
+	lock, err := config.AcquireLock(archiveLockName(sha))
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = lock.Release() }()
+
+	if existing, ok := lookup(entry); ok {
+		ui.Debug("Using cached archive: %s", existing.Path())
+		out.Progress("Using cached archive %s", existing.FileName)
+		existing.LastUsed = time.Now()
+		_ = writeEntry(existing)
+		return existing.Path(), nil
+	}
+
+	if config.IsOffline() {
+		return "", fmt.Errorf("%w: %s %s (run without --offline to download it)", ErrNotCached, req.Runtime, req.Version)
+	}
+
+	out.Progress("Downloading from %s", req.URL)
+	path := entry.Path()
+	if err := download.FileWithPolicyProgress(req.URL, path, sha, config.GetChecksumPolicy(), out.Reporter()); err != nil {
+		return "", err
+	}
+
+	if info, err := os.Stat(path); err == nil {
+		entry.Size = info.Size()
+	}
+	entry.CachedAt = time.Now()
+	entry.LastUsed = entry.CachedAt
+	if err := writeEntry(entry); err != nil {
+		ui.Debug("Failed to write cache entry metadata: %v", err)
+	}
+
+	return path, nil
+}
+
+// lookup returns a verified cached archive matching the entry's checksum
+func lookup(want Entry) (Entry, bool) {
+	entry, err := readEntry(want.SHA256)
+	if err != nil {
+		// Archive present without metadata (e.g., written by an interrupted run)
+		entry = want
+	}
+
+	path := entry.Path()
+	if _, err := os.Stat(path); err != nil {
+		return Entry{}, false
+	}
+
+	if err := download.VerifyFile(path, want.SHA256); err != nil {
+		ui.Debug("Cached archive %s failed verification, discarding: %v", path, err)
+		_ = os.RemoveAll(entryDir(want.SHA256))
+		return Entry{}, false
+	}
+
+	if entry.Size == 0 {
+		if info, err := os.Stat(path); err == nil {
+			entry.Size = info.Size()
+		}
+	}
+	return entry, true
+}
+
+// List returns every cached archive, sorted by runtime and version
+func List() ([]Entry, error) {
+	dirs, err := os.ReadDir(config.ArchiveCacheDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Entry{}, nil
+		}
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(dirs))
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		entry, err := readEntry(dir.Name())
+		if err != nil {
+			ui.Debug("Skipping cache directory %s: %v", dir.Name(), err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Runtime != entries[j].Runtime {
+			return entries[i].Runtime < entries[j].Runtime
+		}
+		return entries[i].Version < entries[j].Version
+	})
+	return entries, nil
+}
+
+// Remove deletes a cached archive, waiting for a process fetching it to
+// finish first
+func Remove(entry Entry) error {
+	lock, err := config.AcquireLock(archiveLockName(entry.SHA256))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Release() }()
+
+	return os.RemoveAll(entryDir(entry.SHA256))
+}
+
+// Prune removes cached archives for which remove returns true, along with
+// any cache directories that have no metadata and the partial files of
+// interrupted downloads. Archives and partial files another process is
+// fetching are left alone. Returns the removed entries and the number of
+// bytes freed.
+func Prune(remove func(Entry) bool) ([]Entry, int64, error) {
+	freed, err := download.RemovePartials(config.PartialDownloadsDir())
+	if err != nil {
+		return nil, freed, err
+	}
+
+	dirs, err := os.ReadDir(config.ArchiveCacheDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, freed, nil
+		}
+		return nil, freed, err
+	}
+
+	var removed []Entry
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+
+		// Without metadata, the directory is an orphaned partial download,
+		// unless a fetch holding the lock is still writing it
+		entry, entryErr := readEntry(dir.Name())
+		if entryErr == nil && !remove(entry) {
+			continue
+		}
+
+		size, ok, err := removeIdle(dir.Name())
+		if err != nil {
+			return removed, freed, err
+		}
+		if !ok {
+			continue
+		}
+		if entryErr != nil {
+			freed += size
+			continue
+		}
+		removed = append(removed, entry)
+		freed += entry.Size
+	}
+
+	return removed, freed, nil
+}
+
+// Clear removes every cached archive and partial download, except those
+// another process is fetching. Returns the number of bytes freed.
+func Clear() (int64, error) {
+	freed, err := download.RemovePartials(config.PartialDownloadsDir())
+	if err != nil {
+		return freed, err
+	}
+
+	dirs, err := os.ReadDir(config.ArchiveCacheDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return freed, nil
+		}
+		return freed, err
+	}
+
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		size, _, err := removeIdle(dir.Name())
+		if err != nil {
+			return freed, err
+		}
+		freed += size
+	}
+	return freed, nil
+}
+
+// removeIdle deletes the cache directory for an archive checksum unless a
+// fetch holds its lock. Returns the number of bytes freed and whether the
+// directory was removed.
+func removeIdle(sha string) (int64, bool, error) {
+	lock, err := filelock.TryAcquire(config.LockPath(archiveLockName(sha)))
+	if err != nil {
+		return 0, false, err
+	}
+	if lock == nil {
+		ui.Debug("Skipping cached archive %s: it is being fetched", sha)
+		return 0, false, nil
+	}
+	defer func() { _ = lock.Release() }()
+
+	dir := entryDir(sha)
+	size := dirSize(dir)
+	if err := os.RemoveAll(dir); err != nil {
+		return 0, false, err
+	}
+	return size, true, nil
+}
+
+// archiveLockName returns the name of the lock held while the archive with
+// the given checksum is fetched or removed
+func archiveLockName(sha string) string {
+	return "archive-" + sha
+}
+
+// entryDir returns the directory for an archive checksum
+func entryDir(sha string) string {
+	return filepath.Join(config.ArchiveCacheDir(), sha)
+}
+
+// readEntry loads the metadata for a cached archive
+func readEntry(sha string) (Entry, error) {
+	data, err := os.ReadFile(filepath.Join(entryDir(sha), entryFileName))
+	if err != nil {
+		return Entry{}, err
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return Entry{}, fmt.Errorf("invalid cache entry: %w", err)
+	}
+	if entry.SHA256 != sha || entry.FileName == "" {
+		return Entry{}, fmt.Errorf("cache entry does not match directory %s", sha)
+	}
+	return entry, nil
+}
+
+// writeEntry saves the metadata for a cached archive
+func writeEntry(entry Entry) error {
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	return filelock.WriteFile(filepath.Join(entryDir(entry.SHA256), entryFileName), data, 0644)
+}
+
+// dirSize returns the total size of regular files under dir
+func dirSize(dir string) int64 {
+	var size int64
+	_ = filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+// isSHA256 reports whether s is a lowercase hex SHA256 digest, and therefore
+// safe to use as a cache directory name
+func isSHA256(s string) bool {
+	if len(s) != 64 {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeSHA256 lowercases a checksum so it can be used as a directory name
+func normalizeSHA256(sha string) string {
+	return strings.ToLower(strings.TrimSpace(sha))
+}
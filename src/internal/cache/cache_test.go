@@ -0,0 +1,265 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+)
+
+// withTempRoot points DTVEM_ROOT at a temporary directory for the test
+func withTempRoot(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	t.Setenv("DTVEM_ROOT", root)
+	config.ResetPathsCache()
+	t.Cleanup(config.ResetPathsCache)
+	return root
+}
+
+// archiveServer serves content and counts requests
+func archiveServer(t *testing.T, content []byte) (*httptest.Server, *int32) {
+	t.Helper()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_, _ = w.Write(content)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestFetch_CachesByChecksum(t *testing.T) {
+	withTempRoot(t)
+	content := []byte("node archive contents")
+	server, requests := archiveServer(t, content)
+
+	req := Request{
+		Runtime:  "node",
+		Version:  "22.0.0",
+		URL:      server.URL + "/node-v22.0.0-linux-x64.tar.gz",
+		SHA256:   sha256Hex(content),
+		FileName: "node-v22.0.0-linux-x64.tar.gz",
+	}
+	out := ui.NewTaskOutput(nil)
+
+	first, err := Fetch(req, t.TempDir(), out)
+	if err != nil {
+		t.Fatalf("first Fetch failed: %v", err)
+	}
+	second, err := Fetch(req, t.TempDir(), out)
+	if err != nil {
+		t.Fatalf("second Fetch failed: %v", err)
+	}
+
+	if first != second {
+		t.Errorf("Fetch paths differ: %q vs %q", first, second)
+	}
+	if *requests != 1 {
+		t.Errorf("requests = %d, want 1 (second fetch should hit the cache)", *requests)
+	}
+	if filepath.Dir(first) != filepath.Join(config.ArchiveCacheDir(), req.SHA256) {
+		t.Errorf("archive stored at %q, want it under the checksum directory", first)
+	}
+
+	entries, err := List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Runtime != "node" || entries[0].Size != int64(len(content)) {
+		t.Errorf("List() = %+v, want one node entry of %d bytes", entries, len(content))
+	}
+}
+
+func TestFetch_RedownloadsCorruptArchive(t *testing.T) {
+	withTempRoot(t)
+	content := []byte("python archive contents")
+	server, requests := archiveServer(t, content)
+
+	req := Request{
+		Runtime:  "python",
+		Version:  "3.12.1",
+		URL:      server.URL + "/python.tar.gz",
+		SHA256:   sha256Hex(content),
+		FileName: "python.tar.gz",
+	}
+
+	path, err := Fetch(req, t.TempDir(), ui.NewTaskOutput(nil))
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("corrupted"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Fetch(req, t.TempDir(), ui.NewTaskOutput(nil)); err != nil {
+		t.Fatalf("Fetch after corruption failed: %v", err)
+	}
+	if *requests != 2 {
+		t.Errorf("requests = %d, want 2 (corrupt archive should be re-downloaded)", *requests)
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != string(content) {
+		t.Error("cached archive was not replaced with the verified download")
+	}
+}
+
+func TestFetch_Offline(t *testing.T) {
+	withTempRoot(t)
+	content := []byte("ruby archive contents")
+	server, requests := archiveServer(t, content)
+
+	config.SetOffline(true)
+	t.Cleanup(func() { config.SetOffline(false) })
+
+	req := Request{
+		Runtime:  "ruby",
+		Version:  "3.3.0",
+		URL:      server.URL + "/ruby.tar.gz",
+		SHA256:   sha256Hex(content),
+		FileName: "ruby.tar.gz",
+	}
+
+	_, err := Fetch(req, t.TempDir(), ui.NewTaskOutput(nil))
+	if !errors.Is(err, ErrNotCached) {
+		t.Fatalf("offline Fetch error = %v, want ErrNotCached", err)
+	}
+	if *requests != 0 {
+		t.Errorf("offline Fetch made %d requests, want 0", *requests)
+	}
+
+	// Once cached, offline installs succeed
+	config.SetOffline(false)
+	if _, err := Fetch(req, t.TempDir(), ui.NewTaskOutput(nil)); err != nil {
+		t.Fatalf("online Fetch failed: %v", err)
+	}
+	config.SetOffline(true)
+	if _, err := Fetch(req, t.TempDir(), ui.NewTaskOutput(nil)); err != nil {
+		t.Errorf("offline Fetch of cached archive failed: %v", err)
+	}
+}
+
+func TestFetch_WithoutChecksumIsNotCached(t *testing.T) {
+	withTempRoot(t)
+	t.Setenv(config.ChecksumPolicyEnvVar, string(config.ChecksumPolicySkip))
+	content := []byte("unverified")
+	server, _ := archiveServer(t, content)
+
+	fallback := t.TempDir()
+	path, err := Fetch(Request{Runtime: "node", Version: "1.0.0", URL: server.URL, FileName: "a.tar.gz"}, fallback, ui.NewTaskOutput(nil))
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if filepath.Dir(path) != fallback {
+		t.Errorf("Fetch path = %q, want it in the fallback directory", path)
+	}
+
+	entries, _ := List()
+	if len(entries) != 0 {
+		t.Errorf("List() = %+v, want no cached entries", entries)
+	}
+}
+
+func TestPruneAndClear(t *testing.T) {
+	withTempRoot(t)
+
+	old := Entry{SHA256: sha256Hex([]byte("old")), Runtime: "node", Version: "18.0.0", FileName: "old.tar.gz", Size: 3, LastUsed: time.Now().Add(-48 * time.Hour)}
+	recent := Entry{SHA256: sha256Hex([]byte("new")), Runtime: "node", Version: "22.0.0", FileName: "new.tar.gz", Size: 3, LastUsed: time.Now()}
+	for _, entry := range []Entry{old, recent} {
+		if err := os.MkdirAll(entryDir(entry.SHA256), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(entry.Path(), []byte("abc"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := writeEntry(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Leftover from an interrupted download
+	orphan := filepath.Join(config.ArchiveCacheDir(), sha256Hex([]byte("partial")))
+	if err := os.MkdirAll(orphan, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(orphan, "x.tar.gz.partial"), []byte("12345"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	partial := filepath.Join(config.PartialDownloadsDir(), "x.partial")
+	if err := os.MkdirAll(filepath.Dir(partial), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(partial, []byte("1234"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, freed, err := Prune(func(e Entry) bool { return e.LastUsed.Before(time.Now().Add(-24 * time.Hour)) })
+	if err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if len(removed) != 1 || removed[0].Version != "18.0.0" {
+		t.Errorf("Prune removed %+v, want only 18.0.0", removed)
+	}
+	if freed != 12 {
+		t.Errorf("Prune freed %d bytes, want 12", freed)
+	}
+	if _, err := os.Stat(orphan); !os.IsNotExist(err) {
+		t.Error("Prune should remove cache directories without metadata")
+	}
+	if _, err := os.Stat(partial); !os.IsNotExist(err) {
+		t.Error("Prune should remove partial downloads")
+	}
+
+	if _, err := Clear(); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	entries, _ := List()
+	if len(entries) != 0 {
+		t.Errorf("List() after Clear = %+v, want empty", entries)
+	}
+}
+
+func TestPruneAndClear_SkipArchivesBeingFetched(t *testing.T) {
+	withTempRoot(t)
+
+	// A fetch in progress: the archive is downloading and has no metadata yet
+	sha := sha256Hex([]byte("downloading"))
+	if err := os.MkdirAll(entryDir(sha), 0755); err != nil {
+		t.Fatal(err)
+	}
+	lock, err := config.AcquireLock(archiveLockName(sha))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = lock.Release() }()
+
+	if _, _, err := Prune(func(Entry) bool { return true }); err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if _, err := os.Stat(entryDir(sha)); err != nil {
+		t.Error("Prune should not remove an archive that is being fetched")
+	}
+
+	if _, err := Clear(); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	if _, err := os.Stat(entryDir(sha)); err != nil {
+		t.Error("Clear should not remove an archive that is being fetched")
+	}
+}
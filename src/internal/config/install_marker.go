@@ -0,0 +1,265 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/filelock"
+)
+
+// InstallMarkerFileName is written into a runtime version directory once the
+// install, including post-install steps such as pip bootstrapping and shim
+// creation, has completed. Directories without it are incomplete installs.
+const InstallMarkerFileName = ".dtvem-installed"
+
+// FileManifestFileName is written into a runtime version directory at install
+// time and lists its files with their sizes and checksums, for dtvem verify
+const FileManifestFileName = ".dtvem-files.json"
+
+// StagingDirPrefix starts the name of the sibling directories that installs
+// are extracted into before being moved into place
+const StagingDirPrefix = ".staging-"
+
+// RepairDirPrefix starts the name of the sibling directory a repair moves
+// the previous install into until the reinstall succeeds. It may hold the
+// only copy of that install, so it is never reported as incomplete.
+const RepairDirPrefix = ".repair-"
+
+// markersEnabledFileName is created in the versions directory the first time
+// a version is installed with markers. Until then, every version directory is
+// treated as installed, so installs made before markers existed keep working.
+const markersEnabledFileName = ".install-markers"
+
+// InstallMarker is the content of the install marker file
+type InstallMarker struct {
+	Runtime     string    `json:"runtime"`
+	Version     string    `json:"version"`
+	InstalledAt time.Time `json:"installedAt"`
+}
+
+// IncompleteInstall is a directory under versions/<runtime> that is not a
+// committed install: a leftover staging directory, or a version directory
+// whose install was interrupted before its marker was written
+type IncompleteInstall struct {
+	Runtime string
+	Name    string // Directory name (the version, or the staging directory name)
+	Path    string
+	Staging bool
+}
+
+// IsVersionInstalled reports whether a runtime version is a committed install
+func IsVersionInstalled(runtimeName, version string) bool {
+	return IsCommittedInstall(DefaultPaths().Versions, runtimeName, version)
+}
+
+// IsCommittedInstall reports whether versionsDir/<runtime>/<name> is a
+// committed install. Staging directories never are; other directories need
+// an install marker once markers are enabled for versionsDir.
+func IsCommittedInstall(versionsDir, runtimeName, name string) bool {
+	if name == "" || strings.HasPrefix(name, ".") {
+		return false
+	}
+
+	dir := filepath.Join(versionsDir, runtimeName, name)
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return false
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, InstallMarkerFileName)); err == nil {
+		return true
+	}
+	return !markersEnabled(versionsDir)
+}
+
+// InstalledVersions returns the committed versions installed for a runtime.
+// Returns an empty slice if the runtime has no versions directory.
+func InstalledVersions(runtimeName string) []string {
+	versionsDir := DefaultPaths().Versions
+	entries, err := os.ReadDir(filepath.Join(versionsDir, runtimeName))
+	if err != nil {
+		return []string{}
+	}
+
+	versions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() && IsCommittedInstall(versionsDir, runtimeName, entry.Name()) {
+			versions = append(versions, entry.Name())
+		}
+	}
+	return versions
+}
+
+// IsLiveInstall reports whether versionsDir/<runtime>/<name> is a committed
+// install or one that a running install has moved into place but not yet
+// committed. Shim bookkeeping treats both as installed, so a reshim or an
+// uninstall in another process never deletes shims an install is creating.
+func IsLiveInstall(versionsDir, runtimeName, name string) bool {
+	if IsCommittedInstall(versionsDir, runtimeName, name) {
+		return true
+	}
+	if name == "" || strings.HasPrefix(name, ".") {
+		return false
+	}
+	if info, err := os.Stat(filepath.Join(versionsDir, runtimeName, name)); err != nil || !info.IsDir() {
+		return false
+	}
+	return installLockHeld(runtimeName, name)
+}
+
+// LiveVersions returns the versions of a runtime for which IsLiveInstall
+// holds. Returns an empty slice if the runtime has no versions directory.
+func LiveVersions(runtimeName string) []string {
+	versionsDir := DefaultPaths().Versions
+	entries, err := os.ReadDir(filepath.Join(versionsDir, runtimeName))
+	if err != nil {
+		return []string{}
+	}
+
+	versions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() && IsLiveInstall(versionsDir, runtimeName, entry.Name()) {
+			versions = append(versions, entry.Name())
+		}
+	}
+	return versions
+}
+
+// installLockHeld reports whether the install lock of a runtime version is
+// held, by this or another process
+func installLockHeld(runtimeName, version string) bool {
+	lock, err := filelock.TryAcquire(LockPath(InstallLockName(runtimeName, version)))
+	if err != nil {
+		return false
+	}
+	if lock == nil {
+		return true
+	}
+	_ = lock.Release()
+	return false
+}
+
+// IncompleteInstalls returns every directory under versionsDir that is not a
+// committed install, sorted by runtime and name. Installs set aside by a
+// repair are skipped.
+func IncompleteInstalls(versionsDir string) ([]IncompleteInstall, error) {
+	runtimeEntries, err := os.ReadDir(versionsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var incomplete []IncompleteInstall
+	for _, r := range runtimeEntries {
+		if !r.IsDir() {
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(versionsDir, r.Name()))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() || strings.HasPrefix(entry.Name(), RepairDirPrefix) ||
+				IsCommittedInstall(versionsDir, r.Name(), entry.Name()) {
+				continue
+			}
+			incomplete = append(incomplete, IncompleteInstall{
+				Runtime: r.Name(),
+				Name:    entry.Name(),
+				Path:    filepath.Join(versionsDir, r.Name(), entry.Name()),
+				Staging: strings.HasPrefix(entry.Name(), StagingDirPrefix),
+			})
+		}
+	}
+	return incomplete, nil
+}
+
+// WriteInstallMarker marks a runtime version as installed. The marker is
+// written atomically, so it either exists completely or not at all.
+func WriteInstallMarker(runtimeName, version string) error {
+	if err := EnableInstallMarkers(); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(InstallMarker{
+		Runtime:     runtimeName,
+		Version:     version,
+		InstalledAt: time.Now().UTC(),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	markerPath := filepath.Join(RuntimeVersionPath(runtimeName, version), InstallMarkerFileName)
+	if err := filelock.WriteFile(markerPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write install marker: %w", err)
+	}
+	return nil
+}
+
+// EnableInstallMarkers switches the versions directory to requiring install
+// markers. The first time, every existing version directory is marked, since
+// it was installed by a release that did not write markers. Called before
+// an install creates any new directory, so that an interrupted install is
+// never mistaken for a legacy one.
+func EnableInstallMarkers() error {
+	versionsDir := DefaultPaths().Versions
+	if markersEnabled(versionsDir) {
+		return nil
+	}
+
+	// Another process may be switching the directory over at the same time;
+	// whichever gets the lock second finds the flag and has nothing to do
+	lock, err := AcquireLock(VersionsLockName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Release() }()
+	if markersEnabled(versionsDir) {
+		return nil
+	}
+
+	runtimeEntries, err := os.ReadDir(versionsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, r := range runtimeEntries {
+		if !r.IsDir() {
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(versionsDir, r.Name()))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			markerPath := filepath.Join(versionsDir, r.Name(), entry.Name(), InstallMarkerFileName)
+			if _, err := os.Stat(markerPath); err == nil {
+				continue
+			}
+			data, _ := json.MarshalIndent(InstallMarker{Runtime: r.Name(), Version: entry.Name()}, "", "  ")
+			if err := os.WriteFile(markerPath, data, 0644); err != nil {
+				return fmt.Errorf("failed to mark existing install %s %s: %w", r.Name(), entry.Name(), err)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(versionsDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(versionsDir, markersEnabledFileName), []byte{}, 0644)
+}
+
+// markersEnabled reports whether installs under versionsDir require markers
+func markersEnabled(versionsDir string) bool {
+	_, err := os.Stat(filepath.Join(versionsDir, markersEnabledFileName))
+	return err == nil
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func setupInstallMarkerTest(t *testing.T) string {
+	t.Helper()
+	t.Setenv("DTVEM_ROOT", t.TempDir())
+	ResetPathsCache()
+	t.Cleanup(ResetPathsCache)
+	return DefaultPaths().Versions
+}
+
+func mkdirAll(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(path, 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIsVersionInstalled_LegacyDirectories(t *testing.T) {
+	versions := setupInstallMarkerTest(t)
+	mkdirAll(t, filepath.Join(versions, "node", "18.0.0"))
+	mkdirAll(t, filepath.Join(versions, "node", StagingDirPrefix+"20.0.0-1"))
+
+	if !IsVersionInstalled("node", "18.0.0") {
+		t.Error("a version installed before markers existed should count as installed")
+	}
+	if IsVersionInstalled("node", StagingDirPrefix+"20.0.0-1") {
+		t.Error("a staging directory should never count as installed")
+	}
+	if got := InstalledVersions("node"); len(got) != 1 || got[0] != "18.0.0" {
+		t.Errorf("InstalledVersions() = %v, want [18.0.0]", got)
+	}
+}
+
+func TestEnableInstallMarkers_MarksExistingVersions(t *testing.T) {
+	versions := setupInstallMarkerTest(t)
+	mkdirAll(t, filepath.Join(versions, "node", "18.0.0"))
+
+	if err := EnableInstallMarkers(); err != nil {
+		t.Fatalf("EnableInstallMarkers failed: %v", err)
+	}
+	if !IsVersionInstalled("node", "18.0.0") {
+		t.Error("existing versions should stay installed after enabling markers")
+	}
+
+	// A directory created afterwards without a marker is an interrupted install
+	mkdirAll(t, filepath.Join(versions, "node", "20.0.0"))
+	if IsVersionInstalled("node", "20.0.0") {
+		t.Error("an unmarked directory should not count as installed once markers are enabled")
+	}
+	if got := InstalledVersions("node"); len(got) != 1 || got[0] != "18.0.0" {
+		t.Errorf("InstalledVersions() = %v, want [18.0.0]", got)
+	}
+
+	if err := WriteInstallMarker("node", "20.0.0"); err != nil {
+		t.Fatalf("WriteInstallMarker failed: %v", err)
+	}
+	if !IsVersionInstalled("node", "20.0.0") {
+		t.Error("a marked version should count as installed")
+	}
+}
+
+func TestIncompleteInstalls(t *testing.T) {
+	versions := setupInstallMarkerTest(t)
+	mkdirAll(t, filepath.Join(versions, "node", "18.0.0"))
+	if err := EnableInstallMarkers(); err != nil {
+		t.Fatal(err)
+	}
+	mkdirAll(t, filepath.Join(versions, "node", "20.0.0"))
+	mkdirAll(t, filepath.Join(versions, "python", StagingDirPrefix+"3.12.0-1"))
+	mkdirAll(t, filepath.Join(versions, "python", RepairDirPrefix+"3.11.0"))
+
+	got, err := IncompleteInstalls(versions)
+	if err != nil {
+		t.Fatalf("IncompleteInstalls failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("IncompleteInstalls() = %+v, want 2 entries", got)
+	}
+	if got[0].Runtime != "node" || got[0].Name != "20.0.0" || got[0].Staging {
+		t.Errorf("unexpected first entry: %+v", got[0])
+	}
+	if got[1].Runtime != "python" || !got[1].Staging {
+		t.Errorf("unexpected second entry: %+v", got[1])
+	}
+}
+
+func TestLiveVersions_InstallInProgress(t *testing.T) {
+	versions := setupInstallMarkerTest(t)
+	if err := EnableInstallMarkers(); err != nil {
+		t.Fatal(err)
+	}
+	mkdirAll(t, filepath.Join(versions, "node", "20.0.0"))
+	mkdirAll(t, filepath.Join(versions, "node", StagingDirPrefix+"22.0.0-1"))
+
+	if got := LiveVersions("node"); len(got) != 0 {
+		t.Errorf("LiveVersions() = %v for an interrupted install, want none", got)
+	}
+
+	// An install that has moved 20.0.0 into place holds its install lock
+	lock, err := AcquireLock(InstallLockName("node", "20.0.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := LiveVersions("node"); len(got) != 1 || got[0] != "20.0.0" {
+		t.Errorf("LiveVersions() = %v while 20.0.0 is being installed, want [20.0.0]", got)
+	}
+	if IsVersionInstalled("node", "20.0.0") {
+		t.Error("an install in progress should not count as installed")
+	}
+	_ = lock.Release()
+
+	if got := LiveVersions("node"); len(got) != 0 {
+		t.Errorf("LiveVersions() = %v after the install stopped, want none", got)
+	}
+}
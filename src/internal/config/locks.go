@@ -0,0 +1,56 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/filelock"
+)
+
+// LocksDirName is the name of the lock file directory under Paths.Root
+const LocksDirName = "locks"
+
+// Names of the locks that serialize dtvem processes sharing a DTVEM_ROOT
+const (
+	// ShimsLockName guards the shims directory and the shim-map cache
+	ShimsLockName = "shims"
+	// VersionsLockName guards changes that span the whole versions directory
+	VersionsLockName = "versions"
+)
+
+// LockPath returns the path of the lock file with the given name
+func LockPath(name string) string {
+	paths := DefaultPaths()
+	return filepath.Join(paths.Root, LocksDirName, name+".lock")
+}
+
+// AcquireLock blocks until the named lock is held
+func AcquireLock(name string) (*filelock.Lock, error) {
+	return filelock.Acquire(LockPath(name))
+}
+
+// InstallLockName returns the name of the lock held while a runtime version
+// is installed or removed
+func InstallLockName(runtimeName, version string) string {
+	return "install-" + runtimeName + "-" + version
+}
+
+// FileLockName returns the name of the lock guarding read-modify-write
+// updates of a config file, such as settings.json or a runtimes.json. Each
+// file has its own lock, so updating one project's config doesn't wait for
+// another's.
+func FileLockName(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	sum := sha256.Sum256([]byte(filepath.Clean(path)))
+	return "file-" + hex.EncodeToString(sum[:8])
+}
+
+// AutoInstallLockName returns the name of the lock a shim holds while it
+// installs a missing version of a runtime, so parallel invocations wait for
+// one install instead of each starting their own
+func AutoInstallLockName(runtimeName string) string {
+	return "auto-install-" + runtimeName
+}
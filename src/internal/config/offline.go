@@ -0,0 +1,29 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// OfflineEnvVar enables offline mode when set to "1" or "true"
+const OfflineEnvVar = "DTVEM_OFFLINE"
+
+// offline is set by the --offline flag
+var offline atomic.Bool
+
+// SetOffline enables or disables offline mode for this process.
+// In offline mode, installs only use archives from the download cache and
+// version lookups only use the manifests embedded in the binary.
+func SetOffline(enabled bool) {
+	offline.Store(enabled)
+}
+
+// IsOffline reports whether offline mode is enabled by flag or environment
+func IsOffline() bool {
+	if offline.Load() {
+		return true
+	}
+	val := strings.ToLower(strings.TrimSpace(os.Getenv(OfflineEnvVar)))
+	return val == "1" || val == "true"
+}
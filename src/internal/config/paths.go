@@ -176,6 +176,36 @@ func ShimMapPath() string {
 	return filepath.Join(paths.Cache, ShimMapFileName)
 }
 
+// ArchiveCacheDirName is the name of the download cache directory under Paths.Cache
+const ArchiveCacheDirName = "archives"
+
+// ArchiveCacheDir returns the directory holding cached runtime archives
+func ArchiveCacheDir() string {
+	paths := DefaultPaths()
+	return filepath.Join(paths.Cache, ArchiveCacheDirName)
+}
+
+// PartialDownloadsDirName is the name of the directory under Paths.Cache
+// holding interrupted downloads
+const PartialDownloadsDirName = "partials"
+
+// PartialDownloadsDir returns the directory holding interrupted downloads,
+// kept until a later attempt resumes and completes them
+func PartialDownloadsDir() string {
+	paths := DefaultPaths()
+	return filepath.Join(paths.Cache, PartialDownloadsDirName)
+}
+
+// LogsDirName is the name of the directory under Paths.Root holding logs of
+// changes dtvem makes outside its own directories
+const LogsDirName = "logs"
+
+// LogsDir returns the directory holding dtvem's logs
+func LogsDir() string {
+	paths := DefaultPaths()
+	return filepath.Join(paths.Root, LogsDirName)
+}
+
 // ResetPathsCache resets the cached paths, forcing reinitialization on next access.
 // This is primarily useful for testing.
 func ResetPathsCache() {
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/filelock"
 )
 
 // InstallType represents the type of dtvem installation
@@ -17,12 +20,92 @@ const (
 	InstallTypeUser InstallType = "user"
 )
 
+// ChecksumPolicy controls how installs behave when the manifest has no
+// SHA256 checksum for a download. Downloads whose checksum is present but
+// does not match are always rejected, regardless of policy.
+type ChecksumPolicy string
+
+const (
+	// ChecksumPolicyRequire refuses to install downloads without a checksum
+	ChecksumPolicyRequire ChecksumPolicy = "require"
+	// ChecksumPolicyWarn installs unverified downloads after printing a warning
+	ChecksumPolicyWarn ChecksumPolicy = "warn"
+	// ChecksumPolicySkip installs unverified downloads silently
+	ChecksumPolicySkip ChecksumPolicy = "skip"
+)
+
+// DefaultChecksumPolicy is used when no policy is configured
+const DefaultChecksumPolicy = ChecksumPolicyWarn
+
+// ChecksumPolicyEnvVar overrides the checksum policy from settings.json
+const ChecksumPolicyEnvVar = "DTVEM_CHECKSUM_POLICY"
+
+// ReshimPolicy controls what a shim does after a package manager command
+// (e.g., npm install -g, pip install) that may have added executables
+type ReshimPolicy string
+
+const (
+	// ReshimPolicyAuto creates shims for new executables without asking
+	ReshimPolicyAuto ReshimPolicy = "auto"
+	// ReshimPolicyNever leaves shims alone; run dtvem reshim manually
+	ReshimPolicyNever ReshimPolicy = "never"
+	// ReshimPolicyPrompt asks before creating shims. Without a terminal to
+	// ask on (CI, editors), shims are created as with auto.
+	ReshimPolicyPrompt ReshimPolicy = "prompt"
+)
+
+// DefaultReshimPolicy is used when no policy is configured
+const DefaultReshimPolicy = ReshimPolicyPrompt
+
+// ReshimPolicyEnvVar overrides the reshim policy from settings.json
+const ReshimPolicyEnvVar = "DTVEM_RESHIM_POLICY"
+
+// ShimStrategy controls how shims are laid down in the shims directory
+type ShimStrategy string
+
+const (
+	// ShimStrategyCopy copies the shim binary for every shim
+	ShimStrategyCopy ShimStrategy = "copy"
+	// ShimStrategySymlink symlinks every shim to the shim binary
+	ShimStrategySymlink ShimStrategy = "symlink"
+	// ShimStrategyHardlink hard-links every shim to the shim binary, falling
+	// back to a copy where a link is not possible (e.g., across filesystems)
+	ShimStrategyHardlink ShimStrategy = "hardlink"
+)
+
+// DefaultShimStrategy is used when no strategy is configured
+const DefaultShimStrategy = ShimStrategyCopy
+
+// ShimStrategyEnvVar overrides the shim strategy from settings.json
+const ShimStrategyEnvVar = "DTVEM_SHIM_STRATEGY"
+
+// AutoInstallEnvVar enables ("true") or disables ("false") installing
+// missing versions without prompting, overriding settings.json for shims
+const AutoInstallEnvVar = "DTVEM_AUTO_INSTALL"
+
 // SettingsFileName is the name of the settings configuration file
 const SettingsFileName = "settings.json"
 
 // Settings holds dtvem installation settings
 type Settings struct {
-	InstallType InstallType `json:"installType"`
+	InstallType    InstallType    `json:"installType"`
+	ChecksumPolicy ChecksumPolicy `json:"checksumPolicy,omitempty"`
+	ReshimPolicy   ReshimPolicy   `json:"reshimPolicy,omitempty"`
+	ShimStrategy   ShimStrategy   `json:"shimStrategy,omitempty"`
+	// ShimAutoInstall makes a shim install a configured version that is
+	// missing, then run the command, instead of failing
+	ShimAutoInstall bool             `json:"shimAutoInstall,omitempty"`
+	Network         *NetworkSettings `json:"network,omitempty"`
+}
+
+// defaultSettings returns the settings used when no settings file exists
+func defaultSettings() *Settings {
+	return &Settings{
+		InstallType:    InstallTypeSystem,
+		ChecksumPolicy: DefaultChecksumPolicy,
+		ReshimPolicy:   DefaultReshimPolicy,
+		ShimStrategy:   DefaultShimStrategy,
+	}
 }
 
 // SettingsPath returns the path to the settings file
@@ -40,7 +123,7 @@ func LoadSettings() (*Settings, error) {
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			// Return default settings if file doesn't exist
-			return &Settings{InstallType: InstallTypeSystem}, nil
+			return defaultSettings(), nil
 		}
 		return nil, err
 	}
@@ -56,11 +139,56 @@ func LoadSettings() (*Settings, error) {
 		settings.InstallType = InstallTypeSystem
 	}
 
+	// Validate checksum policy
+	if !settings.ChecksumPolicy.IsValid() {
+		settings.ChecksumPolicy = DefaultChecksumPolicy
+	}
+
+	// Validate reshim policy
+	if !settings.ReshimPolicy.IsValid() {
+		settings.ReshimPolicy = DefaultReshimPolicy
+	}
+
+	// Validate shim strategy
+	if !settings.ShimStrategy.IsValid() {
+		settings.ShimStrategy = DefaultShimStrategy
+	}
+
 	return &settings, nil
 }
 
-// SaveSettings saves settings to the settings file
+// SaveSettings saves settings to the settings file, replacing its contents.
+// To change some settings and keep the rest, use UpdateSettings.
 func SaveSettings(settings *Settings) error {
+	lock, err := AcquireLock(FileLockName(SettingsPath()))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Release() }()
+
+	return writeSettings(settings)
+}
+
+// UpdateSettings loads the settings, applies update and saves the result.
+// The settings file's lock is held from load to write, so concurrent
+// processes don't drop each other's changes.
+func UpdateSettings(update func(*Settings)) error {
+	lock, err := AcquireLock(FileLockName(SettingsPath()))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Release() }()
+
+	settings, err := LoadSettings()
+	if err != nil {
+		return err
+	}
+	update(settings)
+	return writeSettings(settings)
+}
+
+// writeSettings replaces the settings file atomically. The caller holds its lock.
+func writeSettings(settings *Settings) error {
 	settingsPath := SettingsPath()
 
 	// Ensure the config directory exists
@@ -74,7 +202,7 @@ func SaveSettings(settings *Settings) error {
 		return err
 	}
 
-	return os.WriteFile(settingsPath, data, 0644)
+	return filelock.WriteFile(settingsPath, data, 0644)
 }
 
 // IsUserInstall checks if the current installation is a user-level install
@@ -85,3 +213,89 @@ func IsUserInstall() bool {
 	}
 	return settings.InstallType == InstallTypeUser
 }
+
+// IsValid reports whether the policy is one of the known values
+func (p ChecksumPolicy) IsValid() bool {
+	switch p {
+	case ChecksumPolicyRequire, ChecksumPolicyWarn, ChecksumPolicySkip:
+		return true
+	}
+	return false
+}
+
+// GetChecksumPolicy returns the effective checksum policy.
+// Priority: DTVEM_CHECKSUM_POLICY env var > settings.json > default (warn)
+func GetChecksumPolicy() ChecksumPolicy {
+	if env := ChecksumPolicy(strings.ToLower(strings.TrimSpace(os.Getenv(ChecksumPolicyEnvVar)))); env.IsValid() {
+		return env
+	}
+
+	settings, err := LoadSettings()
+	if err != nil {
+		return DefaultChecksumPolicy
+	}
+	return settings.ChecksumPolicy
+}
+
+// IsValid reports whether the policy is one of the known values
+func (p ReshimPolicy) IsValid() bool {
+	switch p {
+	case ReshimPolicyAuto, ReshimPolicyNever, ReshimPolicyPrompt:
+		return true
+	}
+	return false
+}
+
+// GetReshimPolicy returns the effective reshim policy.
+// Priority: DTVEM_RESHIM_POLICY env var > settings.json > default (prompt)
+func GetReshimPolicy() ReshimPolicy {
+	if env := ReshimPolicy(strings.ToLower(strings.TrimSpace(os.Getenv(ReshimPolicyEnvVar)))); env.IsValid() {
+		return env
+	}
+
+	settings, err := LoadSettings()
+	if err != nil {
+		return DefaultReshimPolicy
+	}
+	return settings.ReshimPolicy
+}
+
+// IsValid reports whether the strategy is one of the known values
+func (s ShimStrategy) IsValid() bool {
+	switch s {
+	case ShimStrategyCopy, ShimStrategySymlink, ShimStrategyHardlink:
+		return true
+	}
+	return false
+}
+
+// GetShimStrategy returns the configured shim strategy.
+// Priority: DTVEM_SHIM_STRATEGY env var > settings.json > default (copy)
+func GetShimStrategy() ShimStrategy {
+	if env := ShimStrategy(strings.ToLower(strings.TrimSpace(os.Getenv(ShimStrategyEnvVar)))); env.IsValid() {
+		return env
+	}
+
+	settings, err := LoadSettings()
+	if err != nil {
+		return DefaultShimStrategy
+	}
+	return settings.ShimStrategy
+}
+
+// ShimAutoInstallEnabled reports whether shims install missing versions.
+// Priority: DTVEM_AUTO_INSTALL env var > settings.json > default (off)
+func ShimAutoInstallEnabled() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(AutoInstallEnvVar))) {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	settings, err := LoadSettings()
+	if err != nil {
+		return false
+	}
+	return settings.ShimAutoInstall
+}
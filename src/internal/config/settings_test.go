@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 )
 
@@ -295,3 +296,196 @@ func TestSettingsFileName(t *testing.T) {
 		t.Errorf("SettingsFileName = %q, want %q", SettingsFileName, expected)
 	}
 }
+
+func TestLoadSettings_ChecksumPolicy(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("DTVEM_ROOT", tmpDir)
+	t.Setenv(ChecksumPolicyEnvVar, "")
+	resetPathsForTesting()
+	defer resetPathsForTesting()
+
+	configDir := filepath.Join(tmpDir, "config")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config directory: %v", err)
+	}
+	settingsPath := filepath.Join(configDir, SettingsFileName)
+
+	tests := []struct {
+		name string
+		json string
+		want ChecksumPolicy
+	}{
+		{"missing defaults to warn", `{"installType": "user"}`, ChecksumPolicyWarn},
+		{"require", `{"installType": "user", "checksumPolicy": "require"}`, ChecksumPolicyRequire},
+		{"skip", `{"installType": "user", "checksumPolicy": "skip"}`, ChecksumPolicySkip},
+		{"invalid defaults to warn", `{"installType": "user", "checksumPolicy": "bogus"}`, ChecksumPolicyWarn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.WriteFile(settingsPath, []byte(tt.json), 0644); err != nil {
+				t.Fatalf("Failed to write test settings file: %v", err)
+			}
+
+			settings, err := LoadSettings()
+			if err != nil {
+				t.Fatalf("LoadSettings() unexpected error: %v", err)
+			}
+			if settings.ChecksumPolicy != tt.want {
+				t.Errorf("ChecksumPolicy = %q, want %q", settings.ChecksumPolicy, tt.want)
+			}
+			if got := GetChecksumPolicy(); got != tt.want {
+				t.Errorf("GetChecksumPolicy() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetChecksumPolicy_EnvOverride(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("DTVEM_ROOT", tmpDir)
+	resetPathsForTesting()
+	defer resetPathsForTesting()
+
+	if err := SaveSettings(&Settings{InstallType: InstallTypeUser, ChecksumPolicy: ChecksumPolicySkip}); err != nil {
+		t.Fatalf("SaveSettings() error = %v", err)
+	}
+
+	t.Setenv(ChecksumPolicyEnvVar, "REQUIRE")
+	if got := GetChecksumPolicy(); got != ChecksumPolicyRequire {
+		t.Errorf("GetChecksumPolicy() with env override = %q, want %q", got, ChecksumPolicyRequire)
+	}
+
+	t.Setenv(ChecksumPolicyEnvVar, "nonsense")
+	if got := GetChecksumPolicy(); got != ChecksumPolicySkip {
+		t.Errorf("GetChecksumPolicy() with invalid env = %q, want %q", got, ChecksumPolicySkip)
+	}
+}
+
+func TestGetReshimPolicy(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("DTVEM_ROOT", tmpDir)
+	t.Setenv(ReshimPolicyEnvVar, "")
+	resetPathsForTesting()
+	defer resetPathsForTesting()
+
+	if got := GetReshimPolicy(); got != DefaultReshimPolicy {
+		t.Errorf("GetReshimPolicy() without settings = %q, want %q", got, DefaultReshimPolicy)
+	}
+
+	if err := SaveSettings(&Settings{InstallType: InstallTypeUser, ReshimPolicy: ReshimPolicyNever}); err != nil {
+		t.Fatalf("SaveSettings() error = %v", err)
+	}
+	if got := GetReshimPolicy(); got != ReshimPolicyNever {
+		t.Errorf("GetReshimPolicy() = %q, want %q", got, ReshimPolicyNever)
+	}
+
+	t.Setenv(ReshimPolicyEnvVar, "Auto")
+	if got := GetReshimPolicy(); got != ReshimPolicyAuto {
+		t.Errorf("GetReshimPolicy() with env override = %q, want %q", got, ReshimPolicyAuto)
+	}
+
+	t.Setenv(ReshimPolicyEnvVar, "sometimes")
+	if got := GetReshimPolicy(); got != ReshimPolicyNever {
+		t.Errorf("GetReshimPolicy() with invalid env = %q, want %q", got, ReshimPolicyNever)
+	}
+}
+
+func TestShimAutoInstallEnabled(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("DTVEM_ROOT", tmpDir)
+	t.Setenv(AutoInstallEnvVar, "")
+	resetPathsForTesting()
+	defer resetPathsForTesting()
+
+	if ShimAutoInstallEnabled() {
+		t.Error("ShimAutoInstallEnabled() should default to false")
+	}
+
+	if err := SaveSettings(&Settings{InstallType: InstallTypeUser, ShimAutoInstall: true}); err != nil {
+		t.Fatalf("SaveSettings() error = %v", err)
+	}
+	if !ShimAutoInstallEnabled() {
+		t.Error("ShimAutoInstallEnabled() should follow settings.json")
+	}
+
+	t.Setenv(AutoInstallEnvVar, "false")
+	if ShimAutoInstallEnabled() {
+		t.Error("DTVEM_AUTO_INSTALL=false should override settings.json")
+	}
+
+	t.Setenv(AutoInstallEnvVar, "true")
+	if err := SaveSettings(&Settings{InstallType: InstallTypeUser}); err != nil {
+		t.Fatalf("SaveSettings() error = %v", err)
+	}
+	if !ShimAutoInstallEnabled() {
+		t.Error("DTVEM_AUTO_INSTALL=true should enable auto-install")
+	}
+}
+
+func TestGetShimStrategy(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("DTVEM_ROOT", tmpDir)
+	t.Setenv(ShimStrategyEnvVar, "")
+	resetPathsForTesting()
+	defer resetPathsForTesting()
+
+	if got := GetShimStrategy(); got != DefaultShimStrategy {
+		t.Errorf("GetShimStrategy() without settings = %q, want %q", got, DefaultShimStrategy)
+	}
+
+	if err := SaveSettings(&Settings{InstallType: InstallTypeUser, ShimStrategy: ShimStrategySymlink}); err != nil {
+		t.Fatalf("SaveSettings() error = %v", err)
+	}
+	if got := GetShimStrategy(); got != ShimStrategySymlink {
+		t.Errorf("GetShimStrategy() = %q, want %q", got, ShimStrategySymlink)
+	}
+
+	t.Setenv(ShimStrategyEnvVar, "HARDLINK")
+	if got := GetShimStrategy(); got != ShimStrategyHardlink {
+		t.Errorf("GetShimStrategy() with env override = %q, want %q", got, ShimStrategyHardlink)
+	}
+}
+
+func TestUpdateSettings_ConcurrentUpdatesKeepEachChange(t *testing.T) {
+	t.Setenv("DTVEM_ROOT", t.TempDir())
+	resetPathsForTesting()
+	defer resetPathsForTesting()
+
+	updates := []func(*Settings){
+		func(s *Settings) { s.InstallType = InstallTypeUser },
+		func(s *Settings) { s.ChecksumPolicy = ChecksumPolicyRequire },
+		func(s *Settings) { s.ReshimPolicy = ReshimPolicyNever },
+		func(s *Settings) { s.ShimStrategy = ShimStrategySymlink },
+		func(s *Settings) { s.ShimAutoInstall = true },
+	}
+
+	var wg sync.WaitGroup
+	for _, update := range updates {
+		wg.Add(1)
+		go func(update func(*Settings)) {
+			defer wg.Done()
+			if err := UpdateSettings(update); err != nil {
+				t.Errorf("UpdateSettings() error = %v", err)
+			}
+		}(update)
+	}
+	wg.Wait()
+
+	settings, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings() error = %v", err)
+	}
+	want := Settings{
+		InstallType:     InstallTypeUser,
+		ChecksumPolicy:  ChecksumPolicyRequire,
+		ReshimPolicy:    ReshimPolicyNever,
+		ShimStrategy:    ShimStrategySymlink,
+		ShimAutoInstall: true,
+	}
+	if settings.InstallType != want.InstallType || settings.ChecksumPolicy != want.ChecksumPolicy ||
+		settings.ReshimPolicy != want.ReshimPolicy || settings.ShimStrategy != want.ShimStrategy ||
+		settings.ShimAutoInstall != want.ShimAutoInstall {
+		t.Errorf("settings after concurrent updates = %+v, want %+v", *settings, want)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/filelock"
 )
 
 // RuntimesConfig represents the flat structure of runtimes.json
@@ -16,57 +18,81 @@ type RuntimesConfig map[string]string
 const SchemaURL = "https://raw.githubusercontent.com/CodingWithCalvin/dtvem.cli/main/schemas/runtimes.schema.json"
 
 // ResolveVersion finds the version to use for a runtime
-// Priority: local dtvem.config.json file (walking up directory tree) > global config
+// Priority: DTVEM_<RUNTIME>_VERSION > local version files (walking up directory tree) > global config
 func ResolveVersion(runtimeName string) (string, error) {
-	// First, try to find local version
-	localVersion, err := findLocalVersion(runtimeName)
-	if err == nil && localVersion != "" {
-		return localVersion, nil
+	resolved, err := ResolveVersionSource(runtimeName)
+	if err != nil {
+		return "", err
+	}
+	return resolved.Version, nil
+}
+
+// ResolveVersionSource finds the version to use for a runtime along with the
+// file that supplied it. A DTVEM_<RUNTIME>_VERSION environment variable
+// overrides everything; otherwise local version files (see VersionFile for
+// precedence) are checked walking up from the current directory, then the
+// global config.
+func ResolveVersionSource(runtimeName string) (*ResolvedVersion, error) {
+	// A per-invocation override wins over any configuration
+	if resolved, ok := EnvVersionSource(runtimeName); ok {
+		return resolved, nil
+	}
+
+	// Next, try to find local version
+	if resolved, err := LocalVersionSource(runtimeName); err == nil {
+		return resolved, nil
 	}
 
 	// Fall back to global version
 	globalVersion, err := GlobalVersion(runtimeName)
 	if err == nil && globalVersion != "" {
-		return globalVersion, nil
+		return &ResolvedVersion{Version: globalVersion, Source: GlobalConfigPath()}, nil
 	}
 
-	return "", fmt.Errorf("no version configured for %s", runtimeName)
+	return nil, fmt.Errorf("no version configured for %s", runtimeName)
 }
 
-// findLocalVersion walks up the directory tree looking for .dtvem/runtimes.json file
-// Stops at filesystem root
-func findLocalVersion(runtimeName string) (string, error) {
-	// Start from current working directory
-	currentDir, err := os.Getwd()
-	if err != nil {
-		return "", err
-	}
-
-	// Walk up the directory tree
-	for {
-		configDir := filepath.Join(currentDir, LocalConfigDirName)
-		versionFile := filepath.Join(configDir, RuntimesFileName)
-
-		// Check if .dtvem/runtimes.json exists
-		if _, err := os.Stat(versionFile); err == nil {
-			version, err := readVersionFile(versionFile, runtimeName)
-			if err == nil && version != "" {
-				return version, nil
-			}
+// VersionEnvVar returns the name of the environment variable that overrides
+// the configured version of a runtime (e.g., DTVEM_NODE_VERSION)
+func VersionEnvVar(runtimeName string) string {
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
 		}
+		return '_'
+	}, runtimeName)
+	return "DTVEM_" + strings.ToUpper(name) + "_VERSION"
+}
 
-		// Move up one directory
-		parent := filepath.Dir(currentDir)
-
-		// Stop if we've reached the filesystem root
-		if parent == currentDir {
-			break
-		}
+// EnvVersionSource returns the version set in the runtime's override
+// environment variable, if any. The Source is the variable name.
+func EnvVersionSource(runtimeName string) (*ResolvedVersion, bool) {
+	envVar := VersionEnvVar(runtimeName)
+	version := strings.TrimSpace(os.Getenv(envVar))
+	if version == "" {
+		return nil, false
+	}
+	return &ResolvedVersion{Version: NormalizeFileVersion(runtimeName, version), Source: envVar, Env: true}, true
+}
 
-		currentDir = parent
+// LocalVersionSource finds the locally pinned version for a runtime by walking
+// up the directory tree from the current working directory
+func LocalVersionSource(runtimeName string) (*ResolvedVersion, error) {
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return nil, err
 	}
+	return findLocalVersionSource(currentDir, runtimeName)
+}
 
-	return "", fmt.Errorf("no local version file found")
+// findLocalVersion walks up the directory tree looking for a version file
+// that pins the runtime. Stops at filesystem root
+func findLocalVersion(runtimeName string) (string, error) {
+	resolved, err := LocalVersionSource(runtimeName)
+	if err != nil {
+		return "", err
+	}
+	return resolved.Version, nil
 }
 
 // readVersionFile reads a JSON config file and extracts the version for a runtime
@@ -77,6 +103,11 @@ func readVersionFile(filePath, runtimeName string) (string, error) {
 		return "", err
 	}
 
+	return parseRuntimesJSON(data, runtimeName)
+}
+
+// parseRuntimesJSON extracts the version for a runtime from runtimes.json contents
+func parseRuntimesJSON(data []byte, runtimeName string) (string, error) {
 	var config RuntimesConfig
 	if err := json.Unmarshal(data, &config); err != nil {
 		return "", fmt.Errorf("failed to parse config file: %w", err)
@@ -139,6 +170,7 @@ func FindLocalRuntimesFile() (string, error) {
 }
 
 // LocalVersion reads the local version for a runtime by walking up the directory tree
+// and consulting every registered version file
 func LocalVersion(runtimeName string) (string, error) {
 	return findLocalVersion(runtimeName)
 }
@@ -165,44 +197,34 @@ func GlobalVersion(runtimeName string) (string, error) {
 
 // SetGlobalVersion sets the global version for a runtime
 func SetGlobalVersion(runtimeName, version string) error {
-	configPath := GlobalConfigPath()
+	return setConfigVersion(GlobalConfigPath(), runtimeName, version)
+}
 
+// SetLocalVersion sets the local version for a runtime in the current directory
+func SetLocalVersion(runtimeName, version string) error {
+	return setConfigVersion(LocalConfigPath(), runtimeName, version)
+}
+
+// SetRuntimesFileVersion sets the version for a runtime in the given
+// runtimes.json file, such as a project's config found by FindLocalRuntimesFile
+func SetRuntimesFileVersion(configPath, runtimeName, version string) error {
+	return setConfigVersion(configPath, runtimeName, version)
+}
+
+// setConfigVersion updates one runtime's version in a runtimes.json file.
+// The read-modify-write holds the file's lock so concurrent processes don't
+// drop each other's changes, and the file is replaced atomically.
+func setConfigVersion(configPath, runtimeName, version string) error {
 	// Ensure config directory exists
 	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
 		return err
 	}
 
-	// Read existing config
-	config := make(RuntimesConfig)
-
-	if _, err := os.Stat(configPath); err == nil {
-		data, err := os.ReadFile(configPath)
-		if err == nil {
-			_ = json.Unmarshal(data, &config)
-		}
-	}
-
-	// Update version for runtime
-	config[runtimeName] = version
-
-	// Write back to file
-	data, err := json.MarshalIndent(config, "", "  ")
+	lock, err := AcquireLock(FileLockName(configPath))
 	if err != nil {
 		return err
 	}
-
-	return os.WriteFile(configPath, data, 0644)
-}
-
-// SetLocalVersion sets the local version for a runtime in the current directory
-func SetLocalVersion(runtimeName, version string) error {
-	configDir := LocalConfigDir()
-	configPath := LocalConfigPath()
-
-	// Ensure .dtvem directory exists
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return err
-	}
+	defer func() { _ = lock.Release() }()
 
 	// Read existing config
 	config := make(RuntimesConfig)
@@ -217,11 +239,11 @@ func SetLocalVersion(runtimeName, version string) error {
 	// Update version for runtime
 	config[runtimeName] = version
 
-	// Write to file
+	// Write back to file
 	data, err := json.MarshalIndent(config, "", "  ")
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(configPath, data, 0644)
+	return filelock.WriteFile(configPath, data, 0644)
 }
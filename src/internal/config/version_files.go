@@ -0,0 +1,242 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// VersionFile reads a pinned runtime version from a single version file format
+// such as .nvmrc or asdf's .tool-versions.
+//
+// Version files are consulted while walking up from the current directory.
+// The nearest directory containing any supported file wins. Within a single
+// directory, files are checked in registration order, which by default is:
+//
+//  1. .dtvem/runtimes.json
+//  2. Runtime-specific files (.nvmrc, .node-version, .python-version, .ruby-version)
+//  3. .tool-versions
+type VersionFile interface {
+	// Name returns the file path relative to the directory being searched
+	// (e.g., ".nvmrc" or ".dtvem/runtimes.json")
+	Name() string
+
+	// Supports reports whether the file can pin a version for the runtime
+	Supports(runtimeName string) bool
+
+	// Parse extracts the version for a runtime from the file contents.
+	// Returns an empty string when the file does not pin that runtime.
+	Parse(data []byte, runtimeName string) (string, error)
+}
+
+// ResolvedVersion describes a resolved runtime version and where it came from
+type ResolvedVersion struct {
+	Version string // Resolved version string
+	Source  string // Absolute path of the file that supplied the version, or the environment variable name
+	Local   bool   // True if the version came from a local (project) file
+	Env     bool   // True if the version came from a DTVEM_<RUNTIME>_VERSION variable
+}
+
+var (
+	versionFilesMu sync.RWMutex
+	versionFiles   = []VersionFile{
+		runtimesJSONFile{},
+		singleVersionFile{name: ".nvmrc", runtimeName: "node"},
+		singleVersionFile{name: ".node-version", runtimeName: "node"},
+		singleVersionFile{name: ".python-version", runtimeName: "python"},
+		singleVersionFile{name: ".ruby-version", runtimeName: "ruby"},
+		toolVersionsFile{},
+	}
+)
+
+// RegisterVersionFile appends a version file format to the resolver chain.
+// Formats registered later have lower precedence within the same directory.
+func RegisterVersionFile(vf VersionFile) {
+	versionFilesMu.Lock()
+	defer versionFilesMu.Unlock()
+	versionFiles = append(versionFiles, vf)
+}
+
+// VersionFiles returns the registered version file formats in precedence order
+func VersionFiles() []VersionFile {
+	versionFilesMu.RLock()
+	defer versionFilesMu.RUnlock()
+	result := make([]VersionFile, len(versionFiles))
+	copy(result, versionFiles)
+	return result
+}
+
+// VersionFileNames returns the names of the version files that can pin a runtime,
+// in precedence order
+func VersionFileNames(runtimeName string) []string {
+	var names []string
+	for _, vf := range VersionFiles() {
+		if vf.Supports(runtimeName) {
+			names = append(names, vf.Name())
+		}
+	}
+	return names
+}
+
+// findLocalVersionSource walks up the directory tree from startDir looking for
+// any version file that pins the runtime. Stops at filesystem root.
+func findLocalVersionSource(startDir, runtimeName string) (*ResolvedVersion, error) {
+	return FindVersionFileSource(startDir, runtimeName, VersionFiles())
+}
+
+// FindVersionFileSource walks up the directory tree from startDir looking for
+// any of the given version files that pins the runtime, such as another
+// version manager's files that are not in the resolver chain. Within a
+// directory, files are checked in the order given.
+func FindVersionFileSource(startDir, runtimeName string, files []VersionFile) (*ResolvedVersion, error) {
+	candidates := make([]VersionFile, 0)
+	for _, vf := range files {
+		if vf.Supports(runtimeName) {
+			candidates = append(candidates, vf)
+		}
+	}
+
+	currentDir := startDir
+	for {
+		for _, vf := range candidates {
+			filePath := filepath.Join(currentDir, vf.Name())
+			data, err := os.ReadFile(filePath)
+			if err != nil {
+				continue
+			}
+
+			version, err := vf.Parse(data, runtimeName)
+			if err == nil && version != "" {
+				return &ResolvedVersion{Version: version, Source: filePath, Local: true}, nil
+			}
+		}
+
+		// Move up one directory
+		parent := filepath.Dir(currentDir)
+
+		// Stop if we've reached the filesystem root
+		if parent == currentDir {
+			break
+		}
+
+		currentDir = parent
+	}
+
+	return nil, fmt.Errorf("no local version file found")
+}
+
+// runtimesJSONFile reads dtvem's own .dtvem/runtimes.json
+type runtimesJSONFile struct{}
+
+func (runtimesJSONFile) Name() string {
+	return filepath.Join(LocalConfigDirName, RuntimesFileName)
+}
+
+func (runtimesJSONFile) Supports(string) bool {
+	return true
+}
+
+func (runtimesJSONFile) Parse(data []byte, runtimeName string) (string, error) {
+	return parseRuntimesJSON(data, runtimeName)
+}
+
+// singleVersionFile reads files that contain a single version for one runtime,
+// such as .nvmrc, .node-version, .python-version and .ruby-version
+type singleVersionFile struct {
+	name        string
+	runtimeName string
+}
+
+func (f singleVersionFile) Name() string {
+	return f.name
+}
+
+func (f singleVersionFile) Supports(runtimeName string) bool {
+	return runtimeName == f.runtimeName
+}
+
+// Parse returns the first non-empty, non-comment line. pyenv allows several
+// versions in .python-version; the first one is the active interpreter.
+func (f singleVersionFile) Parse(data []byte, runtimeName string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := stripComment(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		return NormalizeFileVersion(runtimeName, fields[0]), nil
+	}
+	return "", scanner.Err()
+}
+
+// toolVersionsFile reads asdf's .tool-versions format:
+//
+//	nodejs 20.11.0
+//	python 3.12.1 3.11.7
+type toolVersionsFile struct{}
+
+// ToolVersionsFile returns the reader for asdf's .tool-versions format
+func ToolVersionsFile() VersionFile {
+	return toolVersionsFile{}
+}
+
+// toolVersionsPluginNames maps asdf plugin names to dtvem runtime names
+var toolVersionsPluginNames = map[string]string{
+	"nodejs": "node",
+	"node":   "node",
+	"python": "python",
+	"ruby":   "ruby",
+}
+
+func (toolVersionsFile) Name() string {
+	return ".tool-versions"
+}
+
+func (toolVersionsFile) Supports(runtimeName string) bool {
+	for _, name := range toolVersionsPluginNames {
+		if name == runtimeName {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse returns the first version listed for the runtime's plugin
+func (toolVersionsFile) Parse(data []byte, runtimeName string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(stripComment(scanner.Text()))
+		if len(fields) < 2 {
+			continue
+		}
+		if toolVersionsPluginNames[fields[0]] == runtimeName {
+			return NormalizeFileVersion(runtimeName, fields[1]), nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+// stripComment removes a trailing # comment and surrounding whitespace
+func stripComment(line string) string {
+	if idx := strings.Index(line, "#"); idx != -1 {
+		line = line[:idx]
+	}
+	return strings.TrimSpace(line)
+}
+
+// NormalizeFileVersion removes tool-specific decoration from a version string,
+// such as the "v" prefix used by nvm or the "ruby-" prefix used by rbenv/chruby
+func NormalizeFileVersion(runtimeName, version string) string {
+	if runtimeName == "ruby" {
+		version = strings.TrimPrefix(version, "ruby-")
+	}
+	if len(version) > 1 && (version[0] == 'v' || version[0] == 'V') && version[1] >= '0' && version[1] <= '9' {
+		version = version[1:]
+	}
+	return version
+}
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestSingleVersionFile_Parse(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        singleVersionFile
+		content     string
+		runtimeName string
+		expected    string
+	}{
+		{"nvmrc plain", singleVersionFile{".nvmrc", "node"}, "20.11.0\n", "node", "20.11.0"},
+		{"nvmrc v prefix", singleVersionFile{".nvmrc", "node"}, "v18.19.0\n", "node", "18.19.0"},
+		{"nvmrc alias kept", singleVersionFile{".nvmrc", "node"}, "lts/iron\n", "node", "lts/iron"},
+		{"nvmrc with comment", singleVersionFile{".nvmrc", "node"}, "# pinned\n20 # major only\n", "node", "20"},
+		{"python-version multiple", singleVersionFile{".python-version", "python"}, "3.12.1\n3.11.7\n", "python", "3.12.1"},
+		{"ruby-version prefix", singleVersionFile{".ruby-version", "ruby"}, "ruby-3.2.2\n", "ruby", "3.2.2"},
+		{"empty file", singleVersionFile{".node-version", "node"}, "\n\n", "node", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := tt.file.Parse([]byte(tt.content), tt.runtimeName)
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if version != tt.expected {
+				t.Errorf("Parse() = %q, want %q", version, tt.expected)
+			}
+		})
+	}
+}
+
+func TestToolVersionsFile_Parse(t *testing.T) {
+	content := `# asdf versions
+nodejs 20.11.0
+python 3.12.1 3.11.7
+golang 1.22.0
+`
+	tests := []struct {
+		runtimeName string
+		expected    string
+	}{
+		{"node", "20.11.0"},
+		{"python", "3.12.1"},
+		{"ruby", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.runtimeName, func(t *testing.T) {
+			version, err := toolVersionsFile{}.Parse([]byte(content), tt.runtimeName)
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if version != tt.expected {
+				t.Errorf("Parse() = %q, want %q", version, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindLocalVersionSource_Precedence(t *testing.T) {
+	t.Run("runtimes.json wins over nvmrc in same directory", func(t *testing.T) {
+		root := t.TempDir()
+		writeTestFile(t, filepath.Join(root, LocalConfigDirName, RuntimesFileName), `{"node": "22.0.0"}`)
+		writeTestFile(t, filepath.Join(root, ".nvmrc"), "18.0.0")
+
+		resolved, err := findLocalVersionSource(root, "node")
+		if err != nil {
+			t.Fatalf("findLocalVersionSource() error: %v", err)
+		}
+		if resolved.Version != "22.0.0" {
+			t.Errorf("Version = %q, want %q", resolved.Version, "22.0.0")
+		}
+		if filepath.Base(resolved.Source) != RuntimesFileName {
+			t.Errorf("Source = %q, want runtimes.json", resolved.Source)
+		}
+	})
+
+	t.Run("runtime-specific file wins over tool-versions", func(t *testing.T) {
+		root := t.TempDir()
+		writeTestFile(t, filepath.Join(root, ".tool-versions"), "python 3.10.0\n")
+		writeTestFile(t, filepath.Join(root, ".python-version"), "3.12.1\n")
+
+		resolved, err := findLocalVersionSource(root, "python")
+		if err != nil {
+			t.Fatalf("findLocalVersionSource() error: %v", err)
+		}
+		if resolved.Version != "3.12.1" {
+			t.Errorf("Version = %q, want %q", resolved.Version, "3.12.1")
+		}
+	})
+
+	t.Run("falls through when runtimes.json lacks the runtime", func(t *testing.T) {
+		root := t.TempDir()
+		writeTestFile(t, filepath.Join(root, LocalConfigDirName, RuntimesFileName), `{"python": "3.12.1"}`)
+		writeTestFile(t, filepath.Join(root, ".ruby-version"), "3.3.0\n")
+
+		resolved, err := findLocalVersionSource(root, "ruby")
+		if err != nil {
+			t.Fatalf("findLocalVersionSource() error: %v", err)
+		}
+		if resolved.Version != "3.3.0" {
+			t.Errorf("Version = %q, want %q", resolved.Version, "3.3.0")
+		}
+	})
+
+	t.Run("nearest directory wins over file type", func(t *testing.T) {
+		root := t.TempDir()
+		child := filepath.Join(root, "packages", "web")
+		writeTestFile(t, filepath.Join(root, LocalConfigDirName, RuntimesFileName), `{"node": "22.0.0"}`)
+		writeTestFile(t, filepath.Join(child, ".nvmrc"), "v20.11.0\n")
+
+		resolved, err := findLocalVersionSource(child, "node")
+		if err != nil {
+			t.Fatalf("findLocalVersionSource() error: %v", err)
+		}
+		if resolved.Version != "20.11.0" {
+			t.Errorf("Version = %q, want %q", resolved.Version, "20.11.0")
+		}
+		if resolved.Source != filepath.Join(child, ".nvmrc") {
+			t.Errorf("Source = %q, want %q", resolved.Source, filepath.Join(child, ".nvmrc"))
+		}
+		if !resolved.Local {
+			t.Error("Local = false, want true")
+		}
+	})
+
+	t.Run("no version file", func(t *testing.T) {
+		root := t.TempDir()
+		if _, err := findLocalVersionSource(root, "node"); err == nil {
+			t.Error("findLocalVersionSource() expected error when no file exists")
+		}
+	})
+}
+
+func TestVersionFileNames(t *testing.T) {
+	names := VersionFileNames("node")
+	expected := []string{
+		filepath.Join(LocalConfigDirName, RuntimesFileName),
+		".nvmrc",
+		".node-version",
+		".tool-versions",
+	}
+	if len(names) != len(expected) {
+		t.Fatalf("VersionFileNames(node) = %v, want %v", names, expected)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("VersionFileNames(node)[%d] = %q, want %q", i, names[i], expected[i])
+		}
+	}
+}
@@ -2,8 +2,10 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 )
 
@@ -519,3 +521,86 @@ func TestSetLocalVersion_CreatesDirectoryAndFile(t *testing.T) {
 		t.Errorf("Config python version = %q, want %q", config["python"], "3.11.0")
 	}
 }
+
+func TestSetGlobalVersion_ConcurrentWritersKeepAllRuntimes(t *testing.T) {
+	t.Setenv("DTVEM_ROOT", t.TempDir())
+	ResetPathsCache()
+	t.Cleanup(ResetPathsCache)
+
+	const writers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := SetGlobalVersion(fmt.Sprintf("runtime%d", i), "1.0.0"); err != nil {
+				t.Errorf("SetGlobalVersion() error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	data, err := os.ReadFile(GlobalConfigPath())
+	if err != nil {
+		t.Fatalf("Failed to read config file: %v", err)
+	}
+	var config RuntimesConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	if len(config) != writers {
+		t.Errorf("config has %d runtimes after %d concurrent writes, want all of them", len(config), writers)
+	}
+}
+
+func TestVersionEnvVar(t *testing.T) {
+	tests := map[string]string{
+		"node":    "DTVEM_NODE_VERSION",
+		"python":  "DTVEM_PYTHON_VERSION",
+		"go-lang": "DTVEM_GO_LANG_VERSION",
+	}
+	for runtimeName, expected := range tests {
+		if got := VersionEnvVar(runtimeName); got != expected {
+			t.Errorf("VersionEnvVar(%q) = %q, want %q", runtimeName, got, expected)
+		}
+	}
+}
+
+func TestResolveVersionSource_EnvOverride(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("DTVEM_ROOT", root)
+	ResetPathsCache()
+	t.Cleanup(ResetPathsCache)
+
+	project := t.TempDir()
+	writeTestFile(t, filepath.Join(project, ".nvmrc"), "20.11.0\n")
+	originalDir, _ := os.Getwd()
+	if err := os.Chdir(project); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(originalDir) })
+
+	if err := SetGlobalVersion("node", "22.0.0"); err != nil {
+		t.Fatal(err)
+	}
+
+	resolved, err := ResolveVersionSource("node")
+	if err != nil || resolved.Version != "20.11.0" {
+		t.Fatalf("ResolveVersionSource() = %+v, %v; want the local version without an override", resolved, err)
+	}
+
+	t.Setenv("DTVEM_NODE_VERSION", "v18")
+	resolved, err = ResolveVersionSource("node")
+	if err != nil {
+		t.Fatalf("ResolveVersionSource() error: %v", err)
+	}
+	if resolved.Version != "18" || !resolved.Env || resolved.Source != "DTVEM_NODE_VERSION" {
+		t.Errorf("ResolveVersionSource() = %+v, want 18 from DTVEM_NODE_VERSION", resolved)
+	}
+
+	// Runtimes without an override still resolve from configuration
+	t.Setenv("DTVEM_NODE_VERSION", "  ")
+	if version, err := ResolveVersion("node"); err != nil || version != "20.11.0" {
+		t.Errorf("ResolveVersion() with a blank override = %q, %v; want 20.11.0", version, err)
+	}
+}
@@ -20,6 +20,7 @@ const (
 	ShellBash = "bash"
 	ShellZsh  = "zsh"
 	ShellFish = "fish"
+	ShellPwsh = "pwsh"
 )
 
 // User responses
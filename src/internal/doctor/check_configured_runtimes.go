@@ -75,6 +75,19 @@ func (c configuredRuntimesCheck) Run() Finding {
 			continue
 		}
 
+		// Ranges and aliases are satisfied by any installed match
+		if runtime.IsVersionSpec(version) {
+			if _, err := runtime.ResolveInstalledVersion(p, version); err != nil {
+				problems = append(problems, problem{
+					runtimeName: name,
+					version:     version,
+					displayName: p.DisplayName(),
+					detail:      fmt.Sprintf("no installed version satisfies %s (run `dtvem install %s %q`)", version, name, version),
+				})
+			}
+			continue
+		}
+
 		installed, err := p.IsInstalled(version)
 		if err != nil {
 			problems = append(problems, problem{
@@ -131,7 +131,7 @@ func countInstalledRuntimeVersions(versionsDir string) (int, error) {
 			continue
 		}
 		for _, v := range versionEntries {
-			if v.IsDir() {
+			if v.IsDir() && config.IsCommittedInstall(versionsDir, e.Name(), v.Name()) {
 				total++
 			}
 		}
@@ -0,0 +1,110 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+)
+
+// incompleteInstallGracePeriod keeps the check away from installs that may
+// still be running in another terminal: anything modified more recently is
+// left alone.
+const incompleteInstallGracePeriod = 10 * time.Minute
+
+// incompleteInstallsCheck looks for directories under versions/ that are
+// not committed installs: staging directories left behind when an install
+// was killed during extraction, and version directories whose install
+// failed or was interrupted before its marker was written (for example
+// while bootstrapping pip). dtvem already ignores them, but they waste disk
+// space and a version directory left in place blocks nothing but confuses
+// anyone browsing ~/.dtvem.
+//
+// The fix removes them; the affected versions can simply be reinstalled.
+type incompleteInstallsCheck struct {
+	// Injected so tests can drive the check against a synthetic layout
+	// and a fixed clock.
+	versionsDir func() string
+	now         func() time.Time
+}
+
+func newIncompleteInstallsCheck() *incompleteInstallsCheck {
+	return &incompleteInstallsCheck{
+		versionsDir: func() string { return config.DefaultPaths().Versions },
+		now:         time.Now,
+	}
+}
+
+func (incompleteInstallsCheck) Name() string { return "incomplete-installs" }
+
+func (c incompleteInstallsCheck) Run() Finding {
+	incomplete, err := c.find()
+	if err != nil {
+		return Finding{
+			Severity:   SeverityWarning,
+			Title:      "Could not inspect installed runtime versions",
+			Details:    []Detail{{Key: "Error", Value: err.Error()}},
+			Resolution: "Check that " + c.versionsDir() + " is readable.",
+		}
+	}
+	if len(incomplete) == 0 {
+		return Finding{OK: true, Title: "No incomplete installs"}
+	}
+
+	var details []Detail
+	var versions []string
+	for _, inst := range incomplete {
+		key := "Interrupted"
+		if inst.Staging {
+			key = "Staging"
+		} else {
+			versions = append(versions, inst.Runtime+" "+inst.Name)
+		}
+		details = append(details, Detail{Key: key, Value: inst.Path})
+	}
+
+	resolution := "Remove the incomplete install directories."
+	if len(versions) > 0 {
+		resolution += "\nReinstall afterwards with 'dtvem install': " + strings.Join(versions, ", ")
+	}
+
+	return Finding{
+		Severity:   SeverityWarning,
+		Title:      fmt.Sprintf("%d incomplete install%s found", len(incomplete), plural(len(incomplete), "", "s")),
+		Details:    details,
+		Resolution: resolution,
+		Fix: func() error {
+			for _, inst := range incomplete {
+				if err := os.RemoveAll(inst.Path); err != nil {
+					return fmt.Errorf("failed to remove %s: %w", inst.Path, err)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// find returns incomplete installs old enough not to be in progress
+func (c incompleteInstallsCheck) find() ([]config.IncompleteInstall, error) {
+	all, err := config.IncompleteInstalls(c.versionsDir())
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := c.now().Add(-incompleteInstallGracePeriod)
+	var stale []config.IncompleteInstall
+	for _, inst := range all {
+		info, err := os.Stat(inst.Path)
+		if err != nil || info.ModTime().After(cutoff) {
+			continue
+		}
+		stale = append(stale, inst)
+	}
+	return stale, nil
+}
+
+func init() {
+	Register(newIncompleteInstallsCheck())
+}
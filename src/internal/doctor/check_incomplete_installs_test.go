@@ -0,0 +1,113 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+)
+
+// incompleteInstallsFixture builds a versions directory with markers enabled
+// and returns a check reading it with a clock an hour ahead of the files
+func incompleteInstallsFixture(t *testing.T) (string, *incompleteInstallsCheck) {
+	t.Helper()
+	versions := t.TempDir()
+	if err := os.WriteFile(filepath.Join(versions, ".install-markers"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return versions, &incompleteInstallsCheck{
+		versionsDir: func() string { return versions },
+		now:         func() time.Time { return time.Now().Add(time.Hour) },
+	}
+}
+
+func mkdirVersion(t *testing.T, versions, runtimeName, name string, marked bool) string {
+	t.Helper()
+	dir := filepath.Join(versions, runtimeName, name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if marked {
+		if err := os.WriteFile(filepath.Join(dir, config.InstallMarkerFileName), []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestIncompleteInstallsCheck_NoneIsOK(t *testing.T) {
+	versions, check := incompleteInstallsFixture(t)
+	mkdirVersion(t, versions, "node", "22.0.0", true)
+
+	if got := check.Run(); !got.OK {
+		t.Errorf("expected OK with only committed installs, got %#v", got)
+	}
+}
+
+func TestIncompleteInstallsCheck_MissingVersionsDirIsOK(t *testing.T) {
+	check := &incompleteInstallsCheck{
+		versionsDir: func() string { return filepath.Join(t.TempDir(), "missing") },
+		now:         time.Now,
+	}
+	if got := check.Run(); !got.OK {
+		t.Errorf("expected OK without a versions directory, got %#v", got)
+	}
+}
+
+func TestIncompleteInstallsCheck_DetectsAndFixes(t *testing.T) {
+	versions, check := incompleteInstallsFixture(t)
+	committed := mkdirVersion(t, versions, "node", "22.0.0", true)
+	interrupted := mkdirVersion(t, versions, "python", "3.12.0", false)
+	staging := mkdirVersion(t, versions, "ruby", config.StagingDirPrefix+"3.3.0-1234", false)
+
+	got := check.Run()
+	if got.OK {
+		t.Fatal("expected non-OK finding with incomplete installs")
+	}
+	if got.Severity != SeverityWarning {
+		t.Errorf("expected warning severity, got %s", got.Severity)
+	}
+	if len(got.Details) != 2 {
+		t.Fatalf("expected 2 details, got %#v", got.Details)
+	}
+	if got.Fix == nil {
+		t.Fatal("expected a Fix")
+	}
+
+	if err := got.Fix(); err != nil {
+		t.Fatalf("Fix failed: %v", err)
+	}
+	for _, dir := range []string{interrupted, staging} {
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed", dir)
+		}
+	}
+	if _, err := os.Stat(committed); err != nil {
+		t.Errorf("committed install was removed: %v", err)
+	}
+}
+
+func TestIncompleteInstallsCheck_SkipsRecentEntries(t *testing.T) {
+	versions, check := incompleteInstallsFixture(t)
+	mkdirVersion(t, versions, "node", config.StagingDirPrefix+"22.0.0-1", false)
+	check.now = time.Now
+
+	if got := check.Run(); !got.OK {
+		t.Errorf("expected an install in progress to be ignored, got %#v", got)
+	}
+}
+
+func TestIncompleteInstallsCheck_LegacyLayoutIsOK(t *testing.T) {
+	versions := t.TempDir()
+	mkdirVersion(t, versions, "node", "18.0.0", false)
+	check := &incompleteInstallsCheck{
+		versionsDir: func() string { return versions },
+		now:         func() time.Time { return time.Now().Add(time.Hour) },
+	}
+
+	if got := check.Run(); !got.OK {
+		t.Errorf("expected installs from before markers to count as complete, got %#v", got)
+	}
+}
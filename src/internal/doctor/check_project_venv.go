@@ -0,0 +1,91 @@
+package doctor
+
+import (
+	"errors"
+	"os"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/venv"
+)
+
+// projectVenvCheck reports a project venv (declared in .dtvem/venv.json)
+// that hasn't been created, or was built with another Python version than
+// the project now pins. Shims fall back to the pinned Python in both cases,
+// so the project's packages silently disappear until the venv is rebuilt.
+//
+// The fix rebuilds the venv from the pinned Python and reinstalls its
+// requirements files, the same as `dtvem venv sync`.
+type projectVenvCheck struct {
+	// findProject and resolvePython are injected so tests can drive the
+	// check without a real project or Python installation
+	findProject   func() (*venv.Project, error)
+	resolvePython func() (version, pythonPath string, err error)
+}
+
+func newProjectVenvCheck() *projectVenvCheck {
+	return &projectVenvCheck{
+		findProject: func() (*venv.Project, error) {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return nil, err
+			}
+			return venv.Find(cwd)
+		},
+		resolvePython: venv.ResolvePython,
+	}
+}
+
+func (projectVenvCheck) Name() string { return "project-venv" }
+
+func (c projectVenvCheck) Run() Finding {
+	project, err := c.findProject()
+	if err != nil {
+		if errors.Is(err, venv.ErrNotDeclared) {
+			return Finding{OK: true, Title: "No project venv declared"}
+		}
+		return Finding{
+			Severity:   SeverityWarning,
+			Title:      "Could not read the project venv declaration",
+			Details:    []Detail{{Key: "Error", Value: err.Error()}},
+			Resolution: "Check that .dtvem/" + venv.ConfigFileName + " is valid JSON and readable.",
+		}
+	}
+
+	version, pythonPath, err := c.resolvePython()
+	if err != nil {
+		return Finding{
+			Severity:   SeverityWarning,
+			Title:      "Project venv has no Python to build from",
+			Details:    []Detail{{Key: "Venv", Value: project.Dir()}, {Key: "Error", Value: err.Error()}},
+			Resolution: "Install the project's Python version, or pin an installed one with `dtvem local python <version>`.",
+		}
+	}
+
+	rebuild := func() error {
+		return project.Build(pythonPath, os.Stdout)
+	}
+
+	status := project.Check(version)
+	switch status.State {
+	case venv.Missing:
+		return Finding{
+			Severity:   SeverityWarning,
+			Title:      "Project venv has not been created",
+			Details:    []Detail{{Key: "Venv", Value: project.Dir()}, {Key: "Python", Value: version}},
+			Resolution: "Run `dtvem venv create` to create it.",
+			Fix:        rebuild,
+		}
+	case venv.Stale:
+		return Finding{
+			Severity:   SeverityWarning,
+			Title:      "Project venv needs a rebuild",
+			Details:    []Detail{{Key: "Venv", Value: project.Dir()}, {Key: "Reason", Value: status.Reason}},
+			Resolution: "Run `dtvem venv sync` to rebuild it from Python " + version + ".",
+			Fix:        rebuild,
+		}
+	}
+	return Finding{OK: true, Title: "Project venv matches the project's Python version"}
+}
+
+func init() {
+	Register(newProjectVenvCheck())
+}
@@ -0,0 +1,92 @@
+package doctor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/venv"
+)
+
+// projectVenvFor returns a check against a project in a temp directory
+// whose venv, when builtVersion is set, was built with that version
+func projectVenvFor(t *testing.T, builtVersion, pinnedVersion string) *projectVenvCheck {
+	t.Helper()
+	project := &venv.Project{Root: t.TempDir()}
+	if builtVersion != "" {
+		if err := os.MkdirAll(filepath.Dir(project.Interpreter()), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(project.Interpreter(), nil, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(project.Dir(), "pyvenv.cfg"), []byte("version = "+builtVersion+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &projectVenvCheck{
+		findProject: func() (*venv.Project, error) { return project, nil },
+		resolvePython: func() (string, string, error) {
+			return pinnedVersion, "/opt/python/bin/python", nil
+		},
+	}
+}
+
+func TestProjectVenvCheck_NotDeclared(t *testing.T) {
+	c := &projectVenvCheck{
+		findProject: func() (*venv.Project, error) { return nil, venv.ErrNotDeclared },
+	}
+	if got := c.Run(); !got.OK {
+		t.Errorf("expected OK without a declared venv, got %#v", got)
+	}
+}
+
+func TestProjectVenvCheck_NoPython(t *testing.T) {
+	c := projectVenvFor(t, "", "")
+	c.resolvePython = func() (string, string, error) { return "", "", errors.New("no version configured for python") }
+
+	got := c.Run()
+	if got.OK || got.Severity != SeverityWarning || got.Fixable() {
+		t.Errorf("expected an unfixable warning without a Python, got %#v", got)
+	}
+}
+
+func TestProjectVenvCheck_States(t *testing.T) {
+	tests := []struct {
+		name    string
+		built   string
+		pinned  string
+		wantOK  bool
+		wantFix bool
+	}{
+		{name: "ready", built: "3.12.1", pinned: "3.12.1", wantOK: true},
+		{name: "missing", built: "", pinned: "3.12.1", wantFix: true},
+		{name: "stale", built: "3.12.1", pinned: "3.13.0", wantFix: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := projectVenvFor(t, tt.built, tt.pinned).Run()
+			if got.OK != tt.wantOK {
+				t.Errorf("OK = %v, want %v (%#v)", got.OK, tt.wantOK, got)
+			}
+			if got.Fixable() != tt.wantFix {
+				t.Errorf("Fixable() = %v, want %v", got.Fixable(), tt.wantFix)
+			}
+		})
+	}
+}
+
+func TestProjectVenvCheck_Registered(t *testing.T) {
+	found := false
+	for _, c := range All() {
+		if c.Name() == "project-venv" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("project-venv check is not in the default registry")
+	}
+}
@@ -176,7 +176,8 @@ func listInstalledVersions(versionsDir string) ([]installedVersion, error) {
 			continue
 		}
 		for _, v := range versionEntries {
-			if !v.IsDir() {
+			// Incomplete installs are reported by the incomplete-installs check
+			if !v.IsDir() || !config.IsCommittedInstall(versionsDir, r.Name(), v.Name()) {
 				continue
 			}
 			out = append(out, installedVersion{runtimeName: r.Name(), version: v.Name()})
@@ -113,12 +113,11 @@ func (c shimMapCheck) Run() Finding {
 			}
 
 			// Rehash rebuilds the cache from the installed runtimes
-			// on disk, but it doesn't delete orphan shim files (shim
-			// binaries in shims/ whose name isn't provided by any
-			// installed runtime version's executable list). If we
-			// still see drift afterward, surface that distinctly so
-			// the user understands the next step is to delete the
-			// stray shim file by hand rather than re-running fix.
+			// on disk and removes orphan shim files. If we still see
+			// drift afterward (e.g., a shim file that couldn't be
+			// removed), surface that distinctly so the user
+			// understands the next step is manual rather than
+			// re-running fix.
 			shim.ResetShimMapCache()
 			diskShimsAfter, listErr := listShimNamesOnDisk(c.shimsDir())
 			if listErr != nil {
@@ -141,9 +140,7 @@ func (c shimMapCheck) Run() Finding {
 			}
 
 			// Construct a focused message that names the still-broken
-			// shims and the manual step needed — typically `rm` on
-			// the orphan file, since reshim won't delete shims whose
-			// underlying runtime executable isn't installed anywhere.
+			// shims and the manual step needed.
 			var parts []string
 			if len(missingInCache) > 0 {
 				parts = append(parts, fmt.Sprintf("orphan shim file(s) %s — delete them from %s manually",
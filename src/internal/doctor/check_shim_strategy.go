@@ -0,0 +1,155 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"sort"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/shim"
+)
+
+// shimStrategyCheck verifies that every shim on disk matches the shim
+// strategy in effect (see config.ShimStrategy):
+//
+//   - symlink: a symlink that resolves to the dtvem-shim binary
+//   - hardlink: a hard link to dtvem-shim, or a copy of it where linking
+//     wasn't possible (reshim falls back to copying, e.g. across
+//     filesystems)
+//   - copy: a regular file the same size as dtvem-shim
+//
+// Mismatches show up after changing the strategy without running reshim,
+// after moving dtvem (dangling symlinks), or after upgrading it, which
+// replaces dtvem-shim and leaves copies of the old binary behind. The fix
+// is `dtvem reshim`, which lays every shim down again.
+type shimStrategyCheck struct {
+	shimsDir   func() string
+	shimSource func() (string, error)
+	strategy   func() config.ShimStrategy
+	newManager func() (rehasher, error)
+}
+
+func newShimStrategyCheck() *shimStrategyCheck {
+	return &shimStrategyCheck{
+		shimsDir:   func() string { return config.DefaultPaths().Shims },
+		shimSource: shim.FindShimExecutable,
+		strategy:   shim.Strategy,
+		newManager: func() (rehasher, error) {
+			m, err := shim.NewManager()
+			if err != nil {
+				return nil, err
+			}
+			return m, nil
+		},
+	}
+}
+
+func (shimStrategyCheck) Name() string { return "shim-strategy" }
+
+func (c shimStrategyCheck) Run() Finding {
+	strategy := c.strategy()
+
+	// A missing dtvem-shim is reported by the shim-binary-present check
+	source, err := c.shimSource()
+	if err != nil {
+		return Finding{OK: true, Title: "Shim strategy not checked (dtvem-shim binary not found)"}
+	}
+	sourceInfo, err := os.Stat(source)
+	if err != nil {
+		return Finding{OK: true, Title: "Shim strategy not checked (dtvem-shim binary not found)"}
+	}
+
+	names, err := listShimNamesOnDisk(c.shimsDir())
+	if err != nil {
+		return Finding{
+			Severity:   SeverityWarning,
+			Title:      "Could not list shim files on disk",
+			Details:    []Detail{{Key: "Error", Value: err.Error()}},
+			Resolution: "Check that " + c.shimsDir() + " is readable.",
+		}
+	}
+
+	byProblem := make(map[string][]string)
+	mismatched := 0
+	for _, name := range names {
+		shimPath := filepath.Join(c.shimsDir(), name)
+		if goruntime.GOOS == constants.OSWindows {
+			shimPath += constants.ExtExe
+		}
+		if problem := shimStrategyProblem(shimPath, sourceInfo, strategy); problem != "" {
+			byProblem[problem] = append(byProblem[problem], name)
+			mismatched++
+		}
+	}
+
+	if mismatched == 0 {
+		return Finding{OK: true, Title: fmt.Sprintf("Shims match the %s strategy", strategy)}
+	}
+
+	problems := make([]string, 0, len(byProblem))
+	for problem := range byProblem {
+		problems = append(problems, problem)
+	}
+	sort.Strings(problems)
+
+	details := []Detail{{Key: "Strategy", Value: string(strategy)}, {Key: "dtvem-shim", Value: source}}
+	for _, problem := range problems {
+		details = append(details, Detail{Key: problem, Value: summarizeNames(byProblem[problem])})
+	}
+
+	return Finding{
+		Severity:   SeverityWarning,
+		Title:      fmt.Sprintf("%d shim%s don't match the %s strategy", mismatched, plural(mismatched, "", "s"), strategy),
+		Details:    details,
+		Resolution: "Run `dtvem reshim` to lay the shims down again.",
+		Fix: func() error {
+			m, err := c.newManager()
+			if err != nil {
+				return fmt.Errorf("could not create shim manager: %w", err)
+			}
+			if _, err := m.Rehash(); err != nil {
+				return fmt.Errorf("reshim failed: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+// shimStrategyProblem describes how the shim at shimPath deviates from
+// strategy, or returns "" if it matches
+func shimStrategyProblem(shimPath string, source os.FileInfo, strategy config.ShimStrategy) string {
+	info, err := os.Lstat(shimPath)
+	if err != nil {
+		return "Unreadable"
+	}
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Stat(shimPath)
+		switch {
+		case err != nil:
+			return "Dangling symlinks"
+		case !os.SameFile(target, source):
+			return "Symlinks to another binary"
+		case strategy != config.ShimStrategySymlink:
+			return "Symlinks instead of " + string(strategy)
+		}
+		return ""
+	}
+
+	if strategy == config.ShimStrategySymlink {
+		return "Not symlinks"
+	}
+	// Hard links and copies both run dtvem-shim's code; a copy of a
+	// different size is left over from an older dtvem-shim
+	if !os.SameFile(info, source) && info.Size() != source.Size() {
+		return "Outdated copies"
+	}
+	return ""
+}
+
+func init() {
+	Register(newShimStrategyCheck())
+}
@@ -0,0 +1,154 @@
+package doctor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
+)
+
+// shimStrategyLayout creates a dtvem-shim binary and an empty shims
+// directory, returning both paths
+func shimStrategyLayout(t *testing.T) (source, shimsDir string) {
+	t.Helper()
+	root := t.TempDir()
+	source = filepath.Join(root, "dtvem-shim")
+	if err := os.WriteFile(source, []byte("shim binary v2"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	shimsDir = filepath.Join(root, "shims")
+	if err := os.MkdirAll(shimsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	return source, shimsDir
+}
+
+func newShimStrategyCheckWith(source, shimsDir string, strategy config.ShimStrategy, rh *fakeRehasher) *shimStrategyCheck {
+	c := newShimStrategyCheck()
+	c.shimsDir = func() string { return shimsDir }
+	c.shimSource = func() (string, error) { return source, nil }
+	c.strategy = func() config.ShimStrategy { return strategy }
+	c.newManager = func() (rehasher, error) {
+		if rh == nil {
+			return nil, errors.New("no rehasher provided in test")
+		}
+		return rh, nil
+	}
+	return c
+}
+
+func skipLinksOnWindows(t *testing.T) {
+	t.Helper()
+	if goruntime.GOOS == constants.OSWindows {
+		t.Skip("shim link strategies are Unix-only")
+	}
+}
+
+func TestShimStrategyCheck_Copies(t *testing.T) {
+	source, shimsDir := shimStrategyLayout(t)
+	if err := os.WriteFile(filepath.Join(shimsDir, shimFileName("node")), []byte("shim binary v2"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	got := newShimStrategyCheckWith(source, shimsDir, config.ShimStrategyCopy, nil).Run()
+	if !got.OK {
+		t.Fatalf("current copies should pass, got %+v", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(shimsDir, shimFileName("npm")), []byte("shim v1"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	rh := &fakeRehasher{}
+	got = newShimStrategyCheckWith(source, shimsDir, config.ShimStrategyCopy, rh).Run()
+	if got.OK || got.Severity != SeverityWarning {
+		t.Fatalf("outdated copy should warn, got %+v", got)
+	}
+	if !hasDetail(got, "Outdated copies", "npm") {
+		t.Errorf("details should name the outdated copy: %+v", got.Details)
+	}
+	if got.Fix == nil {
+		t.Fatal("finding should be fixable")
+	}
+	if err := got.Fix(); err != nil || !rh.called {
+		t.Errorf("Fix() = %v, rehash called = %v; want a reshim", err, rh.called)
+	}
+}
+
+func TestShimStrategyCheck_Symlinks(t *testing.T) {
+	skipLinksOnWindows(t)
+	source, shimsDir := shimStrategyLayout(t)
+	if err := os.Symlink(source, filepath.Join(shimsDir, "node")); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := newShimStrategyCheckWith(source, shimsDir, config.ShimStrategySymlink, nil).Run(); !got.OK {
+		t.Fatalf("symlinked shims should pass, got %+v", got)
+	}
+
+	// A copy, and a link left behind after dtvem moved
+	if err := os.WriteFile(filepath.Join(shimsDir, "npm"), []byte("shim binary v2"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(filepath.Dir(source), "old", "dtvem-shim"), filepath.Join(shimsDir, "npx")); err != nil {
+		t.Fatal(err)
+	}
+
+	got := newShimStrategyCheckWith(source, shimsDir, config.ShimStrategySymlink, nil).Run()
+	if got.OK {
+		t.Fatal("mismatched shims should be reported")
+	}
+	if !strings.HasPrefix(got.Title, "2 shims") {
+		t.Errorf("Title = %q, want 2 shims reported", got.Title)
+	}
+	if !hasDetail(got, "Not symlinks", "npm") || !hasDetail(got, "Dangling symlinks", "npx") {
+		t.Errorf("details should name each problem: %+v", got.Details)
+	}
+
+	// Symlinks are not what the copy strategy lays down
+	got = newShimStrategyCheckWith(source, shimsDir, config.ShimStrategyCopy, nil).Run()
+	if !hasDetail(got, "Symlinks instead of copy", "node") {
+		t.Errorf("details should flag symlinks under the copy strategy: %+v", got.Details)
+	}
+}
+
+func TestShimStrategyCheck_Hardlinks(t *testing.T) {
+	skipLinksOnWindows(t)
+	source, shimsDir := shimStrategyLayout(t)
+	if err := os.Link(source, filepath.Join(shimsDir, "node")); err != nil {
+		t.Fatal(err)
+	}
+	// A same-size copy is the fallback when linking fails
+	if err := os.WriteFile(filepath.Join(shimsDir, "npm"), []byte("shim binary v2"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := newShimStrategyCheckWith(source, shimsDir, config.ShimStrategyHardlink, nil).Run(); !got.OK {
+		t.Fatalf("hard links and fallback copies should pass, got %+v", got)
+	}
+}
+
+func TestShimStrategyCheck_MissingShimBinary(t *testing.T) {
+	_, shimsDir := shimStrategyLayout(t)
+	c := newShimStrategyCheckWith("", shimsDir, config.ShimStrategyCopy, nil)
+	c.shimSource = func() (string, error) { return "", errors.New("not found") }
+
+	if got := c.Run(); !got.OK {
+		t.Errorf("a missing dtvem-shim is reported elsewhere; got %+v", got)
+	}
+}
+
+// hasDetail reports whether the finding has a detail with the given key
+// whose value mentions name
+func hasDetail(f Finding, key, name string) bool {
+	for _, d := range f.Details {
+		if d.Key == key && strings.Contains(d.Value, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,480 @@
+package download
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/filelock"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/httpclient"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+	"github.com/schollz/progressbar/v3"
+)
+
+// Default retry settings for the package-level downloader
+const (
+	DefaultMaxAttempts    = 5
+	DefaultInitialBackoff = 1 * time.Second
+	DefaultMaxBackoff     = 30 * time.Second
+)
+
+// partialSuffix is the extension of a download in progress. A leftover
+// partial file is resumed with an HTTP Range request.
+const partialSuffix = ".partial"
+
+// Downloader fetches files over HTTP with resume and retry support.
+//
+// Downloads are written to a partial file named after the URL and moved into
+// place once complete. If a transfer is interrupted, the next attempt asks
+// the server for the remaining bytes with a Range header; servers that ignore
+// Range simply restart the file. Connection failures, read timeouts,
+// truncated bodies and HTTP 5xx/408/429 responses are retried with
+// exponential backoff.
+//
+// Partial files outlive a failed download, so a later install of the same
+// archive resumes where this one stopped, even though the failed install
+// removed its own temporary directory.
+type Downloader struct {
+	// Client performs the HTTP requests. Its transport should apply a
+	// connect timeout; the overall request must not have a deadline,
+	// since large archives can legitimately take a long time.
+	Client *http.Client
+
+	// MaxAttempts is the total number of attempts, including the first
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry. Each further
+	// retry doubles it, up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// ReadTimeout aborts an attempt when no data arrives for this long
+	ReadTimeout time.Duration
+
+	// PartialDir holds partial files. When empty, they are written next to
+	// the destination.
+	PartialDir string
+}
+
+// defaultDownloader returns the downloader used by the package-level
+// download functions, built on the shared client from settings.json
+func defaultDownloader() (*Downloader, error) {
+	client, err := httpclient.Client()
+	if err != nil {
+		return nil, err
+	}
+	return NewDownloader(client), nil
+}
+
+// NewDownloader creates a Downloader with the default retry policy, using
+// client for requests and the configured request timeout for idle reads
+func NewDownloader(client *http.Client) *Downloader {
+	return &Downloader{
+		Client:         client,
+		MaxAttempts:    DefaultMaxAttempts,
+		InitialBackoff: DefaultInitialBackoff,
+		MaxBackoff:     DefaultMaxBackoff,
+		ReadTimeout:    httpclient.RequestTimeout(),
+		PartialDir:     config.PartialDownloadsDir(),
+	}
+}
+
+// retryableError marks a failure that may succeed if the download is retried
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+func (e *retryableError) Unwrap() error { return e.err }
+
+// isRetryable reports whether err was marked as transient
+func isRetryable(err error) bool {
+	var re *retryableError
+	return errors.As(err, &re)
+}
+
+// Download fetches url into destPath, resuming and retrying as needed.
+// Progress is shown as a console progress bar, or sent to reporter when
+// it is non-nil.
+func (d *Downloader) Download(url, destPath string, reporter ui.ProgressReporter) error {
+	ui.Debug("Starting download: %s", url)
+	ui.Debug("Destination: %s", destPath)
+
+	// Create destination directory if it doesn't exist
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return err
+	}
+
+	partPath := d.partialPath(url, destPath)
+	if err := os.MkdirAll(filepath.Dir(partPath), 0755); err != nil {
+		return err
+	}
+
+	// Processes downloading the same URL take turns, so they don't append
+	// to the same partial file
+	lock, err := config.AcquireLock(downloadLockName(urlKey(url)))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Release() }()
+
+	maxAttempts := d.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := d.attempt(url, partPath, reporter)
+		if err == nil {
+			break
+		}
+
+		ui.Debug("Download attempt %d/%d failed: %v", attempt, maxAttempts, err)
+		if !isRetryable(err) || attempt >= maxAttempts {
+			// The partial file is kept for the next download of the URL
+			if isRetryable(err) {
+				return fmt.Errorf("download failed after %d attempts: %w", attempt, err)
+			}
+			return err
+		}
+
+		delay := d.backoff(attempt)
+		ui.Warning("Download interrupted (%v), retrying in %s (attempt %d/%d)", err, delay, attempt+1, maxAttempts)
+		time.Sleep(delay)
+	}
+
+	if err := moveFile(partPath, destPath); err != nil {
+		return fmt.Errorf("failed to move download into place: %w", err)
+	}
+
+	ui.Debug("Download complete: %s", destPath)
+	return nil
+}
+
+// partialPath returns the partial file for a download of url. Partial files
+// in PartialDir are keyed by URL, so any download of the URL can resume them.
+func (d *Downloader) partialPath(url, destPath string) string {
+	if d.PartialDir == "" {
+		return destPath + partialSuffix
+	}
+	return filepath.Join(d.PartialDir, urlKey(url)+partialSuffix)
+}
+
+// urlKey returns a file name safe key for url
+func urlKey(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:])
+}
+
+// downloadLockName returns the name of the lock held while the URL with the
+// given key is downloaded, or its partial file removed
+func downloadLockName(key string) string {
+	return "download-" + key
+}
+
+// RemovePartials deletes the partial files in dir, except those of
+// downloads in progress. Returns the number of bytes freed.
+func RemovePartials(dir string) (int64, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	var freed int64
+	for _, file := range files {
+		key, ok := strings.CutSuffix(file.Name(), partialSuffix)
+		if !ok || file.IsDir() {
+			continue
+		}
+
+		lock, err := filelock.TryAcquire(config.LockPath(downloadLockName(key)))
+		if err != nil {
+			return freed, err
+		}
+		if lock == nil {
+			ui.Debug("Skipping partial download %s: it is in progress", file.Name())
+			continue
+		}
+
+		path := filepath.Join(dir, file.Name())
+		info, statErr := os.Stat(path)
+		err = os.Remove(path)
+		_ = lock.Release()
+		if err != nil && !os.IsNotExist(err) {
+			return freed, err
+		}
+		if err == nil && statErr == nil {
+			freed += info.Size()
+		}
+	}
+	return freed, nil
+}
+
+// moveFile renames src to dst, copying it when they are on different
+// volumes (the cache and a temporary directory may be)
+func moveFile(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		_ = os.Remove(dst)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		_ = os.Remove(dst)
+		return err
+	}
+	_ = in.Close()
+	return os.Remove(src)
+}
+
+// backoff returns the delay before the retry following the given attempt
+func (d *Downloader) backoff(attempt int) time.Duration {
+	delay := d.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if d.MaxBackoff > 0 && delay >= d.MaxBackoff {
+			return d.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// attempt performs a single request, appending to partPath when it already
+// holds part of the file
+func (d *Downloader) attempt(url, partPath string, reporter ui.ProgressReporter) error {
+	var offset int64
+	if info, err := os.Stat(partPath); err == nil {
+		offset = info.Size()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		ui.Debug("Resuming download at byte %d", offset)
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	// Make HTTP request
+	ui.Debug("Making HTTP GET request...")
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		ui.Debug("HTTP request failed: %v", err)
+		err = fmt.Errorf("failed to connect: %w (URL: %s)", err, url)
+		if isTransientError(err) {
+			return &retryableError{err}
+		}
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	ui.Debug("HTTP response: %s", resp.Status)
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		start, ok := contentRangeStart(resp.Header.Get("Content-Range"))
+		if !ok || start != offset {
+			// The server resumed somewhere unexpected; start over
+			_ = os.Remove(partPath)
+			return &retryableError{fmt.Errorf("server returned unexpected range %q", resp.Header.Get("Content-Range"))}
+		}
+		flags |= os.O_APPEND
+	case resp.StatusCode == http.StatusOK:
+		// Full response, either a fresh download or a server without Range support
+		offset = 0
+		flags |= os.O_TRUNC
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// The partial file is stale or already larger than the resource
+		_ = os.Remove(partPath)
+		return &retryableError{fmt.Errorf("download failed (HTTP %s): %s", resp.Status, url)}
+	case isTransientStatus(resp.StatusCode):
+		return &retryableError{fmt.Errorf("download failed (HTTP %s): %s", resp.Status, url)}
+	default:
+		return fmt.Errorf("download failed (HTTP %s): %s", resp.Status, url)
+	}
+
+	out, err := os.OpenFile(partPath, flags, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = out.Close() }()
+
+	// Get file size for progress bar
+	total := int64(-1)
+	if resp.ContentLength >= 0 {
+		total = offset + resp.ContentLength
+	}
+	ui.Debug("Content-Length: %d bytes (total %d)", resp.ContentLength, total)
+
+	bar, finish := newProgressWriter(offset, total, reporter)
+	defer finish()
+
+	// Abort the request if the server stops sending data
+	var timedOut atomic.Bool
+	timer := time.AfterFunc(d.readTimeout(), func() {
+		timedOut.Store(true)
+		cancel()
+	})
+	defer timer.Stop()
+
+	body := &readTracker{reader: resp.Body, onRead: func() { timer.Reset(d.readTimeout()) }}
+	written, err := io.Copy(io.MultiWriter(out, bar), body)
+	if err != nil {
+		if timedOut.Load() {
+			return &retryableError{fmt.Errorf("no data received for %s", d.readTimeout())}
+		}
+		if body.err != nil && isTransientError(body.err) {
+			return &retryableError{err}
+		}
+		return err
+	}
+
+	if resp.ContentLength >= 0 && written < resp.ContentLength {
+		return &retryableError{fmt.Errorf("connection closed after %d of %d bytes: %w", written, resp.ContentLength, io.ErrUnexpectedEOF)}
+	}
+
+	return nil
+}
+
+func (d *Downloader) readTimeout() time.Duration {
+	if d.ReadTimeout <= 0 {
+		return httpclient.DefaultRequestTimeout
+	}
+	return d.ReadTimeout
+}
+
+// isTransientError reports whether a request or read error is worth
+// retrying: timeouts, refused, reset or aborted connections, and bodies cut
+// short. Certificate, proxy and URL errors fail the same way every time.
+func isTransientError(err error) bool {
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	for _, errno := range connectionErrnos {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isTransientStatus reports whether an HTTP status is worth retrying
+func isTransientStatus(code int) bool {
+	return code >= 500 || code == http.StatusRequestTimeout || code == http.StatusTooManyRequests
+}
+
+// contentRangeStart parses the first byte position from a Content-Range
+// header such as "bytes 100-199/200"
+func contentRangeStart(header string) (int64, bool) {
+	rest, ok := strings.CutPrefix(header, "bytes ")
+	if !ok {
+		return 0, false
+	}
+	startStr, _, ok := strings.Cut(rest, "-")
+	if !ok {
+		return 0, false
+	}
+	start, err := strconv.ParseInt(strings.TrimSpace(startStr), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return start, true
+}
+
+// readTracker wraps a response body, recording read errors (as opposed to
+// write errors on the destination) and signalling each successful read
+type readTracker struct {
+	reader io.Reader
+	onRead func()
+	err    error
+}
+
+func (r *readTracker) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.onRead()
+	}
+	if err != nil && err != io.EOF {
+		r.err = err
+	}
+	return n, err
+}
+
+// newProgressWriter returns a writer that tracks bytes written, and a function
+// to call once the transfer stops. offset is the number of bytes already on
+// disk when resuming. With a nil reporter, progress is shown as a console
+// progress bar; otherwise it is sent to the reporter.
+func newProgressWriter(offset, total int64, reporter ui.ProgressReporter) (io.Writer, func()) {
+	if reporter == nil {
+		bar := progressbar.DefaultBytes(total, "Downloading")
+		if offset > 0 {
+			_ = bar.Set64(offset)
+		}
+		return bar, func() { fmt.Println() } // New line after progress bar
+	}
+
+	reporter.Status("Downloading...")
+	w := &reporterWriter{reporter: reporter, current: offset, total: total}
+	if offset > 0 {
+		reporter.Bytes(offset, total)
+	}
+	return w, func() {}
+}
+
+// reporterWriter forwards byte counts to a ProgressReporter
+type reporterWriter struct {
+	reporter ui.ProgressReporter
+	current  int64
+	total    int64
+}
+
+func (w *reporterWriter) Write(p []byte) (int, error) {
+	w.current += int64(len(p))
+	w.reporter.Bytes(w.current, w.total)
+	return len(p), nil
+}
+
+// funcReporter adapts a progress callback to ui.ProgressReporter
+type funcReporter func(current, total int64)
+
+func (f funcReporter) Status(string) {}
+
+func (f funcReporter) Bytes(current, total int64) {
+	if f != nil {
+		f(current, total)
+	}
+}
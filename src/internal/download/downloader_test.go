@@ -0,0 +1,440 @@
+package download
+
+import (
+	"bytes"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/httpclient"
+)
+
+// newTestDownloader returns a downloader with short backoffs and timeouts,
+// taking its locks under a temporary DTVEM_ROOT
+func newTestDownloader(t *testing.T) *Downloader {
+	t.Helper()
+	t.Setenv("DTVEM_ROOT", t.TempDir())
+	config.ResetPathsCache()
+	t.Cleanup(config.ResetPathsCache)
+
+	return &Downloader{
+		Client:         newTestClient(),
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		ReadTimeout:    200 * time.Millisecond,
+	}
+}
+
+// newTestClient returns a client with a one second request timeout
+func newTestClient() *http.Client {
+	client, err := httpclient.New(config.NetworkSettings{RequestTimeout: "1s"})
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// testPayload returns deterministic content large enough to split
+func testPayload() []byte {
+	return bytes.Repeat([]byte("0123456789abcdef"), 4096)
+}
+
+// serveRange writes content honoring a "bytes=N-" Range header
+func serveRange(w http.ResponseWriter, r *http.Request, content []byte) {
+	rangeHeader := r.Header.Get("Range")
+	if rangeHeader == "" {
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		_, _ = w.Write(content)
+		return
+	}
+
+	start, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rangeHeader, "bytes="), "-"))
+	if err != nil || start >= len(content) {
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+		return
+	}
+	w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(content)-1, len(content)))
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)-start))
+	w.WriteHeader(http.StatusPartialContent)
+	_, _ = w.Write(content[start:])
+}
+
+// dropConnection sends the headers and the first n bytes of content, then
+// closes the connection mid-body
+func dropConnection(t *testing.T, w http.ResponseWriter, content []byte, n int) {
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(content[:n])
+	w.(http.Flusher).Flush()
+
+	conn, _, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		t.Errorf("Hijack failed: %v", err)
+		return
+	}
+	_ = conn.Close()
+}
+
+func TestDownloader_ResumesAfterDroppedConnection(t *testing.T) {
+	content := testPayload()
+	var requests int32
+	var rangeSeen atomic.Value
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			dropConnection(t, w, content, len(content)/3)
+			return
+		}
+		rangeSeen.Store(r.Header.Get("Range"))
+		serveRange(w, r, content)
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "archive.tar.gz")
+	if err := newTestDownloader(t).Download(server.URL, dest, nil); err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+
+	got, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("downloaded %d bytes, want %d matching bytes", len(got), len(content))
+	}
+	if want := fmt.Sprintf("bytes=%d-", len(content)/3); rangeSeen.Load() != want {
+		t.Errorf("resume Range header = %v, want %q", rangeSeen.Load(), want)
+	}
+	if _, err := os.Stat(dest + partialSuffix); !os.IsNotExist(err) {
+		t.Error("partial file should be renamed into place")
+	}
+}
+
+func TestDownloader_ResumesExistingPartialFile(t *testing.T) {
+	content := testPayload()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") == "" {
+			t.Error("expected a Range request for an existing partial file")
+		}
+		serveRange(w, r, content)
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "archive.tar.gz")
+	if err := os.WriteFile(dest+partialSuffix, content[:1000], 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := newTestDownloader(t).Download(server.URL, dest, nil); err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+
+	got, _ := os.ReadFile(dest)
+	if !bytes.Equal(got, content) {
+		t.Error("resumed download does not match the original content")
+	}
+}
+
+func TestDownloader_KeepsPartialFileAcrossFailedDownloads(t *testing.T) {
+	content := testPayload()
+	var requests int32
+	var rangeSeen atomic.Value
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			dropConnection(t, w, content, len(content)/2)
+			return
+		}
+		rangeSeen.Store(r.Header.Get("Range"))
+		serveRange(w, r, content)
+	}))
+	defer server.Close()
+
+	d := newTestDownloader(t)
+	d.MaxAttempts = 1
+	d.PartialDir = t.TempDir()
+
+	// The first install fails and removes its temporary directory
+	firstDir := t.TempDir()
+	if err := d.Download(server.URL, filepath.Join(firstDir, "archive.tar.gz"), nil); err == nil {
+		t.Fatal("Download should fail when the connection drops")
+	}
+	if err := os.RemoveAll(firstDir); err != nil {
+		t.Fatal(err)
+	}
+
+	partPath := d.partialPath(server.URL, "")
+	if info, err := os.Stat(partPath); err != nil || info.Size() != int64(len(content)/2) {
+		t.Fatalf("partial file should be kept after a failed download (stat: %v)", err)
+	}
+
+	dest := filepath.Join(t.TempDir(), "archive.tar.gz")
+	if err := d.Download(server.URL, dest, nil); err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+	got, _ := os.ReadFile(dest)
+	if !bytes.Equal(got, content) {
+		t.Error("resumed download does not match the original content")
+	}
+	if want := fmt.Sprintf("bytes=%d-", len(content)/2); rangeSeen.Load() != want {
+		t.Errorf("resume Range header = %v, want %q", rangeSeen.Load(), want)
+	}
+	if _, err := os.Stat(partPath); !os.IsNotExist(err) {
+		t.Error("partial file should be removed once the download succeeds")
+	}
+}
+
+func TestRemovePartials_SkipsDownloadsInProgress(t *testing.T) {
+	newTestDownloader(t)
+	dir := t.TempDir()
+
+	idle := filepath.Join(dir, urlKey("https://example.com/idle.tar.gz")+partialSuffix)
+	busyKey := urlKey("https://example.com/busy.tar.gz")
+	busy := filepath.Join(dir, busyKey+partialSuffix)
+	for _, path := range []string{idle, busy} {
+		if err := os.WriteFile(path, []byte("12345"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lock, err := config.AcquireLock(downloadLockName(busyKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = lock.Release() }()
+
+	freed, err := RemovePartials(dir)
+	if err != nil {
+		t.Fatalf("RemovePartials failed: %v", err)
+	}
+	if freed != 5 {
+		t.Errorf("RemovePartials freed %d bytes, want 5", freed)
+	}
+	if _, err := os.Stat(idle); !os.IsNotExist(err) {
+		t.Error("partial file of an interrupted download should be removed")
+	}
+	if _, err := os.Stat(busy); err != nil {
+		t.Error("partial file of a download in progress should be kept")
+	}
+}
+
+func TestDownloader_RestartsWhenRangeIgnored(t *testing.T) {
+	content := testPayload()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Always send the full body, as servers without Range support do
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "archive.tar.gz")
+	if err := os.WriteFile(dest+partialSuffix, []byte("stale partial data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := newTestDownloader(t).Download(server.URL, dest, nil); err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+
+	got, _ := os.ReadFile(dest)
+	if !bytes.Equal(got, content) {
+		t.Error("download should replace the partial file when the server ignores Range")
+	}
+}
+
+func TestDownloader_RetriesServerErrors(t *testing.T) {
+	content := []byte("runtime archive")
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "archive.tar.gz")
+	if err := newTestDownloader(t).Download(server.URL, dest, nil); err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+}
+
+func TestDownloader_DoesNotRetryClientErrors(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "archive.tar.gz")
+	err := newTestDownloader(t).Download(server.URL, dest, nil)
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("Download error = %v, want HTTP 404", err)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
+
+func TestDownloader_DoesNotRetryCertificateErrors(t *testing.T) {
+	var connections int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the request should fail the TLS handshake")
+	}))
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&connections, 1)
+		}
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	// The test client does not trust the server's self-signed certificate
+	dest := filepath.Join(t.TempDir(), "archive.tar.gz")
+	err := newTestDownloader(t).Download(server.URL, dest, nil)
+	if err == nil {
+		t.Fatal("Download should fail on an untrusted certificate")
+	}
+	if isRetryable(err) {
+		t.Errorf("certificate errors should not be retried, got %v", err)
+	}
+	if got := atomic.LoadInt32(&connections); got != 1 {
+		t.Errorf("connections = %d, want 1", got)
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"truncated body", fmt.Errorf("read: %w", io.ErrUnexpectedEOF), true},
+		{"connection reset", &net.OpError{Op: "read", Err: os.NewSyscallError("read", connectionErrnos[1])}, true},
+		{"connection refused", &url.Error{Op: "Get", URL: "https://example.com", Err: &net.OpError{Op: "dial", Err: connectionErrnos[0]}}, true},
+		{"timeout", &url.Error{Op: "Get", URL: "https://example.com", Err: timeoutError{}}, true},
+		{"malformed URL", &url.Error{Op: "parse", URL: "://", Err: errors.New("missing protocol scheme")}, false},
+		{"certificate", &url.Error{Op: "Get", URL: "https://example.com", Err: x509.UnknownAuthorityError{}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransientError(tt.err); got != tt.want {
+				t.Errorf("isTransientError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// timeoutError is a net.Error that timed out
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestDownloader_GivesUpAfterMaxAttempts(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "archive.tar.gz")
+	err := newTestDownloader(t).Download(server.URL, dest, nil)
+	if err == nil {
+		t.Fatal("Download should fail when every attempt fails")
+	}
+	if !isRetryable(err) {
+		t.Errorf("final error should wrap the transient failure, got %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+	if _, statErr := os.Stat(dest); !os.IsNotExist(statErr) {
+		t.Error("destination should not exist after a failed download")
+	}
+}
+
+func TestDownloader_ReadTimeout(t *testing.T) {
+	content := testPayload()
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			// Send part of the body, then stall past the read timeout
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			_, _ = w.Write(content[:1024])
+			w.(http.Flusher).Flush()
+			select {
+			case <-r.Context().Done():
+			case <-time.After(2 * time.Second):
+			}
+			return
+		}
+		serveRange(w, r, content)
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "archive.tar.gz")
+	if err := newTestDownloader(t).Download(server.URL, dest, nil); err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+
+	got, _ := os.ReadFile(dest)
+	if !bytes.Equal(got, content) {
+		t.Error("download after read timeout does not match the original content")
+	}
+}
+
+func TestDownloader_Backoff(t *testing.T) {
+	d := &Downloader{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 5 * time.Second},
+		{10, 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := d.backoff(tt.attempt); got != tt.expected {
+			t.Errorf("backoff(%d) = %s, want %s", tt.attempt, got, tt.expected)
+		}
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	if isRetryable(errors.New("plain")) {
+		t.Error("plain errors should not be retryable")
+	}
+	if !isRetryable(fmt.Errorf("wrapped: %w", &retryableError{errors.New("reset")})) {
+		t.Error("wrapped retryable errors should be retryable")
+	}
+}
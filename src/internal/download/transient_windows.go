@@ -0,0 +1,13 @@
+//go:build windows
+
+package download
+
+import "golang.org/x/sys/windows"
+
+// connectionErrnos are the socket errors of a connection that was refused,
+// reset or aborted, which may succeed if the download is retried
+var connectionErrnos = []error{
+	windows.WSAECONNREFUSED,
+	windows.WSAECONNRESET,
+	windows.WSAECONNABORTED,
+}
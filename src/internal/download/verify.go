@@ -5,13 +5,11 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
-	"path/filepath"
 	"strings"
 
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
-	"github.com/schollz/progressbar/v3"
 )
 
 // ErrChecksumMismatch is returned when the downloaded file's checksum doesn't match.
@@ -24,84 +22,68 @@ func (e *ErrChecksumMismatch) Error() string {
 	return fmt.Sprintf("checksum mismatch: expected %s, got %s", e.Expected, e.Actual)
 }
 
-// FileVerified downloads a file from a URL and verifies its SHA256 checksum.
-// If the checksum doesn't match, the file is deleted and an error is returned.
-func FileVerified(url, destPath, expectedSHA256 string) error {
-	ui.Debug("Starting verified download: %s", url)
-	ui.Debug("Destination: %s", destPath)
-	ui.Debug("Expected SHA256: %s", expectedSHA256)
+// ErrChecksumMissing is returned when a download has no published checksum
+// and the checksum policy requires one.
+type ErrChecksumMissing struct {
+	URL string
+}
 
-	// Create destination directory if it doesn't exist
-	destDir := filepath.Dir(destPath)
-	if err := os.MkdirAll(destDir, 0755); err != nil {
-		return err
-	}
+func (e *ErrChecksumMissing) Error() string {
+	return fmt.Sprintf("no checksum available for %s (checksum policy is %q)", e.URL, config.ChecksumPolicyRequire)
+}
 
-	// Create the destination file
-	out, err := os.Create(destPath)
-	if err != nil {
-		return err
-	}
-	defer func() { _ = out.Close() }()
+// FileWithPolicy downloads a file and verifies it against expectedSHA256.
+// A checksum mismatch is always an error. When expectedSHA256 is empty, the
+// policy decides whether the download is refused (require), accepted with a
+// warning (warn), or accepted silently (skip).
+func FileWithPolicy(url, destPath, expectedSHA256 string, policy config.ChecksumPolicy) error {
+	return FileWithPolicyProgress(url, destPath, expectedSHA256, policy, nil)
+}
 
-	// Make HTTP request
-	ui.Debug("Making HTTP GET request...")
-	resp, err := http.Get(url)
-	if err != nil {
-		ui.Debug("HTTP request failed: %v", err)
-		return fmt.Errorf("failed to connect: %w (URL: %s)", err, url)
+// FileWithPolicyProgress is FileWithPolicy with transfer progress sent to
+// reporter instead of a console progress bar. A nil reporter shows the bar.
+func FileWithPolicyProgress(url, destPath, expectedSHA256 string, policy config.ChecksumPolicy, reporter ui.ProgressReporter) error {
+	if strings.TrimSpace(expectedSHA256) != "" {
+		return fileVerified(url, destPath, expectedSHA256, reporter)
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	ui.Debug("HTTP response: %s", resp.Status)
+	ui.Debug("No checksum available for %s (policy: %s)", url, policy)
 
-	// Check response status
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("download failed (HTTP %s): %s", resp.Status, url)
+	switch policy {
+	case config.ChecksumPolicySkip:
+		// Proceed without verification
+	case config.ChecksumPolicyWarn:
+		ui.Warning("No checksum available, download will not be verified")
+	default:
+		return &ErrChecksumMissing{URL: url}
 	}
 
-	// Get file size for progress bar
-	size := resp.ContentLength
-	ui.Debug("Content-Length: %d bytes", size)
+	return fetch(url, destPath, reporter)
+}
 
-	// Create progress bar
-	bar := progressbar.DefaultBytes(
-		size,
-		"Downloading",
-	)
+// FileVerified downloads a file from a URL and verifies its SHA256 checksum.
+// If the checksum doesn't match, the file is deleted and an error is returned.
+func FileVerified(url, destPath, expectedSHA256 string) error {
+	return fileVerified(url, destPath, expectedSHA256, nil)
+}
 
-	// Create SHA256 hasher
-	hasher := sha256.New()
+func fileVerified(url, destPath, expectedSHA256 string, reporter ui.ProgressReporter) error {
+	ui.Debug("Starting verified download: %s", url)
+	ui.Debug("Expected SHA256: %s", expectedSHA256)
 
-	// Copy data with progress bar and hashing
-	_, err = io.Copy(io.MultiWriter(out, bar, hasher), resp.Body)
-	if err != nil {
-		ui.Debug("Download failed: %v", err)
-		_ = os.Remove(destPath) // Clean up partial download
+	if err := fetch(url, destPath, reporter); err != nil {
 		return err
 	}
 
-	fmt.Println() // New line after progress bar
-
-	// Verify checksum
-	actualSHA256 := hex.EncodeToString(hasher.Sum(nil))
-	ui.Debug("Actual SHA256: %s", actualSHA256)
-
-	// Normalize both checksums to lowercase for comparison
-	expectedNorm := strings.ToLower(strings.TrimSpace(expectedSHA256))
-	actualNorm := strings.ToLower(actualSHA256)
-
-	if actualNorm != expectedNorm {
-		ui.Debug("Checksum mismatch! Removing downloaded file.")
+	// Verify checksum over the whole file, since a resumed download
+	// was written across several requests
+	if err := VerifyFile(destPath, expectedSHA256); err != nil {
+		ui.Debug("Checksum verification failed: %v. Removing downloaded file.", err)
 		_ = os.Remove(destPath) // Remove the file with bad checksum
-		return &ErrChecksumMismatch{
-			Expected: expectedSHA256,
-			Actual:   actualSHA256,
-		}
+		return err
 	}
 
 	ui.Debug("Checksum verified successfully")
-	ui.Debug("Download complete: %s", destPath)
 	return nil
 }
 
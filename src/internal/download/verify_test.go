@@ -2,9 +2,13 @@ package download
 
 import (
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
 )
 
 func TestComputeSHA256(t *testing.T) {
@@ -103,3 +107,72 @@ func TestErrChecksumMismatch(t *testing.T) {
 		t.Errorf("Error() = %q, want %q", err.Error(), want)
 	}
 }
+
+func TestFileWithPolicy(t *testing.T) {
+	content := []byte("hello world\n")
+	expectedHash := "a948904f2f0f479b8f8197694b30184b0d2ed1c1cd2a1ec0fb85d299a192a447"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	t.Run("matching checksum", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "archive")
+		if err := FileWithPolicy(server.URL, dest, expectedHash, config.ChecksumPolicyRequire); err != nil {
+			t.Fatalf("FileWithPolicy failed: %v", err)
+		}
+		if _, err := os.Stat(dest); err != nil {
+			t.Errorf("expected downloaded file to exist: %v", err)
+		}
+	})
+
+	t.Run("mismatch is rejected under every policy", func(t *testing.T) {
+		policies := []config.ChecksumPolicy{
+			config.ChecksumPolicyRequire,
+			config.ChecksumPolicyWarn,
+			config.ChecksumPolicySkip,
+		}
+		for _, policy := range policies {
+			dest := filepath.Join(t.TempDir(), "archive")
+			err := FileWithPolicy(server.URL, dest, "0000000000000000000000000000000000000000000000000000000000000000", policy)
+			var mismatchErr *ErrChecksumMismatch
+			if !errors.As(err, &mismatchErr) {
+				t.Errorf("policy %s: expected ErrChecksumMismatch, got %v", policy, err)
+			}
+			if _, statErr := os.Stat(dest); !os.IsNotExist(statErr) {
+				t.Errorf("policy %s: file with bad checksum should be removed", policy)
+			}
+		}
+	})
+
+	t.Run("missing checksum with require policy", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "archive")
+		err := FileWithPolicy(server.URL, dest, "", config.ChecksumPolicyRequire)
+		var missingErr *ErrChecksumMissing
+		if !errors.As(err, &missingErr) {
+			t.Fatalf("expected ErrChecksumMissing, got %v", err)
+		}
+		if _, statErr := os.Stat(dest); !os.IsNotExist(statErr) {
+			t.Error("nothing should be downloaded when checksum is required but missing")
+		}
+	})
+
+	t.Run("missing checksum with unknown policy fails closed", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "archive")
+		err := FileWithPolicy(server.URL, dest, "", config.ChecksumPolicy(""))
+		var missingErr *ErrChecksumMissing
+		if !errors.As(err, &missingErr) {
+			t.Fatalf("expected ErrChecksumMissing, got %v", err)
+		}
+	})
+
+	t.Run("missing checksum with warn and skip policies", func(t *testing.T) {
+		for _, policy := range []config.ChecksumPolicy{config.ChecksumPolicyWarn, config.ChecksumPolicySkip} {
+			dest := filepath.Join(t.TempDir(), "archive")
+			if err := FileWithPolicy(server.URL, dest, "", policy); err != nil {
+				t.Errorf("policy %s: unexpected error: %v", policy, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,132 @@
+// Package filelock coordinates dtvem processes that share a DTVEM_ROOT.
+//
+// Locks are advisory, exclusive locks on a lock file (flock on Unix,
+// LockFileEx on Windows). The operating system releases them when the
+// holding process exits, so a crashed install never leaves a stale lock
+// behind. Two Lock values for the same path exclude each other even within
+// one process, which also serializes goroutines; locks are not reentrant.
+//
+// WriteFile replaces files atomically, so readers never observe a partially
+// written runtimes.json, settings.json or shim-map cache.
+package filelock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+)
+
+// errLocked is returned by tryLock when another holder owns the lock
+var errLocked = errors.New("lock is held by another process")
+
+// Lock is a held cross-process lock
+type Lock struct {
+	path string
+	file *os.File
+}
+
+// Acquire blocks until the lock at path is held. The lock file and its
+// directory are created if needed. When another process holds the lock, a
+// message is shown so a waiting command doesn't look hung.
+func Acquire(path string) (*Lock, error) {
+	lock, err := TryAcquire(path)
+	if err != nil || lock != nil {
+		return lock, err
+	}
+
+	ui.Info("Waiting for another dtvem process to finish (%s)...", filepath.Base(path))
+
+	file, err := openLockFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(file); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+	return &Lock{path: path, file: file}, nil
+}
+
+// TryAcquire takes the lock at path without waiting. Returns a nil Lock and
+// no error when the lock is held elsewhere.
+func TryAcquire(path string) (*Lock, error) {
+	file, err := openLockFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := tryLockFile(file); err != nil {
+		_ = file.Close()
+		if errors.Is(err, errLocked) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+	ui.Debug("Acquired lock %s", path)
+	return &Lock{path: path, file: file}, nil
+}
+
+// Release releases the lock. The lock file is left in place: removing it
+// would let a waiting process lock a file that a new process can no longer
+// see. Calling Release on a nil or released Lock is a no-op.
+func (l *Lock) Release() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	err := unlockFile(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	l.file = nil
+	ui.Debug("Released lock %s", l.path)
+	return err
+}
+
+// openLockFile opens (creating if needed) the lock file at path
+func openLockFile(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+	return file, nil
+}
+
+// WriteFile writes data to a temporary file in the same directory as path
+// and renames it into place, so path always holds either the old or the new
+// content in full. The parent directory must exist.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package filelock
+
+import (
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"testing"
+	"time"
+)
+
+func TestTryAcquire_ExcludesOtherHolders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "locks", "shims.lock")
+
+	first, err := TryAcquire(path)
+	if err != nil || first == nil {
+		t.Fatalf("TryAcquire() = %v, %v; want the lock", first, err)
+	}
+
+	second, err := TryAcquire(path)
+	if err != nil {
+		t.Fatalf("TryAcquire() error: %v", err)
+	}
+	if second != nil {
+		t.Fatal("TryAcquire() should not get a lock that is already held")
+	}
+
+	if err := first.Release(); err != nil {
+		t.Fatalf("Release() error: %v", err)
+	}
+
+	third, err := TryAcquire(path)
+	if err != nil || third == nil {
+		t.Fatalf("TryAcquire() after Release = %v, %v; want the lock", third, err)
+	}
+	_ = third.Release()
+}
+
+func TestAcquire_WaitsForRelease(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.lock")
+
+	held, err := Acquire(path)
+	if err != nil {
+		t.Fatalf("Acquire() error: %v", err)
+	}
+
+	acquired := make(chan *Lock)
+	go func() {
+		lock, err := Acquire(path)
+		if err != nil {
+			t.Errorf("Acquire() error: %v", err)
+		}
+		acquired <- lock
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("Acquire() returned while the lock was held")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	_ = held.Release()
+
+	select {
+	case lock := <-acquired:
+		_ = lock.Release()
+	case <-time.After(5 * time.Second):
+		t.Fatal("Acquire() did not return after the lock was released")
+	}
+}
+
+func TestRelease_NilAndTwice(t *testing.T) {
+	var nilLock *Lock
+	if err := nilLock.Release(); err != nil {
+		t.Errorf("Release() on nil lock error: %v", err)
+	}
+
+	lock, err := Acquire(filepath.Join(t.TempDir(), "x.lock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = lock.Release()
+	if err := lock.Release(); err != nil {
+		t.Errorf("second Release() error: %v", err)
+	}
+}
+
+func TestWriteFile_ReplacesAtomically(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "runtimes.json")
+
+	if err := WriteFile(path, []byte(`{"node": "18.0.0"}`), 0644); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+	if err := WriteFile(path, []byte(`{"node": "22.0.0"}`), 0644); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"node": "22.0.0"}` {
+		t.Errorf("content = %q, want the second write", data)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("expected only the target file to remain, found %d entries", len(entries))
+	}
+
+	if goruntime.GOOS != "windows" {
+		info, _ := os.Stat(path)
+		if info.Mode().Perm() != 0644 {
+			t.Errorf("mode = %v, want 0644", info.Mode().Perm())
+		}
+	}
+}
+
+func TestWriteFile_MissingDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "file.json")
+	if err := WriteFile(path, []byte("{}"), 0644); err == nil {
+		t.Error("WriteFile() should fail when the directory does not exist")
+	}
+}
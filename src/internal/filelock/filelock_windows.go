@@ -0,0 +1,42 @@
+//go:build windows
+
+package filelock
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// The whole file is locked by locking its maximum byte range
+const (
+	allBytesLow  = ^uint32(0)
+	allBytesHigh = ^uint32(0)
+)
+
+// lockFile blocks until an exclusive lock on file is held
+func lockFile(file *os.File) error {
+	return lockFileEx(file, windows.LOCKFILE_EXCLUSIVE_LOCK)
+}
+
+// tryLockFile takes an exclusive lock on file, returning errLocked if it is
+// held elsewhere
+func tryLockFile(file *os.File) error {
+	err := lockFileEx(file, windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY)
+	if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		return errLocked
+	}
+	return err
+}
+
+// unlockFile releases the lock on file
+func unlockFile(file *os.File) error {
+	overlapped := new(windows.Overlapped)
+	return windows.UnlockFileEx(windows.Handle(file.Fd()), 0, allBytesLow, allBytesHigh, overlapped)
+}
+
+func lockFileEx(file *os.File, flags uint32) error {
+	overlapped := new(windows.Overlapped)
+	return windows.LockFileEx(windows.Handle(file.Fd()), flags, 0, allBytesLow, allBytesHigh, overlapped)
+}
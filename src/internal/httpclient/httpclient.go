@@ -0,0 +1,189 @@
+// Package httpclient provides the HTTP client shared by every network request
+// dtvem makes, configured from the "network" section of settings.json.
+//
+// It applies the configured proxy (falling back to the standard proxy
+// environment variables), trusts additional CA bundles on top of the system
+// store, enforces connect and response timeouts, and rewrites the official
+// builds and manifests hosts to configured mirrors.
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+)
+
+// Official hosts that can be replaced by mirrors
+const (
+	BuildsBaseURL    = "https://builds.dtvem.io"
+	ManifestsBaseURL = "https://manifests.dtvem.io"
+)
+
+// Default timeouts used when settings.json doesn't configure one
+const (
+	DefaultConnectTimeout = 30 * time.Second
+	DefaultRequestTimeout = 60 * time.Second
+)
+
+var (
+	sharedOnce     sync.Once
+	sharedClient   *http.Client
+	sharedSettings config.NetworkSettings
+	sharedTimeout  time.Duration
+	sharedErr      error
+)
+
+// load builds the shared client from settings.json on first use
+func load() {
+	sharedOnce.Do(func() {
+		sharedTimeout = DefaultRequestTimeout
+
+		settings, err := config.GetNetworkSettings()
+		if err != nil {
+			sharedErr = err
+			return
+		}
+		sharedSettings = settings
+
+		if timeout, err := settings.Timeout(); err != nil {
+			sharedErr = err
+			return
+		} else if timeout > 0 {
+			sharedTimeout = timeout
+		}
+
+		sharedClient, sharedErr = New(settings)
+		if sharedErr != nil {
+			sharedErr = fmt.Errorf("invalid network settings in %s: %w", config.SettingsPath(), sharedErr)
+		}
+	})
+}
+
+// Client returns the shared client configured from settings.json. An error
+// is returned when the network settings are invalid (e.g., an unreadable CA
+// bundle), so that misconfiguration is reported instead of silently ignored.
+func Client() (*http.Client, error) {
+	load()
+	return sharedClient, sharedErr
+}
+
+// RequestTimeout returns the configured request timeout, or the default
+func RequestTimeout() time.Duration {
+	load()
+	return sharedTimeout
+}
+
+// RewriteURL replaces the official builds or manifests host at the start of
+// rawURL with the configured mirror. Other URLs are returned unchanged.
+func RewriteURL(rawURL string) string {
+	load()
+	return rewrite(sharedSettings, rawURL)
+}
+
+// rewrite applies the mirror settings to rawURL
+func rewrite(settings config.NetworkSettings, rawURL string) string {
+	mirrors := []struct{ official, mirror string }{
+		{BuildsBaseURL, settings.BuildsMirror},
+		{ManifestsBaseURL, settings.ManifestsMirror},
+	}
+
+	for _, m := range mirrors {
+		mirror := strings.TrimRight(strings.TrimSpace(m.mirror), "/")
+		if mirror == "" {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(rawURL, m.official); ok && (rest == "" || strings.HasPrefix(rest, "/")) {
+			rewritten := mirror + rest
+			ui.Debug("Using mirror: %s -> %s", rawURL, rewritten)
+			return rewritten
+		}
+	}
+	return rawURL
+}
+
+// New creates a client from the given network settings. The client limits
+// the time spent connecting, in the TLS handshake, and waiting for response
+// headers, without capping the total duration of a transfer, since large
+// archives can legitimately take a long time.
+func New(settings config.NetworkSettings) (*http.Client, error) {
+	timeout, err := settings.Timeout()
+	if err != nil {
+		return nil, err
+	}
+	if timeout == 0 {
+		timeout = DefaultRequestTimeout
+	}
+	connectTimeout := min(timeout, DefaultConnectTimeout)
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   connectTimeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport.TLSHandshakeTimeout = connectTimeout
+	transport.ResponseHeaderTimeout = timeout
+
+	if proxy := settings.ProxyURL(); proxy != "" {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", settings.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if len(settings.CABundles) > 0 {
+		pool, err := certPool(settings.CABundles)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    pool,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// certPool returns the system certificate pool extended with the
+// certificates in the given PEM files
+func certPool(bundles []string) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		ui.Debug("System certificate pool unavailable, using CA bundles only: %v", err)
+		pool = x509.NewCertPool()
+	}
+
+	for _, bundle := range bundles {
+		path := os.ExpandEnv(bundle)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no PEM certificates found in CA bundle %s", path)
+		}
+		ui.Debug("Trusting certificates from %s", path)
+	}
+	return pool, nil
+}
+
+// Reset discards the shared client so the next call reloads settings.json.
+// This is primarily useful for testing.
+func Reset() {
+	sharedOnce = sync.Once{}
+	sharedClient = nil
+	sharedSettings = config.NetworkSettings{}
+	sharedTimeout = 0
+	sharedErr = nil
+}
@@ -0,0 +1,198 @@
+package httpclient
+
+import (
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+)
+
+// writeSettings points DTVEM_ROOT at a temporary directory containing a
+// settings.json with the given network section
+func writeSettings(t *testing.T, network string) {
+	t.Helper()
+	root := t.TempDir()
+	t.Setenv("DTVEM_ROOT", root)
+	config.ResetPathsCache()
+	Reset()
+	t.Cleanup(func() {
+		config.ResetPathsCache()
+		Reset()
+	})
+
+	if network == "" {
+		return
+	}
+	settings := `{"installType": "user", "network": ` + network + `}`
+	if err := os.MkdirAll(filepath.Dir(config.SettingsPath()), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(config.SettingsPath(), []byte(settings), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeServerCA writes the certificate of a TLS test server to a PEM file
+func writeServerCA(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "corporate-ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNew_TrustsCABundles(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	plain, err := New(config.NetworkSettings{})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if _, err := plain.Get(server.URL); err == nil {
+		t.Fatal("request to a server with an untrusted certificate should fail")
+	}
+
+	client, err := New(config.NetworkSettings{CABundles: []string{writeServerCA(t, server)}})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("request with the CA bundle failed: %v", err)
+	}
+	_ = resp.Body.Close()
+}
+
+func TestNew_InvalidCABundle(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.pem")
+	if _, err := New(config.NetworkSettings{CABundles: []string{missing}}); err == nil {
+		t.Error("New should fail for a missing CA bundle")
+	}
+
+	notPEM := filepath.Join(t.TempDir(), "not.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(config.NetworkSettings{CABundles: []string{notPEM}}); err == nil || !strings.Contains(err.Error(), "no PEM certificates") {
+		t.Errorf("New error = %v, want a no PEM certificates error", err)
+	}
+}
+
+func TestNew_UsesProxy(t *testing.T) {
+	var proxied, auth string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		auth = r.Header.Get("Proxy-Authorization")
+		_, _ = w.Write([]byte("via proxy"))
+	}))
+	defer proxy.Close()
+
+	t.Setenv("DTVEM_TEST_PROXY_PASSWORD", "secret")
+	proxyURL := strings.Replace(proxy.URL, "http://", "http://builder:${DTVEM_TEST_PROXY_PASSWORD}@", 1)
+
+	client, err := New(config.NetworkSettings{Proxy: proxyURL})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	resp, err := client.Get("http://builds.example.invalid/node.tar.gz")
+	if err != nil {
+		t.Fatalf("request through proxy failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if string(body) != "via proxy" || proxied != "http://builds.example.invalid/node.tar.gz" {
+		t.Errorf("proxy saw %q and returned %q, want the request to go through the proxy", proxied, body)
+	}
+	if auth == "" {
+		t.Error("proxy credentials were not sent")
+	}
+}
+
+func TestNew_InvalidSettings(t *testing.T) {
+	tests := []config.NetworkSettings{
+		{Proxy: "not a url"},
+		{RequestTimeout: "soon"},
+		{RequestTimeout: "-5s"},
+	}
+	for _, settings := range tests {
+		if _, err := New(settings); err == nil {
+			t.Errorf("New(%+v) should fail", settings)
+		}
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	settings := config.NetworkSettings{
+		BuildsMirror:    "https://artifactory.corp/dtvem-builds/",
+		ManifestsMirror: "https://artifactory.corp/dtvem-manifests",
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"https://builds.dtvem.io/node/22.0.0/node.tar.gz", "https://artifactory.corp/dtvem-builds/node/22.0.0/node.tar.gz"},
+		{"https://manifests.dtvem.io", "https://artifactory.corp/dtvem-manifests"},
+		{"https://manifests.dtvem.io/python.json", "https://artifactory.corp/dtvem-manifests/python.json"},
+		{"https://builds.dtvem.io.evil.example/x", "https://builds.dtvem.io.evil.example/x"},
+		{"https://nodejs.org/dist/v22.0.0/node.tar.gz", "https://nodejs.org/dist/v22.0.0/node.tar.gz"},
+	}
+
+	for _, tt := range tests {
+		if got := rewrite(settings, tt.input); got != tt.expected {
+			t.Errorf("rewrite(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+
+	if got := rewrite(config.NetworkSettings{}, tests[0].input); got != tests[0].input {
+		t.Errorf("rewrite without mirrors = %q, want it unchanged", got)
+	}
+}
+
+func TestClient_LoadsSettings(t *testing.T) {
+	writeSettings(t, `{"requestTimeout": "90s", "buildsMirror": "https://mirror.example"}`)
+
+	if _, err := Client(); err != nil {
+		t.Fatalf("Client failed: %v", err)
+	}
+	if got := RequestTimeout(); got != 90*time.Second {
+		t.Errorf("RequestTimeout() = %s, want 90s", got)
+	}
+	if got := RewriteURL(BuildsBaseURL + "/a.zip"); got != "https://mirror.example/a.zip" {
+		t.Errorf("RewriteURL() = %q, want the mirror URL", got)
+	}
+}
+
+func TestClient_Defaults(t *testing.T) {
+	writeSettings(t, "")
+
+	client, err := Client()
+	if err != nil || client == nil {
+		t.Fatalf("Client() = %v, %v; want a default client", client, err)
+	}
+	if got := RequestTimeout(); got != DefaultRequestTimeout {
+		t.Errorf("RequestTimeout() = %s, want %s", got, DefaultRequestTimeout)
+	}
+}
+
+func TestClient_ReportsInvalidSettings(t *testing.T) {
+	writeSettings(t, `{"caBundles": ["/nonexistent/ca.pem"]}`)
+
+	if _, err := Client(); err == nil || !strings.Contains(err.Error(), "invalid network settings") {
+		t.Errorf("Client error = %v, want an invalid network settings error", err)
+	}
+}
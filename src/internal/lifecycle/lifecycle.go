@@ -16,13 +16,28 @@ const (
 	// MaintenanceLTS indicates a release receiving only critical fixes.
 	MaintenanceLTS Status = "Maintenance LTS"
 
+	// Bugfix indicates a Python release line receiving regular bugfix
+	// releases (PEP 602).
+	Bugfix Status = "Bugfix"
+
+	// NormalMaintenance indicates a Ruby release line receiving regular bug
+	// and security fixes.
+	NormalMaintenance Status = "Normal Maintenance"
+
+	// Security indicates a release line receiving only security fixes.
+	Security Status = "Security"
+
+	// Prerelease indicates a release line with no final release yet.
+	Prerelease Status = "Prerelease"
+
 	// EOL indicates a release that has reached end of life.
 	EOL Status = "EOL"
 )
 
 // StatusProvider returns lifecycle status for a given version string.
-// Providers that track release schedules (e.g., Node.js) can implement
-// this interface so list-all displays lifecycle information.
+// Providers that track release schedules (Node.js, Python, Ruby) implement
+// this interface so list-all, outdated and other version listings display
+// lifecycle information.
 type StatusProvider interface {
 	// VersionStatus returns the lifecycle status label for the given version,
 	// or an empty string if the status is unknown.
@@ -0,0 +1,114 @@
+package lifecycle
+
+import (
+	"strings"
+	"time"
+)
+
+// Phase is a release line's position in its lifecycle. Phases are ordered;
+// a release line only ever moves to a later phase.
+type Phase int
+
+const (
+	// PhaseUnknown means nothing is known about the release line
+	PhaseUnknown Phase = iota
+	// PhasePrerelease is the time before the line's first final release
+	PhasePrerelease
+	// PhaseActive is regular development: Node.js Current, Python bugfix,
+	// Ruby normal maintenance
+	PhaseActive
+	// PhaseLTS is Node.js active long-term support
+	PhaseLTS
+	// PhaseMaintenance is critical or security fixes only
+	PhaseMaintenance
+	// PhaseEOL is end of life
+	PhaseEOL
+)
+
+// Line is a release line from a runtime's release schedule
+type Line struct {
+	// Upstream is the phase the schedule reports for the line, if any
+	Upstream Phase
+	// Starts holds the dates the line's phases start. Zero dates are unknown.
+	Starts map[Phase]time.Time
+}
+
+// PhaseAt returns the phase of the line at now: the later of the upstream
+// phase and the phase its dates imply. The two disagree when the embedded
+// schedule is stale. A line whose dates all lie ahead is a prerelease.
+func (l Line) PhaseAt(now time.Time) Phase {
+	byDate := PhaseUnknown
+	for phase, start := range l.Starts {
+		if start.IsZero() {
+			continue
+		}
+		if !now.Before(start) {
+			if phase > byDate {
+				byDate = phase
+			}
+		} else if byDate == PhaseUnknown {
+			byDate = PhasePrerelease
+		}
+	}
+
+	if l.Upstream > byDate {
+		return l.Upstream
+	}
+	return byDate
+}
+
+// Schedule computes lifecycle status from a runtime's release lines. It
+// implements StatusProvider.
+type Schedule struct {
+	// Lines are the release lines, keyed by Key(version)
+	Lines map[string]Line
+	// Labels are the statuses the runtime reports for each phase; phases
+	// without a label have no status
+	Labels map[Phase]Status
+	// Key returns the release line of a version, e.g. MajorMinor
+	Key func(version string) string
+}
+
+// VersionStatus returns the lifecycle label of a version today
+func (s *Schedule) VersionStatus(version string) string {
+	return s.StatusAt(version, time.Now())
+}
+
+// StatusAt returns the lifecycle label of a version at now, or "" if its
+// release line is unknown
+func (s *Schedule) StatusAt(version string, now time.Time) string {
+	line, ok := s.Lines[s.Key(version)]
+	if !ok {
+		return ""
+	}
+	return string(s.Labels[line.PhaseAt(now)])
+}
+
+// Major returns the major version of a version string like "22.14.0", or
+// "" if it has none
+func Major(version string) string {
+	major, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), ".")
+	if major == "" || strings.Trim(major, "0123456789") != "" {
+		return ""
+	}
+	return major
+}
+
+// MajorMinor returns the "major.minor" release line of a version string like
+// "3.12.4", or "" if it has none
+func MajorMinor(version string) string {
+	parts := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 3)
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// ParseDate parses a "YYYY-MM-DD" date string. Returns zero time on failure.
+func ParseDate(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,130 @@
+package lifecycle
+
+import (
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	return ParseDate(s)
+}
+
+func TestLine_PhaseAt(t *testing.T) {
+	line := Line{Starts: map[Phase]time.Time{
+		PhaseActive:      date("2024-04-24"),
+		PhaseMaintenance: date("2025-10-21"),
+		PhaseEOL:         date("2027-04-30"),
+	}}
+
+	tests := []struct {
+		now  string
+		want Phase
+	}{
+		{"2024-01-01", PhasePrerelease},
+		{"2024-04-24", PhaseActive},
+		{"2026-01-01", PhaseMaintenance},
+		{"2027-04-30", PhaseEOL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.now, func(t *testing.T) {
+			if got := line.PhaseAt(date(tt.now)); got != tt.want {
+				t.Errorf("PhaseAt(%s) = %d, want %d", tt.now, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("upstream phase outranks dates", func(t *testing.T) {
+		stale := line
+		stale.Upstream = PhaseMaintenance
+		if got := stale.PhaseAt(date("2024-06-01")); got != PhaseMaintenance {
+			t.Errorf("PhaseAt() = %d, want %d", got, PhaseMaintenance)
+		}
+	})
+
+	t.Run("no dates", func(t *testing.T) {
+		if got := (Line{}).PhaseAt(date("2024-06-01")); got != PhaseUnknown {
+			t.Errorf("PhaseAt() = %d, want %d", got, PhaseUnknown)
+		}
+	})
+}
+
+func TestSchedule_StatusAt(t *testing.T) {
+	s := &Schedule{
+		Lines: map[string]Line{
+			"3.12": {Starts: map[Phase]time.Time{PhaseActive: date("2023-10-02")}},
+		},
+		Labels: map[Phase]Status{PhaseActive: Bugfix},
+		Key:    MajorMinor,
+	}
+
+	if got := s.StatusAt("3.12.4", date("2024-01-01")); got != string(Bugfix) {
+		t.Errorf("StatusAt(3.12.4) = %q, want %q", got, Bugfix)
+	}
+	if got := s.StatusAt("3.12.0rc1", date("2023-09-01")); got != "" {
+		t.Errorf("StatusAt() = %q for an unlabeled phase, want empty", got)
+	}
+	if got := s.StatusAt("3.11.9", date("2024-01-01")); got != "" {
+		t.Errorf("StatusAt() = %q for an unknown line, want empty", got)
+	}
+}
+
+func TestMajor(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"22.14.0", "22"},
+		{"v22.14.0", "22"},
+		{"0.12.0", "0"},
+		{"abc", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := Major(tt.version); got != tt.want {
+				t.Errorf("Major(%q) = %q, want %q", tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMajorMinor(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"3.12.4", "3.12"},
+		{"v3.10.0", "3.10"},
+		{"3.15.0a1", "3.15"},
+		{"3", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := MajorMinor(tt.version); got != tt.want {
+				t.Errorf("MajorMinor(%q) = %q, want %q", tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		input string
+		zero  bool
+	}{
+		{"2024-04-24", false},
+		{"", true},
+		{"invalid", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := ParseDate(tt.input); got.IsZero() != tt.zero {
+				t.Errorf("ParseDate(%q).IsZero() = %v, want %v", tt.input, got.IsZero(), tt.zero)
+			}
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/filelock"
 )
 
 // DefaultCacheTTL is the default time-to-live for cached manifests.
@@ -138,5 +140,5 @@ func (s *CachedSource) saveToCache(runtime string, manifest *Manifest) error {
 		return err
 	}
 
-	return os.WriteFile(s.cachePath(runtime), data, 0644)
+	return filelock.WriteFile(s.cachePath(runtime), data, 0644)
 }
@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/httpclient"
 )
 
 var (
@@ -20,6 +21,8 @@ var (
 //  2. Fetch from remote (manifests.dtvem.io)
 //  3. Fall back to embedded manifests if remote fails
 //
+// In offline mode only the embedded manifests are used.
+//
 // The source is created once and reused for all subsequent calls.
 func DefaultSource() Source {
 	defaultSourceOnce.Do(func() {
@@ -30,19 +33,23 @@ func DefaultSource() Source {
 
 // createDefaultSource builds the layered source stack.
 func createDefaultSource() Source {
+	// Embedded source - bundled in binary, always available
+	defaultEmbedded = NewEmbeddedSource()
+
+	if config.IsOffline() {
+		return defaultEmbedded
+	}
+
 	// Cache directory for manifest files
 	paths := config.DefaultPaths()
 	cacheDir := filepath.Join(paths.Cache, "manifests")
 
-	// Remote source - fetches from manifests.dtvem.io
-	remote := NewHTTPSource(DefaultRemoteURL)
+	// Remote source - fetches from manifests.dtvem.io or the configured mirror
+	remote := NewHTTPSource(httpclient.RewriteURL(DefaultRemoteURL))
 
 	// Cached source - wraps remote with local disk cache
 	defaultCached = NewCachedSource(remote, cacheDir, DefaultCacheTTL)
 
-	// Embedded source - bundled in binary, always available
-	defaultEmbedded = NewEmbeddedSource()
-
 	// Fallback source - tries cached/remote first, falls back to embedded
 	return NewFallbackSource(defaultCached, defaultEmbedded)
 }
@@ -1,14 +1,18 @@
 package manifest
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/httpclient"
 )
 
 // DefaultRemoteURL is the default URL for fetching manifests.
-const DefaultRemoteURL = "https://manifests.dtvem.io"
+// A mirror can be configured with network.manifestsMirror in settings.json.
+const DefaultRemoteURL = httpclient.ManifestsBaseURL
 
 // DefaultHTTPTimeout is the default timeout for HTTP requests.
 const DefaultHTTPTimeout = 30 * time.Second
@@ -17,15 +21,19 @@ const DefaultHTTPTimeout = 30 * time.Second
 type HTTPSource struct {
 	baseURL    string
 	httpClient *http.Client
+	timeout    time.Duration
+	clientErr  error
 }
 
-// NewHTTPSource creates a Source that fetches manifests from a remote URL.
+// NewHTTPSource creates a Source that fetches manifests from a remote URL,
+// using the shared client configured from settings.json.
 func NewHTTPSource(baseURL string) *HTTPSource {
+	client, err := httpclient.Client()
 	return &HTTPSource{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: DefaultHTTPTimeout,
-		},
+		baseURL:    baseURL,
+		httpClient: client,
+		timeout:    httpclient.RequestTimeout(),
+		clientErr:  err,
 	}
 }
 
@@ -35,14 +43,27 @@ func NewHTTPSourceWithClient(baseURL string, client *http.Client) *HTTPSource {
 	return &HTTPSource{
 		baseURL:    baseURL,
 		httpClient: client,
+		timeout:    DefaultHTTPTimeout,
 	}
 }
 
 // GetManifest fetches and parses a manifest from the remote server.
 func (s *HTTPSource) GetManifest(runtime string) (*Manifest, error) {
+	if s.clientErr != nil {
+		return nil, s.clientErr
+	}
+
 	url := fmt.Sprintf("%s/%s.json", s.baseURL, runtime)
 
-	resp, err := s.httpClient.Get(url)
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch manifest: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch manifest: %w", err)
 	}
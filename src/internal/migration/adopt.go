@@ -0,0 +1,40 @@
+package migration
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SystemProvider is implemented by migration providers for installations
+// from the operating system or a system package manager. Their files share
+// a prefix such as /usr with unrelated software, so dtvem migrate --adopt
+// can't take them over.
+type SystemProvider interface {
+	// IsSystem returns true for system installations
+	IsSystem() bool
+}
+
+// InstallRoot returns the root of the installation a detected version's
+// executable belongs to: the parent of its bin (or Scripts) directory, or
+// the executable's own directory for layouts that keep it at the top, like
+// Node.js and Python on Windows. Returns an error for installations that
+// can't be adopted.
+func InstallRoot(provider Provider, dv DetectedVersion) (string, error) {
+	if sp, ok := provider.(SystemProvider); ok && sp.IsSystem() {
+		return "", fmt.Errorf("%s installations are shared with the system and can't be adopted", provider.DisplayName())
+	}
+
+	root := filepath.Dir(dv.Path)
+	if name := filepath.Base(root); name == "bin" || name == "Scripts" {
+		root = filepath.Dir(root)
+	}
+
+	if home, err := os.UserHomeDir(); err == nil && filepath.Clean(root) == filepath.Clean(home) {
+		return "", fmt.Errorf("%s is not a self-contained installation", dv.Path)
+	}
+	if filepath.Dir(root) == root {
+		return "", fmt.Errorf("%s is not a self-contained installation", dv.Path)
+	}
+	return root, nil
+}
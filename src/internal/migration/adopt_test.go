@@ -0,0 +1,52 @@
+package migration
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// systemProvider is a mock provider for system installations
+type systemProvider struct {
+	mockProvider
+}
+
+func (p *systemProvider) IsSystem() bool { return true }
+
+func TestInstallRoot(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "versions")
+	provider := &mockProvider{name: "pyenv", displayName: "pyenv", runtime: "python"}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{name: "bin", path: filepath.Join(base, "3.12.1", "bin", "python"), expected: filepath.Join(base, "3.12.1")},
+		{name: "Scripts", path: filepath.Join(base, "3.12.1", "Scripts", "python.exe"), expected: filepath.Join(base, "3.12.1")},
+		{name: "root", path: filepath.Join(base, "20.11.0", "node.exe"), expected: filepath.Join(base, "20.11.0")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := InstallRoot(provider, DetectedVersion{Path: tt.path})
+			if err != nil {
+				t.Fatalf("InstallRoot() error = %v", err)
+			}
+			if root != tt.expected {
+				t.Errorf("InstallRoot() = %q, want %q", root, tt.expected)
+			}
+		})
+	}
+}
+
+func TestInstallRoot_NotAdoptable(t *testing.T) {
+	system := &systemProvider{mockProvider{name: "system-node", displayName: "System Node.js", runtime: "node"}}
+	if _, err := InstallRoot(system, DetectedVersion{Path: filepath.Join("/opt", "node", "bin", "node")}); err == nil {
+		t.Error("InstallRoot() should refuse system installations")
+	}
+
+	provider := &mockProvider{name: "manual", runtime: "node"}
+	if _, err := InstallRoot(provider, DetectedVersion{Path: filepath.Join(string(filepath.Separator), "bin", "node")}); err == nil {
+		t.Error("InstallRoot() should refuse an executable directly under the filesystem root")
+	}
+}
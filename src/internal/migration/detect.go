@@ -0,0 +1,95 @@
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// versionDirPattern matches version directory names such as "20.11.0" or
+// "v20.11.0"
+var versionDirPattern = regexp.MustCompile(`^v?\d+\.\d+\.\d+$`)
+
+// DetectVersionDirs finds versions installed as one directory per version,
+// the layout most version managers use (e.g., ~/.asdf/installs/nodejs/20.11.0).
+// executables are paths relative to a version directory, tried in order; a
+// directory without any of them is skipped. Symbolic links, such as mise's
+// "20" → "20.11.0" aliases, are not followed.
+func DetectVersionDirs(versionsDir, source string, executables ...string) []DetectedVersion {
+	detected := make([]DetectedVersion, 0)
+	entries, err := os.ReadDir(versionsDir)
+	if err != nil {
+		return detected
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || !versionDirPattern.MatchString(entry.Name()) {
+			continue
+		}
+
+		versionDir := filepath.Join(versionsDir, entry.Name())
+		for _, executable := range executables {
+			exePath := filepath.Join(versionDir, executable)
+			if _, err := os.Stat(exePath); err == nil {
+				detected = append(detected, DetectedVersion{
+					Version:   strings.TrimPrefix(entry.Name(), "v"),
+					Path:      exePath,
+					Source:    source,
+					Validated: false,
+				})
+				break
+			}
+		}
+	}
+
+	return detected
+}
+
+// InstalledToolDirs returns the tools installed in a version manager's
+// installs directory, laid out as one directory per tool and one below it
+// per version (e.g., ~/.asdf/installs/golang/1.22.0). Tools without any
+// version directory left are not included; neither are dangling links. A
+// missing installs directory has no tools.
+func InstalledToolDirs(installsDir string) ([]string, error) {
+	entries, err := os.ReadDir(installsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var tools []string
+	for _, entry := range entries {
+		toolDir := filepath.Join(installsDir, entry.Name())
+		if info, err := os.Stat(toolDir); err != nil || !info.IsDir() {
+			continue
+		}
+		versions, err := os.ReadDir(toolDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, version := range versions {
+			if info, err := os.Stat(filepath.Join(toolDir, version.Name())); err == nil && info.IsDir() {
+				tools = append(tools, entry.Name())
+				break
+			}
+		}
+	}
+	return tools, nil
+}
+
+// DirFromEnv returns the directory named by an environment variable, or
+// fallback joined to the user's home directory when the variable is unset.
+// Returns "" if neither is available.
+func DirFromEnv(envVar string, fallback ...string) string {
+	if dir := os.Getenv(envVar); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(append([]string{home}, fallback...)...)
+}
@@ -0,0 +1,100 @@
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectVersionDirs(t *testing.T) {
+	dir := t.TempDir()
+	for _, rel := range []string{
+		"20.11.0/bin/node",
+		"v18.19.0/bin/node",
+		"22.1.0/node.exe",
+		"16.20.2/lib/.keep", // no executable
+		"lts/bin/node",      // not a version
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "14.0.0"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	detected := DetectVersionDirs(dir, "test", filepath.Join("bin", "node"), "node.exe")
+
+	got := make(map[string]string)
+	for _, dv := range detected {
+		got[dv.Version] = dv.Path
+		if dv.Source != "test" {
+			t.Errorf("Source = %q, want test", dv.Source)
+		}
+	}
+	expected := map[string]string{
+		"20.11.0": filepath.Join(dir, "20.11.0", "bin", "node"),
+		"18.19.0": filepath.Join(dir, "v18.19.0", "bin", "node"),
+		"22.1.0":  filepath.Join(dir, "22.1.0", "node.exe"),
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("DetectVersionDirs() = %+v, want %v", detected, expected)
+	}
+	for version, path := range expected {
+		if got[version] != path {
+			t.Errorf("version %s path = %q, want %q", version, got[version], path)
+		}
+	}
+}
+
+func TestDetectVersionDirs_MissingDir(t *testing.T) {
+	detected := DetectVersionDirs(filepath.Join(t.TempDir(), "missing"), "test", "bin/node")
+	if detected == nil || len(detected) != 0 {
+		t.Errorf("DetectVersionDirs() = %v, want empty slice", detected)
+	}
+}
+
+func TestInstalledToolDirs(t *testing.T) {
+	dir := t.TempDir()
+	for _, d := range []string{"nodejs/20.11.0", "golang/1.22.0", "empty"} {
+		if err := os.MkdirAll(filepath.Join(dir, d), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "stray-file"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tools, err := InstalledToolDirs(dir)
+	if err != nil {
+		t.Fatalf("InstalledToolDirs() error = %v", err)
+	}
+	if len(tools) != 2 || tools[0] != "golang" || tools[1] != "nodejs" {
+		t.Errorf("InstalledToolDirs() = %v, want [golang nodejs]", tools)
+	}
+
+	tools, err = InstalledToolDirs(filepath.Join(dir, "missing"))
+	if err != nil || tools != nil {
+		t.Errorf("InstalledToolDirs(missing) = %v, %v; want nil, nil", tools, err)
+	}
+}
+
+func TestDirFromEnv(t *testing.T) {
+	t.Setenv("DTVEM_TEST_DIR", "/opt/tool")
+	if got := DirFromEnv("DTVEM_TEST_DIR", ".tool"); got != "/opt/tool" {
+		t.Errorf("DirFromEnv() = %q, want /opt/tool", got)
+	}
+
+	t.Setenv("DTVEM_TEST_DIR", "")
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	if got := DirFromEnv("DTVEM_TEST_DIR", ".tool", "versions"); got != filepath.Join(home, ".tool", "versions") {
+		t.Errorf("DirFromEnv() = %q, want %q", got, filepath.Join(home, ".tool", "versions"))
+	}
+}
@@ -0,0 +1,62 @@
+package migration
+
+import (
+	"path/filepath"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+)
+
+// VersionFileProvider is implemented by migration providers for version
+// managers that pin versions in project files, such as asdf's .tool-versions,
+// mise.toml or volta's package.json. dtvem migrate converts those pins into
+// .dtvem/runtimes.json.
+type VersionFileProvider interface {
+	// VersionFiles returns the project file formats the version manager reads
+	VersionFiles() []config.VersionFile
+}
+
+// ProjectPin is a runtime version pinned by another version manager's
+// project file
+type ProjectPin struct {
+	Runtime string
+	Version string
+	File    string // Absolute path of the file that pins the version
+}
+
+// RuntimesFile returns the .dtvem/runtimes.json the pin converts into: the
+// one in the directory of the file that pins it
+func (p ProjectPin) RuntimesFile() string {
+	return filepath.Join(filepath.Dir(p.File), config.LocalConfigDirName, config.RuntimesFileName)
+}
+
+// FindProjectPin walks up from startDir and returns the nearest pin of the
+// runtime in the provider's version files. Returns nil if the provider has
+// no version files or none of them pins the runtime.
+func FindProjectPin(provider Provider, startDir string) *ProjectPin {
+	vfp, ok := provider.(VersionFileProvider)
+	if !ok {
+		return nil
+	}
+
+	resolved, err := config.FindVersionFileSource(startDir, provider.Runtime(), vfp.VersionFiles())
+	if err != nil {
+		return nil
+	}
+	return &ProjectPin{Runtime: provider.Runtime(), Version: resolved.Version, File: resolved.Source}
+}
+
+// ConvertProjectPin records the pin in its .dtvem/runtimes.json. A runtime
+// the file already pins is left alone; the existing version is returned with
+// converted false.
+func ConvertProjectPin(pin ProjectPin) (existing string, converted bool, err error) {
+	runtimesFile := pin.RuntimesFile()
+	pins, err := config.ReadAllRuntimes(runtimesFile)
+	if err == nil && pins[pin.Runtime] != "" {
+		return pins[pin.Runtime], false, nil
+	}
+
+	if err := config.SetRuntimesFileVersion(runtimesFile, pin.Runtime, pin.Version); err != nil {
+		return "", false, err
+	}
+	return "", true, nil
+}
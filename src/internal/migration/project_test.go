@@ -0,0 +1,99 @@
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+)
+
+// versionFileProvider is a mock provider that pins versions in .tool-versions
+type versionFileProvider struct {
+	mockProvider
+}
+
+func (p *versionFileProvider) VersionFiles() []config.VersionFile {
+	return []config.VersionFile{config.ToolVersionsFile()}
+}
+
+func TestFindProjectPin(t *testing.T) {
+	project := t.TempDir()
+	nested := filepath.Join(project, "src", "app")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	toolVersions := filepath.Join(project, ".tool-versions")
+	if err := os.WriteFile(toolVersions, []byte("nodejs 20.11.0\npython 3.12.1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := &versionFileProvider{mockProvider{name: "asdf-node", runtime: "node"}}
+	pin := FindProjectPin(provider, nested)
+	if pin == nil {
+		t.Fatal("FindProjectPin() = nil, want a pin")
+	}
+	if pin.Runtime != "node" || pin.Version != "20.11.0" || pin.File != toolVersions {
+		t.Errorf("FindProjectPin() = %+v", pin)
+	}
+	expectedRuntimes := filepath.Join(project, config.LocalConfigDirName, config.RuntimesFileName)
+	if pin.RuntimesFile() != expectedRuntimes {
+		t.Errorf("RuntimesFile() = %q, want %q", pin.RuntimesFile(), expectedRuntimes)
+	}
+
+	ruby := &versionFileProvider{mockProvider{name: "asdf-ruby", runtime: "ruby"}}
+	if pin := FindProjectPin(ruby, nested); pin != nil {
+		t.Errorf("FindProjectPin() for unpinned runtime = %+v, want nil", pin)
+	}
+
+	// Providers without version files have no project pins
+	plain := &mockProvider{name: "nvm", runtime: "node"}
+	if pin := FindProjectPin(plain, nested); pin != nil {
+		t.Errorf("FindProjectPin() without version files = %+v, want nil", pin)
+	}
+}
+
+func TestConvertProjectPin(t *testing.T) {
+	t.Setenv("DTVEM_ROOT", t.TempDir())
+	config.ResetPathsCache()
+	t.Cleanup(config.ResetPathsCache)
+
+	project := t.TempDir()
+	pin := ProjectPin{Runtime: "node", Version: "20.11.0", File: filepath.Join(project, ".tool-versions")}
+
+	existing, converted, err := ConvertProjectPin(pin)
+	if err != nil {
+		t.Fatalf("ConvertProjectPin() error = %v", err)
+	}
+	if !converted || existing != "" {
+		t.Errorf("ConvertProjectPin() = (%q, %v), want (\"\", true)", existing, converted)
+	}
+
+	pins, err := config.ReadAllRuntimes(pin.RuntimesFile())
+	if err != nil {
+		t.Fatalf("ReadAllRuntimes() error = %v", err)
+	}
+	if pins["node"] != "20.11.0" {
+		t.Errorf("runtimes.json node = %q, want 20.11.0", pins["node"])
+	}
+
+	// A runtime that is already pinned is left alone
+	pin.Version = "22.1.0"
+	existing, converted, err = ConvertProjectPin(pin)
+	if err != nil {
+		t.Fatalf("ConvertProjectPin() error = %v", err)
+	}
+	if converted || existing != "20.11.0" {
+		t.Errorf("ConvertProjectPin() = (%q, %v), want (\"20.11.0\", false)", existing, converted)
+	}
+
+	// Other runtimes are added alongside
+	pythonPin := ProjectPin{Runtime: "python", Version: "3.12.1", File: pin.File}
+	if _, converted, err := ConvertProjectPin(pythonPin); err != nil || !converted {
+		t.Fatalf("ConvertProjectPin(python) = (%v, %v)", converted, err)
+	}
+	pins, _ = config.ReadAllRuntimes(pin.RuntimesFile())
+	if pins["node"] != "20.11.0" || pins["python"] != "3.12.1" {
+		t.Errorf("runtimes.json = %v", pins)
+	}
+}
@@ -2,6 +2,7 @@ package migration
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 )
 
@@ -50,7 +51,8 @@ func (r *Registry) Get(name string) (Provider, error) {
 	return provider, nil
 }
 
-// GetByRuntime returns all migration providers for a given runtime.
+// GetByRuntime returns all migration providers for a given runtime, sorted
+// by name.
 func (r *Registry) GetByRuntime(runtimeName string) []Provider {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -61,6 +63,7 @@ func (r *Registry) GetByRuntime(runtimeName string) []Provider {
 			providers = append(providers, provider)
 		}
 	}
+	sort.Slice(providers, func(i, j int) bool { return providers[i].Name() < providers[j].Name() })
 	return providers
 }
 
@@ -0,0 +1,44 @@
+package migration
+
+import "github.com/CodingWithCalvin/dtvem.cli/src/internal/path"
+
+// ShellInitProvider is implemented by migration providers for version
+// managers that load themselves from shell startup files. dtvem migrate
+// --cleanup removes those lines once the manager has nothing left to manage.
+type ShellInitProvider interface {
+	// ShellInit returns what the version manager adds to startup files
+	ShellInit() path.ShellInit
+}
+
+// ToolsProvider is implemented by migration providers for version managers
+// that also manage tools dtvem has no provider for, such as asdf plugins
+// and mise tools (golang, java, ...).
+type ToolsProvider interface {
+	// InstalledTools returns every tool the version manager has at least
+	// one version of, including the ones dtvem migrates
+	InstalledTools() ([]string, error)
+}
+
+// ManagerInUse reports whether the version manager still has anything
+// installed: a version detected by one of its registered providers, or
+// any version of a tool it manages beyond them. A manager that fails to
+// scan counts as in use.
+func ManagerInUse(manager string) bool {
+	for _, provider := range GetAll() {
+		sp, ok := provider.(ShellInitProvider)
+		if !ok || sp.ShellInit().Manager != manager {
+			continue
+		}
+		versions, err := provider.DetectVersions()
+		if err != nil || len(versions) > 0 {
+			return true
+		}
+		if tp, ok := provider.(ToolsProvider); ok {
+			tools, err := tp.InstalledTools()
+			if err != nil || len(tools) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package migration
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/path"
+)
+
+// shellInitProvider is a mock provider for a version manager with shell init
+// lines, whose detected versions are configurable
+type shellInitProvider struct {
+	mockProvider
+	manager  string
+	versions []DetectedVersion
+	err      error
+}
+
+// toolsProvider is a mock provider whose manager also reports the tools it
+// has installed, beyond the runtimes dtvem migrates
+type toolsProvider struct {
+	shellInitProvider
+	tools    []string
+	toolsErr error
+}
+
+func (m *toolsProvider) InstalledTools() ([]string, error) {
+	return m.tools, m.toolsErr
+}
+
+func (m *shellInitProvider) DetectVersions() ([]DetectedVersion, error) {
+	return m.versions, m.err
+}
+
+func (m *shellInitProvider) ShellInit() path.ShellInit {
+	return path.ShellInit{Manager: m.manager}
+}
+
+func TestManagerInUse(t *testing.T) {
+	providers := []*shellInitProvider{
+		{mockProvider: mockProvider{name: "test-mgr-node", runtime: "node"}, manager: "test-mgr"},
+		{mockProvider: mockProvider{name: "test-mgr-ruby", runtime: "ruby"}, manager: "test-mgr"},
+		{mockProvider: mockProvider{name: "test-broken", runtime: "node"}, manager: "test-broken", err: errors.New("unreadable")},
+	}
+	for _, p := range providers {
+		if err := Register(p); err != nil {
+			t.Fatalf("Register(%s) error = %v", p.name, err)
+		}
+	}
+	t.Cleanup(func() {
+		for _, p := range providers {
+			_ = GetRegistry().Unregister(p.name)
+		}
+	})
+
+	if ManagerInUse("test-mgr") {
+		t.Error("ManagerInUse() = true with no versions left, want false")
+	}
+
+	providers[1].versions = []DetectedVersion{{Version: "3.3.0"}}
+	if !ManagerInUse("test-mgr") {
+		t.Error("ManagerInUse() = false while another runtime still has versions, want true")
+	}
+
+	if !ManagerInUse("test-broken") {
+		t.Error("ManagerInUse() = false for a manager that failed to scan, want true")
+	}
+
+	if ManagerInUse("test-unknown") {
+		t.Error("ManagerInUse() = true for an unknown manager, want false")
+	}
+}
+
+func TestManagerInUse_Tools(t *testing.T) {
+	p := &toolsProvider{
+		shellInitProvider: shellInitProvider{mockProvider: mockProvider{name: "test-tools-node", runtime: "node"}, manager: "test-tools"},
+	}
+	if err := Register(p); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	t.Cleanup(func() { _ = GetRegistry().Unregister(p.name) })
+
+	if ManagerInUse("test-tools") {
+		t.Error("ManagerInUse() = true with no versions or tools left, want false")
+	}
+
+	p.tools = []string{"golang"}
+	if !ManagerInUse("test-tools") {
+		t.Error("ManagerInUse() = false while the manager still has other tools, want true")
+	}
+
+	p.tools, p.toolsErr = nil, errors.New("unreadable")
+	if !ManagerInUse("test-tools") {
+		t.Error("ManagerInUse() = false when tools could not be listed, want true")
+	}
+}
@@ -0,0 +1,132 @@
+// Package output renders command results in machine-readable formats.
+//
+// Query commands build a result struct and hand it to Print when the global
+// --output flag selects json or yaml, instead of rendering tables with tui.
+// Result structs are part of dtvem's scripting interface: fields may be added,
+// but existing field names and meanings must stay stable.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an output format selected with --output
+type Format string
+
+const (
+	// FormatText renders human-readable tables and messages (the default)
+	FormatText Format = "text"
+	// FormatJSON renders results as indented JSON
+	FormatJSON Format = "json"
+	// FormatYAML renders results as YAML
+	FormatYAML Format = "yaml"
+)
+
+// current is the format selected for this process
+var current = FormatText
+
+// ParseFormat parses a format name (case-insensitive). "yml" is accepted as
+// an alias for yaml.
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", string(FormatText):
+		return FormatText, nil
+	case string(FormatJSON):
+		return FormatJSON, nil
+	case string(FormatYAML), "yml":
+		return FormatYAML, nil
+	}
+	return "", fmt.Errorf("invalid output format %q (valid formats: text, json, yaml)", name)
+}
+
+// String implements pflag.Value
+func (f *Format) String() string {
+	if *f == "" {
+		return string(FormatText)
+	}
+	return string(*f)
+}
+
+// Set implements pflag.Value, validating the format name
+func (f *Format) Set(name string) error {
+	parsed, err := ParseFormat(name)
+	if err != nil {
+		return err
+	}
+	*f = parsed
+	return nil
+}
+
+// Type implements pflag.Value
+func (f *Format) Type() string {
+	return "format"
+}
+
+// SetFormat selects the output format for this process
+func SetFormat(f Format) {
+	if f == "" {
+		f = FormatText
+	}
+	current = f
+}
+
+// Current returns the selected output format
+func Current() Format {
+	return current
+}
+
+// IsStructured reports whether a machine-readable format is selected
+func IsStructured() bool {
+	return current == FormatJSON || current == FormatYAML
+}
+
+// Print writes v to stdout in the selected structured format
+func Print(v interface{}) error {
+	return Encode(os.Stdout, current, v)
+}
+
+// Encode writes v to w in the given format. Text is not a structured format
+// and returns an error.
+func Encode(w io.Writer, f Format, v interface{}) error {
+	switch f {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(v)
+	case FormatYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(v); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+	return fmt.Errorf("%s is not a structured output format", f)
+}
+
+// ErrorOutput is written instead of a command's result when it fails
+//
+//	{"error": {"message": "unknown runtime: java", "hints": ["Available runtimes: [node python ruby]"]}}
+type ErrorOutput struct {
+	Error ErrorDetail `json:"error" yaml:"error"`
+}
+
+// ErrorDetail describes a command failure
+type ErrorDetail struct {
+	// Message is the error message
+	Message string `json:"message" yaml:"message"`
+	// Hints are suggested next steps, as shown in text mode
+	Hints []string `json:"hints,omitempty" yaml:"hints,omitempty"`
+}
+
+// PrintError writes an ErrorOutput for message to stdout in the selected format
+func PrintError(message string, hints ...string) error {
+	return Print(ErrorOutput{Error: ErrorDetail{Message: message, Hints: hints}})
+}
@@ -0,0 +1,121 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type sample struct {
+	Name     string   `json:"name" yaml:"name"`
+	Versions []string `json:"versions" yaml:"versions"`
+	Note     string   `json:"note,omitempty" yaml:"note,omitempty"`
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Format
+		wantErr  bool
+	}{
+		{"", FormatText, false},
+		{"text", FormatText, false},
+		{"json", FormatJSON, false},
+		{"JSON", FormatJSON, false},
+		{"yaml", FormatYAML, false},
+		{"yml", FormatYAML, false},
+		{"xml", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFormat(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("ParseFormat(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestFormat_FlagValue(t *testing.T) {
+	var f Format
+	if f.String() != "text" {
+		t.Errorf("zero Format String() = %q, want text", f.String())
+	}
+	if err := f.Set("yaml"); err != nil || f != FormatYAML {
+		t.Errorf("Set(yaml) = %v, format %q", err, f)
+	}
+	if err := f.Set("toml"); err == nil {
+		t.Error("Set(toml) should fail")
+	}
+}
+
+func TestEncode_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	v := sample{Name: "node", Versions: []string{"18.20.0", "22.0.0"}}
+	if err := Encode(&buf, FormatJSON, v); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	var decoded sample
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if decoded.Name != "node" || len(decoded.Versions) != 2 {
+		t.Errorf("decoded = %+v, want the original value", decoded)
+	}
+	if strings.Contains(buf.String(), "note") {
+		t.Error("empty omitempty fields should not be written")
+	}
+}
+
+func TestEncode_YAML(t *testing.T) {
+	var buf bytes.Buffer
+	v := sample{Name: "python", Versions: []string{"3.12.1"}}
+	if err := Encode(&buf, FormatYAML, v); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	want := "name: python\nversions:\n  - 3.12.1\n"
+	if buf.String() != want {
+		t.Errorf("YAML output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestEncode_TextIsNotStructured(t *testing.T) {
+	if err := Encode(&bytes.Buffer{}, FormatText, sample{}); err == nil {
+		t.Error("Encode with the text format should fail")
+	}
+}
+
+func TestErrorOutput_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	v := ErrorOutput{Error: ErrorDetail{Message: "unknown runtime: java", Hints: []string{"Available runtimes: [node]"}}}
+	if err := Encode(&buf, FormatJSON, v); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	var decoded map[string]map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if decoded["error"]["message"] != "unknown runtime: java" {
+		t.Errorf("error.message = %v, want the error message", decoded["error"]["message"])
+	}
+}
+
+func TestSetFormat(t *testing.T) {
+	t.Cleanup(func() { SetFormat(FormatText) })
+
+	SetFormat(FormatJSON)
+	if !IsStructured() || Current() != FormatJSON {
+		t.Error("json should be a structured format")
+	}
+	SetFormat("")
+	if IsStructured() || Current() != FormatText {
+		t.Error("an empty format should select text")
+	}
+}
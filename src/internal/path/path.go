@@ -7,8 +7,10 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/filelock"
 )
 
 // dtvemMarkerComment is the literal comment line dtvem writes above
@@ -183,33 +185,152 @@ func RemoveStaleShimsFromShellConfig(configFile, currentShimsDir string) ([]stri
 //   - A marker comment followed by an export pointing at the current
 //     shims dir is preserved (it's not stale).
 func removeDtvemMarkerBlocks(configContent, currentShimsDir string) (string, []string) {
+	var removed []string
+	out, _ := removeDtvemMarkerLines(configContent, func(line string) bool {
+		stale := extractStaleShimsPath(line, currentShimsDir)
+		if stale == "" {
+			return false
+		}
+		// Record what we removed so the caller can report it to the user.
+		removed = append(removed, stale)
+		return true
+	})
+	return out, removed
+}
+
+// dtvemMarkerBlock is the block dtvem writes: its marker comment and the
+// single line below it
+var dtvemMarkerBlock = MarkerBlock{Begin: dtvemMarkerComment}
+
+// removeDtvemMarkerLines drops each pair of (dtvem marker comment,
+// following line) for which match returns true, and returns the rewritten
+// content along with the dropped lines. A marker comment on the last line
+// is preserved.
+func removeDtvemMarkerLines(configContent string, match func(line string) bool) (string, []string) {
+	return removeMarkerBlocks(configContent, dtvemMarkerBlock, func(body []string) bool {
+		return match(body[0])
+	})
+}
+
+// removeMarkerBlocks drops each marker block for which match returns true:
+// a line equal to block.Begin through the next line equal to block.End, or,
+// when block.End is empty, the single line below the marker. match is
+// given the lines between the markers, which are also returned for every
+// dropped block. A block that never ends is preserved.
+func removeMarkerBlocks(configContent string, block MarkerBlock, match func(body []string) bool) (string, []string) {
 	lines := strings.Split(configContent, "\n")
 	out := make([]string, 0, len(lines))
 	var removed []string
 
 	for i := 0; i < len(lines); i++ {
 		line := lines[i]
-		if strings.TrimSpace(line) != dtvemMarkerComment || i+1 >= len(lines) {
+		end := markerBlockEnd(lines, i, block)
+		if end < 0 {
 			out = append(out, line)
 			continue
 		}
 
-		next := lines[i+1]
-		stale := extractStaleShimsPath(next, currentShimsDir)
-		if stale == "" {
+		body := lines[i+1 : end+1]
+		if block.End != "" {
+			body = lines[i+1 : end]
+		}
+		if !match(body) {
 			out = append(out, line)
 			continue
 		}
 
-		// Drop both the marker and the export, recording what we
-		// removed so the caller can report it to the user.
-		removed = append(removed, stale)
-		i++
+		// Drop the whole block, markers included
+		removed = append(removed, body...)
+		i = end
 	}
 
 	return strings.Join(out, "\n"), removed
 }
 
+// markerBlockEnd returns the index of the last line of the block that
+// starts at lines[start], or -1 if no block starts there or it never ends
+func markerBlockEnd(lines []string, start int, block MarkerBlock) int {
+	if strings.TrimSpace(lines[start]) != block.Begin {
+		return -1
+	}
+	if block.End == "" {
+		if start+1 >= len(lines) {
+			return -1
+		}
+		return start + 1
+	}
+	for j := start + 1; j < len(lines); j++ {
+		if strings.TrimSpace(lines[j]) == block.End {
+			return j
+		}
+	}
+	return -1
+}
+
+// writeShellConfig replaces the contents of a shell config file the user
+// owns. The original is first copied to a timestamped .bak file next to
+// it, whose path is returned, and the new content is written atomically
+// with the file's permissions kept. A symlinked config (e.g., from a
+// dotfiles repository) is written through to its target.
+func writeShellConfig(configFile, newContent string) (string, error) {
+	target, err := filepath.EvalSymlinks(configFile)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(target)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(target)
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", configFile, err)
+	}
+
+	backup := fmt.Sprintf("%s.dtvem-%s.bak", configFile, time.Now().Format("20060102-150405"))
+	if err := filelock.WriteFile(backup, data, info.Mode().Perm()); err != nil {
+		return "", fmt.Errorf("back up %s: %w", configFile, err)
+	}
+	if err := filelock.WriteFile(target, []byte(newContent), info.Mode().Perm()); err != nil {
+		return backup, fmt.Errorf("write %s: %w", configFile, err)
+	}
+	return backup, nil
+}
+
+// hasMarkerBlock reports whether configFile contains a marker comment
+// followed by exactly the given line
+func hasMarkerBlock(configFile, line string) bool {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return false
+	}
+	lines := strings.Split(string(data), "\n")
+	for i := 0; i+1 < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == dtvemMarkerComment && strings.TrimSpace(lines[i+1]) == strings.TrimSpace(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// appendMarkerBlock appends line to configFile below a marker comment,
+// creating the file and its directory if needed
+func appendMarkerBlock(configFile, line string) error {
+	if err := os.MkdirAll(filepath.Dir(configFile), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	f, err := os.OpenFile(configFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if _, err := f.WriteString("\n" + dtvemMarkerComment + "\n" + line + "\n"); err != nil {
+		return fmt.Errorf("failed to write to config file: %w", err)
+	}
+	return nil
+}
+
 // extractStaleShimsPath returns the dtvem shims path referenced in a
 // PATH-export line if and only if that path is non-empty, looks like a
 // dtvem shims dir, and is not currentShimsDir. Returns "" when the
@@ -90,9 +90,9 @@ func AddToPath(shimsDir string, skipConfirmation bool, userInstall bool) error {
 	// Prepare the export statement
 	exportLine := ""
 	if shell == constants.ShellFish {
-		exportLine = fmt.Sprintf("\n# Added by dtvem\nset -gx PATH \"%s\" $PATH\n", shimsDir)
+		exportLine = fmt.Sprintf("set -gx PATH \"%s\" $PATH", shimsDir)
 	} else {
-		exportLine = fmt.Sprintf("\n# Added by dtvem\nexport PATH=\"%s:$PATH\"\n", shimsDir)
+		exportLine = fmt.Sprintf("export PATH=\"%s:$PATH\"", shimsDir)
 	}
 
 	// Prompt user for confirmation (unless skipConfirmation is true)
@@ -101,7 +101,7 @@ func AddToPath(shimsDir string, skipConfirmation bool, userInstall bool) error {
 		ui.Info("dtvem needs to add the shims directory to your PATH")
 		ui.Info("Shell: %s", ui.Highlight(shell))
 		ui.Info("Config file: %s", ui.Highlight(configFile))
-		ui.Info("Will append: %s", ui.Highlight(strings.TrimSpace(exportLine)))
+		ui.Info("Will append: %s", ui.Highlight(exportLine))
 		fmt.Printf("\nProceed? [Y/n]: ")
 
 		var response string
@@ -110,28 +110,14 @@ func AddToPath(shimsDir string, skipConfirmation bool, userInstall bool) error {
 
 		if response != "" && response != constants.ResponseY && response != constants.ResponseYes {
 			ui.Warning("PATH not modified. Please add this manually to your %s:", configFile)
-			ui.Info("%s", strings.TrimSpace(exportLine))
+			ui.Info("%s", exportLine)
 			return nil
 		}
 	}
 
-	// Ensure the directory exists for fish config
-	if shell == constants.ShellFish {
-		configDir := filepath.Dir(configFile)
-		if err := os.MkdirAll(configDir, 0755); err != nil {
-			return fmt.Errorf("failed to create config directory: %w", err)
-		}
-	}
-
-	// Append to the config file
-	f, err := os.OpenFile(configFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to open config file: %w", err)
-	}
-	defer func() { _ = f.Close() }()
-
-	if _, err := f.WriteString(exportLine); err != nil {
-		return fmt.Errorf("failed to write to config file: %w", err)
+	// Append to the config file below the dtvem marker
+	if err := appendMarkerBlock(configFile, exportLine); err != nil {
+		return err
 	}
 
 	ui.Success("Added %s to PATH in %s", shimsDir, configFile)
@@ -0,0 +1,327 @@
+package path
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
+)
+
+// ShellEnvStateVar records the entries the last shell-integration
+// evaluation prepended to each variable, so the next evaluation can take
+// them out again before adding the entries for the new directory.
+const ShellEnvStateVar = "DTVEM_SHELL_ENV"
+
+// SupportedShells lists the shells accepted by --shell
+func SupportedShells() []string {
+	return []string{constants.ShellBash, constants.ShellZsh, constants.ShellFish, constants.ShellPwsh}
+}
+
+// NormalizeShell maps a shell name (or the name DetectShell returns) to
+// one of the supported shells
+func NormalizeShell(shell string) (string, error) {
+	switch strings.ToLower(strings.TrimSuffix(filepath.Base(strings.TrimSpace(shell)), constants.ExtExe)) {
+	case constants.ShellBash:
+		return constants.ShellBash, nil
+	case constants.ShellZsh:
+		return constants.ShellZsh, nil
+	case constants.ShellFish:
+		return constants.ShellFish, nil
+	case constants.ShellPwsh, "powershell":
+		return constants.ShellPwsh, nil
+	}
+	return "", fmt.Errorf("unsupported shell %q (supported: %s)", shell, strings.Join(SupportedShells(), ", "))
+}
+
+// EnvChange is one variable assignment produced by shell integration
+type EnvChange struct {
+	Name  string
+	Value string
+	Unset bool
+}
+
+// ShellEnvChanges computes the assignments that make the environment
+// reflect additions, the entries to prepend to each list variable (PATH,
+// LD_LIBRARY_PATH, ...). lookup reads the current environment.
+//
+// Entries added by the previous evaluation (recorded in ShellEnvStateVar)
+// are removed first, so evaluating on every prompt never grows PATH, and
+// leaving a project removes its versions again. Variables that only held
+// dtvem entries are unset. Changes are sorted by name.
+func ShellEnvChanges(lookup func(string) (string, bool), additions map[string][]string) []EnvChange {
+	sep := string(filepath.ListSeparator)
+
+	var previous map[string][]string
+	if state, ok := lookup(ShellEnvStateVar); ok && state != "" {
+		_ = json.Unmarshal([]byte(state), &previous)
+	}
+
+	names := make(map[string]struct{})
+	for name := range previous {
+		names[name] = struct{}{}
+	}
+	for name := range additions {
+		names[name] = struct{}{}
+	}
+
+	changes := make([]EnvChange, 0, len(names)+1)
+	for name := range names {
+		current, _ := lookup(name)
+		entries := withoutEntries(splitList(current), previous[name])
+		entries = append(append([]string{}, additions[name]...), entries...)
+
+		if len(entries) == 0 {
+			changes = append(changes, EnvChange{Name: name, Unset: true})
+			continue
+		}
+		changes = append(changes, EnvChange{Name: name, Value: strings.Join(entries, sep)})
+	}
+
+	recorded := make(map[string][]string)
+	for name, entries := range additions {
+		if len(entries) > 0 {
+			recorded[name] = entries
+		}
+	}
+	if len(recorded) > 0 {
+		state, _ := json.Marshal(recorded)
+		changes = append(changes, EnvChange{Name: ShellEnvStateVar, Value: string(state)})
+	} else if _, ok := lookup(ShellEnvStateVar); ok {
+		changes = append(changes, EnvChange{Name: ShellEnvStateVar, Unset: true})
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
+
+// splitList splits a list variable, dropping empty entries
+func splitList(value string) []string {
+	var entries []string
+	for _, entry := range strings.Split(value, string(filepath.ListSeparator)) {
+		if entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// withoutEntries removes one occurrence of each of remove from entries
+func withoutEntries(entries, remove []string) []string {
+	pending := make(map[string]int, len(remove))
+	for _, r := range remove {
+		pending[r]++
+	}
+	out := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if pending[entry] > 0 {
+			pending[entry]--
+			continue
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+// FormatShellEnv renders changes as commands for the given shell, one per line
+func FormatShellEnv(shell string, changes []EnvChange) (string, error) {
+	shell, err := NormalizeShell(shell)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for _, c := range changes {
+		switch shell {
+		case constants.ShellFish:
+			if c.Unset {
+				fmt.Fprintf(&b, "set -e %s;\n", c.Name)
+				continue
+			}
+			// Fish keeps PATH-like variables as lists
+			values := []string{c.Value}
+			if strings.HasSuffix(c.Name, "PATH") {
+				values = strings.Split(c.Value, string(filepath.ListSeparator))
+			}
+			quoted := make([]string, len(values))
+			for i, v := range values {
+				quoted[i] = quoteFish(v)
+			}
+			fmt.Fprintf(&b, "set -gx %s %s;\n", c.Name, strings.Join(quoted, " "))
+		case constants.ShellPwsh:
+			if c.Unset {
+				fmt.Fprintf(&b, "Remove-Item Env:%s -ErrorAction SilentlyContinue\n", c.Name)
+				continue
+			}
+			fmt.Fprintf(&b, "$env:%s = %s\n", c.Name, quotePwsh(c.Value))
+		default:
+			if c.Unset {
+				fmt.Fprintf(&b, "unset %s;\n", c.Name)
+				continue
+			}
+			fmt.Fprintf(&b, "export %s=%s;\n", c.Name, quotePosix(c.Value))
+		}
+	}
+	return b.String(), nil
+}
+
+// quotePosix single-quotes s for bash and zsh
+func quotePosix(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// quoteFish single-quotes s for fish
+func quoteFish(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
+
+// quotePwsh single-quotes s for PowerShell
+func quotePwsh(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// HookScript returns the script that hooks dtvemPath into the shell: it
+// evaluates `dtvem env` once when loaded and again before every prompt, so
+// changing directory (or pinning a new version) takes effect at the next
+// prompt.
+func HookScript(shell, dtvemPath string) (string, error) {
+	shell, err := NormalizeShell(shell)
+	if err != nil {
+		return "", err
+	}
+
+	switch shell {
+	case constants.ShellBash:
+		return fmt.Sprintf(`_dtvem_hook() {
+  local previous_exit_status=$?
+  eval "$(%s env --shell bash)"
+  return $previous_exit_status
+}
+if [[ ";${PROMPT_COMMAND:-};" != *";_dtvem_hook;"* ]]; then
+  PROMPT_COMMAND="_dtvem_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+fi
+_dtvem_hook
+`, quotePosix(dtvemPath)), nil
+	case constants.ShellZsh:
+		return fmt.Sprintf(`_dtvem_hook() {
+  eval "$(%s env --shell zsh)"
+}
+autoload -Uz add-zsh-hook
+add-zsh-hook precmd _dtvem_hook
+add-zsh-hook chpwd _dtvem_hook
+_dtvem_hook
+`, quotePosix(dtvemPath)), nil
+	case constants.ShellFish:
+		return fmt.Sprintf(`function _dtvem_hook --on-event fish_prompt --on-variable PWD
+    %s env --shell fish | source
+end
+_dtvem_hook
+`, quoteFish(dtvemPath)), nil
+	default:
+		return fmt.Sprintf(`function global:_dtvem_hook {
+    & %s env --shell pwsh | Out-String | Invoke-Expression
+}
+if (-not (Test-Path Function:\_dtvem_original_prompt)) {
+    Copy-Item Function:\prompt Function:\global:_dtvem_original_prompt
+    function global:prompt {
+        _dtvem_hook
+        _dtvem_original_prompt
+    }
+}
+_dtvem_hook
+`, quotePwsh(dtvemPath)), nil
+	}
+}
+
+// hookInitLine returns the line written to the shell config file that
+// loads the hook script
+func hookInitLine(shell, dtvemPath string) string {
+	switch shell {
+	case constants.ShellFish:
+		return fmt.Sprintf("%s hook --print --shell fish | source", quoteFish(dtvemPath))
+	case constants.ShellPwsh:
+		return fmt.Sprintf("& %s hook --print --shell pwsh | Out-String | Invoke-Expression", quotePwsh(dtvemPath))
+	default:
+		return fmt.Sprintf(`eval "$(%s hook --print --shell %s)"`, quotePosix(dtvemPath), shell)
+	}
+}
+
+// isHookInitLine reports whether a line below the dtvem marker loads the
+// shell hook, for any dtvem location
+func isHookInitLine(line string) bool {
+	return strings.Contains(line, " hook --print --shell ")
+}
+
+// HookConfigFile returns the startup file the hook is installed into
+func HookConfigFile(shell string) (string, error) {
+	shell, err := NormalizeShell(shell)
+	if err != nil {
+		return "", err
+	}
+
+	if shell == constants.ShellPwsh {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		// The path $PROFILE points at for the current user and host
+		if runtime.GOOS == constants.OSWindows {
+			return filepath.Join(home, "Documents", "PowerShell", "Microsoft.PowerShell_profile.ps1"), nil
+		}
+		return filepath.Join(home, ".config", "powershell", "Microsoft.PowerShell_profile.ps1"), nil
+	}
+
+	configFile := GetShellConfigFile(shell)
+	if configFile == "" {
+		return "", fmt.Errorf("could not determine config file for shell %s", shell)
+	}
+	return configFile, nil
+}
+
+// InstallShellHook adds the line that loads the hook to configFile. Any
+// hook line left by a dtvem at a different location is replaced. Returns
+// false if the hook was already installed.
+func InstallShellHook(configFile, shell, dtvemPath string) (bool, error) {
+	shell, err := NormalizeShell(shell)
+	if err != nil {
+		return false, err
+	}
+
+	line := hookInitLine(shell, dtvemPath)
+	if hasMarkerBlock(configFile, line) {
+		return false, nil
+	}
+
+	if _, err := UninstallShellHook(configFile); err != nil {
+		return false, err
+	}
+	if err := appendMarkerBlock(configFile, line); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// UninstallShellHook removes hook lines (and their marker comments) from
+// configFile, after backing it up. Returns the path of the backup, or "" if
+// there was nothing to remove.
+func UninstallShellHook(configFile string) (string, error) {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("read %s: %w", configFile, err)
+	}
+
+	newContent, removed := removeDtvemMarkerLines(string(data), isHookInitLine)
+	if len(removed) == 0 {
+		return "", nil
+	}
+
+	return writeShellConfig(configFile, newContent)
+}
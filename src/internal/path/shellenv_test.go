@@ -0,0 +1,233 @@
+package path
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
+)
+
+// envLookup returns a lookup function over a fixed environment
+func envLookup(env map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+}
+
+// applyChanges returns env with changes applied, as the shell would
+func applyChanges(env map[string]string, changes []EnvChange) map[string]string {
+	out := make(map[string]string, len(env))
+	for k, v := range env {
+		out[k] = v
+	}
+	for _, c := range changes {
+		if c.Unset {
+			delete(out, c.Name)
+			continue
+		}
+		out[c.Name] = c.Value
+	}
+	return out
+}
+
+func joinList(entries ...string) string {
+	return strings.Join(entries, string(filepath.ListSeparator))
+}
+
+func TestNormalizeShell(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"bash", constants.ShellBash, false},
+		{"/usr/bin/zsh", constants.ShellZsh, false},
+		{"FISH", constants.ShellFish, false},
+		{"pwsh.exe", constants.ShellPwsh, false},
+		{"powershell", constants.ShellPwsh, false},
+		{"cmd", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeShell(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.expected {
+			t.Errorf("NormalizeShell(%q) = %q, %v; want %q (error: %v)", tt.input, got, err, tt.expected, tt.wantErr)
+		}
+	}
+}
+
+func TestShellEnvChanges_ReplacesPreviousEntries(t *testing.T) {
+	env := map[string]string{"PATH": joinList("/usr/bin", "/bin")}
+
+	first := applyChanges(env, ShellEnvChanges(envLookup(env), map[string][]string{
+		"PATH": {"/dtvem/node/20/bin"},
+	}))
+	if got, want := first["PATH"], joinList("/dtvem/node/20/bin", "/usr/bin", "/bin"); got != want {
+		t.Fatalf("PATH after first evaluation = %q, want %q", got, want)
+	}
+	if first[ShellEnvStateVar] == "" {
+		t.Fatalf("%s was not set", ShellEnvStateVar)
+	}
+
+	// Evaluating again with the same versions must not grow PATH
+	again := applyChanges(first, ShellEnvChanges(envLookup(first), map[string][]string{
+		"PATH": {"/dtvem/node/20/bin"},
+	}))
+	if again["PATH"] != first["PATH"] {
+		t.Errorf("PATH after re-evaluation = %q, want %q", again["PATH"], first["PATH"])
+	}
+
+	// A different directory swaps the version
+	second := applyChanges(again, ShellEnvChanges(envLookup(again), map[string][]string{
+		"PATH": {"/dtvem/node/18/bin"},
+	}))
+	if got, want := second["PATH"], joinList("/dtvem/node/18/bin", "/usr/bin", "/bin"); got != want {
+		t.Errorf("PATH after switching versions = %q, want %q", got, want)
+	}
+
+	// Leaving every project restores the original environment
+	left := applyChanges(second, ShellEnvChanges(envLookup(second), nil))
+	if left["PATH"] != env["PATH"] {
+		t.Errorf("PATH after leaving = %q, want %q", left["PATH"], env["PATH"])
+	}
+	if _, ok := left[ShellEnvStateVar]; ok {
+		t.Errorf("%s should be unset when nothing is active", ShellEnvStateVar)
+	}
+}
+
+func TestShellEnvChanges_UnsetsVariablesOnlyDtvemSet(t *testing.T) {
+	env := map[string]string{"PATH": "/usr/bin"}
+
+	active := applyChanges(env, ShellEnvChanges(envLookup(env), map[string][]string{
+		"PATH":            {"/dtvem/ruby/3.3/bin"},
+		"LD_LIBRARY_PATH": {"/dtvem/ruby/3.3/lib"},
+	}))
+	if active["LD_LIBRARY_PATH"] != "/dtvem/ruby/3.3/lib" {
+		t.Fatalf("LD_LIBRARY_PATH = %q, want the ruby lib directory", active["LD_LIBRARY_PATH"])
+	}
+
+	changes := ShellEnvChanges(envLookup(active), nil)
+	var unset bool
+	for _, c := range changes {
+		if c.Name == "LD_LIBRARY_PATH" {
+			unset = c.Unset
+		}
+	}
+	if !unset {
+		t.Errorf("LD_LIBRARY_PATH should be unset, got changes %+v", changes)
+	}
+}
+
+func TestShellEnvChanges_KeepsUserEntries(t *testing.T) {
+	// The user added the same directory themselves after dtvem did; only
+	// dtvem's copy is removed
+	env := map[string]string{
+		"PATH":           joinList("/dtvem/node/20/bin", "/usr/bin", "/dtvem/node/20/bin"),
+		ShellEnvStateVar: `{"PATH":["/dtvem/node/20/bin"]}`,
+	}
+
+	result := applyChanges(env, ShellEnvChanges(envLookup(env), nil))
+	if got, want := result["PATH"], joinList("/usr/bin", "/dtvem/node/20/bin"); got != want {
+		t.Errorf("PATH = %q, want %q", got, want)
+	}
+}
+
+func TestFormatShellEnv(t *testing.T) {
+	changes := []EnvChange{
+		{Name: "DTVEM_SHELL_ENV", Unset: true},
+		{Name: "PATH", Value: joinList("/a b", "/c")},
+		{Name: "RUBYOPT", Value: "it's"},
+	}
+
+	tests := []struct {
+		shell    string
+		contains []string
+	}{
+		{constants.ShellBash, []string{"unset DTVEM_SHELL_ENV;", "export PATH='" + joinList("/a b", "/c") + "';", `export RUBYOPT='it'\''s';`}},
+		{constants.ShellZsh, []string{"unset DTVEM_SHELL_ENV;"}},
+		{constants.ShellFish, []string{"set -e DTVEM_SHELL_ENV;", "set -gx PATH '/a b' '/c';", `set -gx RUBYOPT 'it\'s';`}},
+		{constants.ShellPwsh, []string{"Remove-Item Env:DTVEM_SHELL_ENV", "$env:PATH = '" + joinList("/a b", "/c") + "'", "$env:RUBYOPT = 'it''s'"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			got, err := FormatShellEnv(tt.shell, changes)
+			if err != nil {
+				t.Fatalf("FormatShellEnv failed: %v", err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("output missing %q:\n%s", want, got)
+				}
+			}
+		})
+	}
+
+	if _, err := FormatShellEnv("cmd", changes); err == nil {
+		t.Error("FormatShellEnv should reject unsupported shells")
+	}
+}
+
+func TestHookScript(t *testing.T) {
+	for _, shell := range SupportedShells() {
+		script, err := HookScript(shell, "/opt/dtvem/dtvem")
+		if err != nil {
+			t.Fatalf("HookScript(%s) failed: %v", shell, err)
+		}
+		if !strings.Contains(script, "env --shell "+shell) {
+			t.Errorf("HookScript(%s) does not evaluate dtvem env:\n%s", shell, script)
+		}
+	}
+}
+
+func TestInstallShellHook(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), ".zshrc")
+	original := "export EDITOR=vim\n"
+	if err := os.WriteFile(configFile, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	installed, err := InstallShellHook(configFile, constants.ShellZsh, "/opt/dtvem/dtvem")
+	if err != nil || !installed {
+		t.Fatalf("InstallShellHook = %v, %v; want installed", installed, err)
+	}
+
+	installed, err = InstallShellHook(configFile, constants.ShellZsh, "/opt/dtvem/dtvem")
+	if err != nil || installed {
+		t.Errorf("second InstallShellHook = %v, %v; want already installed", installed, err)
+	}
+
+	// Moving dtvem replaces the old line instead of adding a second one
+	if _, err := InstallShellHook(configFile, constants.ShellZsh, "/usr/local/bin/dtvem"); err != nil {
+		t.Fatalf("InstallShellHook failed: %v", err)
+	}
+	data, _ := os.ReadFile(configFile)
+	if strings.Count(string(data), "hook --print") != 1 || !strings.Contains(string(data), "/usr/local/bin/dtvem") {
+		t.Errorf("config should hold one hook line for the new location:\n%s", data)
+	}
+
+	backup, err := UninstallShellHook(configFile)
+	if err != nil || backup == "" {
+		t.Fatalf("UninstallShellHook = %q, %v; want removed", backup, err)
+	}
+	if saved, _ := os.ReadFile(backup); !bytes.Equal(saved, data) {
+		t.Errorf("backup %s does not hold the original config:\n%s", backup, saved)
+	}
+	data, _ = os.ReadFile(configFile)
+	if strings.Contains(string(data), "hook --print") || strings.Contains(string(data), dtvemMarkerComment) {
+		t.Errorf("hook was not removed:\n%s", data)
+	}
+	if !strings.Contains(string(data), "export EDITOR=vim") {
+		t.Errorf("user content was lost:\n%s", data)
+	}
+
+	backup, err = UninstallShellHook(configFile)
+	if err != nil || backup != "" {
+		t.Errorf("second UninstallShellHook = %q, %v; want nothing removed", backup, err)
+	}
+}
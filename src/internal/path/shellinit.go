@@ -0,0 +1,294 @@
+package path
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
+)
+
+// ShellInit describes what a version manager adds to shell startup files
+// to load itself, so dtvem migrate can take it out again after migrating.
+type ShellInit struct {
+	// Manager names the version manager; providers of one multi-runtime
+	// manager (e.g., asdf) share it
+	Manager string
+	// Lines match single lines, such as `eval "$(pyenv init -)"`. Only lines
+	// that start in the first column are removed, so a line inside an
+	// if/fi block is never taken out of it.
+	Lines []*regexp.Regexp
+	// Blocks are marker comments around a whole section, such as conda's
+	// "# >>> conda initialize >>>"; both markers and everything between them
+	// are removed
+	Blocks []MarkerBlock
+}
+
+// MarkerBlock is a section of a startup file between two marker comments
+type MarkerBlock struct {
+	Begin string
+	End   string
+}
+
+// ShellStartupFiles returns the existing startup files of the supported
+// shells in the user's home directory
+func ShellStartupFiles() []string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+
+	candidates := []string{
+		filepath.Join(home, ".bashrc"),
+		filepath.Join(home, ".bash_profile"),
+		filepath.Join(home, ".profile"),
+		filepath.Join(home, ".zshrc"),
+		filepath.Join(home, ".zprofile"),
+		filepath.Join(home, ".config", "fish", "config.fish"),
+	}
+	if runtime.GOOS == constants.OSWindows {
+		candidates = append(candidates, filepath.Join(home, "Documents", "PowerShell", "Microsoft.PowerShell_profile.ps1"))
+	} else {
+		candidates = append(candidates, filepath.Join(home, ".config", "powershell", "Microsoft.PowerShell_profile.ps1"))
+	}
+
+	var files []string
+	for _, file := range candidates {
+		if info, err := os.Stat(file); err == nil && info.Mode().IsRegular() {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// ShellInitEdit describes the changes RemoveShellInit makes to a startup
+// file
+type ShellInitEdit struct {
+	// Removed are the lines taken out whole, marker blocks included
+	Removed []string
+	// Rewritten are PATH lines that also add unrelated entries; only the
+	// version manager's entries are taken out of them
+	Rewritten []LineEdit
+	// Skipped are lines that load the version manager together with other
+	// commands (e.g., joined with ; or &&). They are left in place for the
+	// user to edit.
+	Skipped []string
+	// Backup is the copy of the original file, when it was changed
+	Backup string
+}
+
+// LineEdit is a line replaced by a new version of it
+type LineEdit struct {
+	Old string
+	New string
+}
+
+// Changed reports whether the edit changes the file
+func (e ShellInitEdit) Changed() bool {
+	return len(e.Removed) > 0 || len(e.Rewritten) > 0
+}
+
+// FindShellInit returns the changes RemoveShellInit would make to
+// configFile, without making them
+func FindShellInit(configFile string, init ShellInit) (ShellInitEdit, error) {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ShellInitEdit{}, nil
+		}
+		return ShellInitEdit{}, fmt.Errorf("read %s: %w", configFile, err)
+	}
+	_, edit := removeShellInit(string(data), init)
+	return edit, nil
+}
+
+// RemoveShellInit removes a version manager's init lines, marker blocks
+// and PATH entries from configFile. The original is first copied to a
+// backup next to it (see ShellInitEdit.Backup). If there is nothing to
+// change, the file is left alone and no backup is made.
+func RemoveShellInit(configFile string, init ShellInit) (ShellInitEdit, error) {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ShellInitEdit{}, nil
+		}
+		return ShellInitEdit{}, fmt.Errorf("read %s: %w", configFile, err)
+	}
+
+	newContent, edit := removeShellInit(string(data), init)
+	if !edit.Changed() {
+		return edit, nil
+	}
+
+	edit.Backup, err = writeShellConfig(configFile, newContent)
+	return edit, err
+}
+
+// removeShellInit drops the marker blocks and lines of configContent that
+// init matches and returns the rewritten content with what was changed.
+// Like removeMarkerBlocks, it errs on the side of keeping content: a block
+// whose end marker is missing is kept whole, and so is a matching line
+// that also runs other commands.
+func removeShellInit(configContent string, init ShellInit) (string, ShellInitEdit) {
+	var edit ShellInitEdit
+
+	content := configContent
+	for _, block := range init.Blocks {
+		content, _ = removeMarkerBlocks(content, block, func(body []string) bool {
+			edit.Removed = append(edit.Removed, block.Begin)
+			edit.Removed = append(edit.Removed, body...)
+			edit.Removed = append(edit.Removed, block.End)
+			return true
+		})
+	}
+
+	lines := strings.Split(content, "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if !matchesInitLine(line, init.Lines) {
+			out = append(out, line)
+			continue
+		}
+
+		if newLine, ok := removePathEntries(line, init.Lines); ok {
+			if newLine == "" {
+				edit.Removed = append(edit.Removed, line)
+				continue
+			}
+			edit.Rewritten = append(edit.Rewritten, LineEdit{Old: line, New: newLine})
+			out = append(out, newLine)
+			continue
+		}
+
+		if !onlyLoadsManager(line, init.Lines) {
+			edit.Skipped = append(edit.Skipped, line)
+			out = append(out, line)
+			continue
+		}
+		edit.Removed = append(edit.Removed, line)
+	}
+
+	return strings.Join(out, "\n"), edit
+}
+
+// matchesInitLine reports whether an unindented line matches one of the
+// patterns
+func matchesInitLine(line string, patterns []*regexp.Regexp) bool {
+	if line == "" || line[0] == ' ' || line[0] == '\t' {
+		return false
+	}
+	return matchesAny(line, patterns)
+}
+
+// matchesAny reports whether s matches one of the patterns
+func matchesAny(s string, patterns []*regexp.Regexp) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// commandSeparator splits a line into the commands it runs
+var commandSeparator = regexp.MustCompile(`;|&&|\|\|`)
+
+// onlyLoadsManager reports whether every command on a line belongs to the
+// version manager, e.g. the line nvm's installer writes:
+//
+//	[ -s "$NVM_DIR/nvm.sh" ] && \. "$NVM_DIR/nvm.sh"
+//
+// A PATH assignment among them must not add anything else.
+func onlyLoadsManager(line string, patterns []*regexp.Regexp) bool {
+	for _, command := range commandSeparator.Split(line, -1) {
+		command = strings.TrimSpace(command)
+		if command == "" {
+			continue
+		}
+		if !matchesAny(command, patterns) {
+			return false
+		}
+		if isPathAssignment(command) {
+			if newLine, ok := removePathEntries(command, patterns); !ok || newLine != "" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+var (
+	// posixPathLine matches PATH assignments in sh-style shells:
+	// export PATH="$HOME/.asdf/shims:$PATH"
+	posixPathLine = regexp.MustCompile(`^((?:export\s+)?PATH=)(["']?)([^"';&|]*)(["']?)\s*$`)
+	// fishPathLine matches fish PATH updates:
+	// set -gx PATH $HOME/.rbenv/bin $PATH, fish_add_path $HOME/.pyenv/bin
+	fishPathLine = regexp.MustCompile(`^((?:set\s+(?:-[a-zA-Z]+\s+)*PATH|fish_add_path(?:\s+-[a-zA-Z]+)*)\s+)([^;&|]*?)\s*$`)
+	// pwshPathLine matches PowerShell PATH assignments:
+	// $env:PATH = "$HOME/.volta/bin;$env:PATH"
+	pwshPathLine = regexp.MustCompile(`(?i)^(\$env:PATH\s*=\s*)(["'])([^"']*)(["'])\s*$`)
+)
+
+// isPathAssignment reports whether line sets PATH in one of the supported
+// shells
+func isPathAssignment(line string) bool {
+	return posixPathLine.MatchString(line) || fishPathLine.MatchString(line) || pwshPathLine.MatchString(line)
+}
+
+// removePathEntries takes the entries that match one of the patterns out of
+// a PATH assignment. ok is false if line isn't a PATH assignment or none
+// of its entries match as a whole. newLine is empty when nothing but the
+// existing PATH would be left.
+func removePathEntries(line string, patterns []*regexp.Regexp) (newLine string, ok bool) {
+	if m := posixPathLine.FindStringSubmatch(line); m != nil && m[2] == m[4] {
+		return rebuildPathLine(m[1]+m[2], m[4], m[3], ":", patterns)
+	}
+	if m := pwshPathLine.FindStringSubmatch(line); m != nil && m[2] == m[4] {
+		separator := ":"
+		if strings.Contains(m[3], ";") {
+			separator = ";"
+		}
+		return rebuildPathLine(m[1]+m[2], m[4], m[3], separator, patterns)
+	}
+	if m := fishPathLine.FindStringSubmatch(line); m != nil {
+		return rebuildPathLine(m[1], "", strings.Join(strings.Fields(m[2]), " "), " ", patterns)
+	}
+	return "", false
+}
+
+// rebuildPathLine drops the matching entries from a PATH value and puts the
+// line back together around what is left
+func rebuildPathLine(prefix, suffix, value, separator string, patterns []*regexp.Regexp) (string, bool) {
+	var kept []string
+	dropped := false
+	for _, entry := range strings.Split(value, separator) {
+		if matchesAny(entry, patterns) {
+			dropped = true
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	if !dropped {
+		return "", false
+	}
+
+	for _, entry := range kept {
+		if !isPathReference(entry) {
+			return prefix + strings.Join(kept, separator) + suffix, true
+		}
+	}
+	return "", true
+}
+
+// isPathReference reports whether a PATH entry is the existing PATH
+// ($PATH, ${PATH}, $env:PATH) or empty
+func isPathReference(entry string) bool {
+	switch strings.ToLower(strings.TrimSpace(entry)) {
+	case "", "$path", "${path}", "$env:path":
+		return true
+	}
+	return false
+}
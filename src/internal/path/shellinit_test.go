@@ -0,0 +1,187 @@
+package path
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var testShellInit = ShellInit{
+	Manager: "pyenv",
+	Lines: []*regexp.Regexp{
+		regexp.MustCompile(`PYENV_ROOT`),
+		regexp.MustCompile(`pyenv init`),
+		regexp.MustCompile(`\.pyenv/shims`),
+	},
+	Blocks: []MarkerBlock{
+		{Begin: "# >>> conda initialize >>>", End: "# <<< conda initialize <<<"},
+	},
+}
+
+func TestRemoveShellInit_Content(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		want          string
+		wantRemoved   int
+		wantRewritten int
+		wantSkipped   int
+	}{
+		{
+			name:        "init lines",
+			content:     "alias ll='ls -l'\nexport PYENV_ROOT=\"$HOME/.pyenv\"\neval \"$(pyenv init -)\"\nexport EDITOR=vim\n",
+			want:        "alias ll='ls -l'\nexport EDITOR=vim\n",
+			wantRemoved: 2,
+		},
+		{
+			name:    "indented lines are kept",
+			content: "if command -v pyenv >/dev/null; then\n  eval \"$(pyenv init -)\"\nfi\n",
+			want:    "if command -v pyenv >/dev/null; then\n  eval \"$(pyenv init -)\"\nfi\n",
+		},
+		{
+			name:        "marker block",
+			content:     "export A=1\n# >>> conda initialize >>>\n  __conda_setup=\"$('/opt/conda/bin/conda' 'shell.bash' 'hook')\"\n  eval \"$__conda_setup\"\n# <<< conda initialize <<<\nexport B=2\n",
+			want:        "export A=1\nexport B=2\n",
+			wantRemoved: 4,
+		},
+		{
+			name:    "marker block without end is kept",
+			content: "# >>> conda initialize >>>\neval \"$__conda_setup\"\n",
+			want:    "# >>> conda initialize >>>\neval \"$__conda_setup\"\n",
+		},
+		{
+			name:        "PATH line with only manager entries",
+			content:     "export PATH=\"$PYENV_ROOT/bin:$PATH\"\n",
+			want:        "",
+			wantRemoved: 1,
+		},
+		{
+			name:          "PATH entry among unrelated entries",
+			content:       "export PATH=\"$HOME/.pyenv/shims:$HOME/bin:$PATH\"\n",
+			want:          "export PATH=\"$HOME/bin:$PATH\"\n",
+			wantRewritten: 1,
+		},
+		{
+			name:          "fish PATH",
+			content:       "set -gx PATH $PYENV_ROOT/bin $HOME/bin $PATH\n",
+			want:          "set -gx PATH $HOME/bin $PATH\n",
+			wantRewritten: 1,
+		},
+		{
+			name:          "PowerShell PATH",
+			content:       "$env:PATH = \"$HOME/.pyenv/shims;C:\\tools;$env:PATH\"\n",
+			want:          "$env:PATH = \"C:\\tools;$env:PATH\"\n",
+			wantRewritten: 1,
+		},
+		{
+			name:        "commands that all load the manager",
+			content:     "[ -d \"$PYENV_ROOT/bin\" ] && export PATH=\"$PYENV_ROOT/bin:$PATH\"\n",
+			want:        "",
+			wantRemoved: 1,
+		},
+		{
+			name:        "line that also runs other commands",
+			content:     "export PYENV_ROOT=\"$HOME/.pyenv\"; export EDITOR=vim\n",
+			want:        "export PYENV_ROOT=\"$HOME/.pyenv\"; export EDITOR=vim\n",
+			wantSkipped: 1,
+		},
+		{
+			name:    "nothing to remove",
+			content: "export PATH=\"$HOME/bin:$PATH\"\n",
+			want:    "export PATH=\"$HOME/bin:$PATH\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, edit := removeShellInit(tt.content, testShellInit)
+			if got != tt.want {
+				t.Errorf("removeShellInit() content = %q, want %q", got, tt.want)
+			}
+			if len(edit.Removed) != tt.wantRemoved {
+				t.Errorf("removed %d lines %q, want %d", len(edit.Removed), edit.Removed, tt.wantRemoved)
+			}
+			if len(edit.Rewritten) != tt.wantRewritten {
+				t.Errorf("rewrote %d lines %+v, want %d", len(edit.Rewritten), edit.Rewritten, tt.wantRewritten)
+			}
+			if len(edit.Skipped) != tt.wantSkipped {
+				t.Errorf("skipped %d lines %q, want %d", len(edit.Skipped), edit.Skipped, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+func TestRemoveShellInit_File(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), ".bashrc")
+	original := "export EDITOR=vim\neval \"$(pyenv init -)\"\n"
+	if err := os.WriteFile(configFile, []byte(original), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := FindShellInit(configFile, testShellInit)
+	if err != nil || len(found.Removed) != 1 {
+		t.Fatalf("FindShellInit() = %+v, %v; want 1 line", found, err)
+	}
+
+	edit, err := RemoveShellInit(configFile, testShellInit)
+	if err != nil {
+		t.Fatalf("RemoveShellInit() error = %v", err)
+	}
+	if len(edit.Removed) != 1 || !strings.Contains(edit.Removed[0], "pyenv init") {
+		t.Errorf("RemoveShellInit() removed = %q", edit.Removed)
+	}
+
+	data, _ := os.ReadFile(configFile)
+	if string(data) != "export EDITOR=vim\n" {
+		t.Errorf("config file = %q", data)
+	}
+	info, _ := os.Stat(configFile)
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("config file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	backupData, err := os.ReadFile(edit.Backup)
+	if err != nil {
+		t.Fatalf("backup not readable: %v", err)
+	}
+	if string(backupData) != original {
+		t.Errorf("backup = %q, want %q", backupData, original)
+	}
+
+	// A second run has nothing left to remove and makes no backup
+	edit, err = RemoveShellInit(configFile, testShellInit)
+	if err != nil || edit.Changed() || edit.Backup != "" {
+		t.Errorf("second RemoveShellInit() = %+v, %v; want nothing", edit, err)
+	}
+}
+
+func TestRemoveShellInit_Symlink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "dotfiles-bashrc")
+	if err := os.WriteFile(target, []byte("eval \"$(pyenv init -)\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	configFile := filepath.Join(dir, ".bashrc")
+	if err := os.Symlink(target, configFile); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	if _, err := RemoveShellInit(configFile, testShellInit); err != nil {
+		t.Fatalf("RemoveShellInit() error = %v", err)
+	}
+	if info, err := os.Lstat(configFile); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("config file is no longer a symlink: %v", err)
+	}
+	if data, _ := os.ReadFile(target); string(data) != "" {
+		t.Errorf("symlink target = %q, want the edited content", data)
+	}
+}
+
+func TestRemoveShellInit_MissingFile(t *testing.T) {
+	edit, err := RemoveShellInit(filepath.Join(t.TempDir(), ".zshrc"), testShellInit)
+	if err != nil || edit.Changed() || edit.Backup != "" {
+		t.Errorf("RemoveShellInit() = %+v, %v; want nothing", edit, err)
+	}
+}
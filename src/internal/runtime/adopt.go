@@ -0,0 +1,257 @@
+//go:build !shim
+
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+)
+
+// defaultSmokeTestArgs validate an adopted version of a runtime whose
+// provider does not implement Verifier
+var defaultSmokeTestArgs = []string{"--version"}
+
+// Adopt installs a version from an existing installation of it, such as a
+// pyenv build with custom compile flags, instead of downloading it.
+// sourceDir is the root of the installation (the directory holding bin/).
+//
+// By default the tree is copied and the shebangs of scripts in its
+// executable directories are rewritten to the new location. With link, the
+// version directory references the original tree through symbolic links
+// instead, so it keeps working only as long as the original does.
+//
+// The adopted version is prepared by the provider (see Adopter) and its main
+// executable is run before Adopt returns. Like BeginInstall, the returned
+// installation still has to be committed, after the caller creates shims;
+// on error, everything Adopt created is removed.
+func Adopt(provider Provider, version, sourceDir string, link bool) (*Installation, error) {
+	sourceDir, err := filepath.Abs(sourceDir)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(sourceDir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", sourceDir)
+	}
+
+	inst, err := BeginInstall(provider.Name(), version)
+	if err != nil {
+		return nil, err
+	}
+	if err := adoptInto(inst, provider, sourceDir, link); err != nil {
+		inst.Rollback()
+		return nil, err
+	}
+	return inst, nil
+}
+
+// adoptInto puts sourceDir in place as the installation's version and
+// validates it
+func adoptInto(inst *Installation, provider Provider, sourceDir string, link bool) error {
+	var err error
+	if link {
+		err = linkTree(sourceDir, inst.StagingDir)
+	} else {
+		err = copyTree(sourceDir, inst.StagingDir)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to adopt %s: %w", sourceDir, err)
+	}
+
+	if err := inst.Promote(inst.StagingDir); err != nil {
+		return err
+	}
+
+	if !link {
+		if err := rewriteShebangs(inst.InstallPath, sourceDir); err != nil {
+			return fmt.Errorf("failed to rewrite shebangs: %w", err)
+		}
+	}
+
+	if adopter, ok := provider.(Adopter); ok {
+		if err := adopter.PrepareAdopted(inst.Version, link); err != nil {
+			return err
+		}
+	}
+
+	args := defaultSmokeTestArgs
+	if verifier, ok := provider.(Verifier); ok {
+		args = verifier.SmokeTestArgs()
+	}
+	if err := SmokeTest(provider, inst.Version, args); err != nil {
+		return fmt.Errorf("adopted %s %s does not run: %w", provider.DisplayName(), inst.Version, err)
+	}
+	return nil
+}
+
+// adoptedExecutableDirs are the directories of an installation that hold
+// its executables; linkTree gives them a real directory of links so the
+// provider and later reshims can add to them without touching the original
+var adoptedExecutableDirs = map[string]bool{"bin": true, "Scripts": true}
+
+// linkTree populates destDir with symbolic links to the entries of
+// sourceDir. Executable directories are recreated with links to each file.
+func linkTree(sourceDir, destDir string) error {
+	entries, err := os.ReadDir(sourceDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		source := filepath.Join(sourceDir, entry.Name())
+		dest := filepath.Join(destDir, entry.Name())
+		if !entry.IsDir() || !adoptedExecutableDirs[entry.Name()] {
+			if err := os.Symlink(source, dest); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := os.Mkdir(dest, 0755); err != nil {
+			return err
+		}
+		files, err := os.ReadDir(source)
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			if err := os.Symlink(filepath.Join(source, file.Name()), filepath.Join(dest, file.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// copyTree copies sourceDir into destDir, preserving file modes and
+// symbolic links. Absolute links into sourceDir are kept as they are; the
+// original tree still exists while the copy is validated, and installs
+// rarely use them.
+func copyTree(sourceDir, destDir string) error {
+	return filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return err
+		}
+		if rel == config.InstallMarkerFileName || rel == config.FileManifestFileName {
+			return nil
+		}
+		dest := filepath.Join(destDir, rel)
+
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(target, dest)
+		case info.IsDir():
+			return os.MkdirAll(dest, info.Mode().Perm()|0700)
+		case info.Mode().IsRegular():
+			return copyRegularFile(path, dest, info.Mode().Perm())
+		default:
+			ui.Debug("Skipping special file %s", path)
+			return nil
+		}
+	})
+}
+
+// copyRegularFile copies one file, creating it with mode
+func copyRegularFile(source, dest string, mode os.FileMode) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode|0200)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// shebangHeaderLines is how many lines at the top of a script may name its
+// interpreter: the shebang itself, plus the exec line of the /bin/sh
+// trampoline pip writes when the interpreter path is too long for one
+const shebangHeaderLines = 2
+
+// rewriteShebangs points the scripts in an adopted copy's executable
+// directories at the copy instead of the original installation at oldRoot
+func rewriteShebangs(installPath, oldRoot string) error {
+	for dir := range adoptedExecutableDirs {
+		entries, err := os.ReadDir(filepath.Join(installPath, dir))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.Type().IsRegular() {
+				continue
+			}
+			path := filepath.Join(installPath, dir, entry.Name())
+			changed, err := rewriteShebang(path, oldRoot, installPath)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			if changed {
+				ui.Debug("Rewrote shebang of %s", path)
+			}
+		}
+	}
+	return nil
+}
+
+// rewriteShebang replaces paths under oldRoot with the same paths under
+// newRoot in the header of a script.
+// Files that don't start with #! are left alone.
+func rewriteShebang(path, oldRoot, newRoot string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	reader := bufio.NewReader(f)
+	magic, err := reader.Peek(2)
+	if err != nil || string(magic) != "#!" {
+		_ = f.Close()
+		return false, nil
+	}
+
+	var header bytes.Buffer
+	for i := 0; i < shebangHeaderLines; i++ {
+		line, err := reader.ReadString('\n')
+		header.WriteString(line)
+		if err != nil {
+			break
+		}
+	}
+	oldPrefix := oldRoot + string(filepath.Separator)
+	if !strings.Contains(header.String(), oldPrefix) {
+		_ = f.Close()
+		return false, nil
+	}
+	rest, err := io.ReadAll(reader)
+	_ = f.Close()
+	if err != nil {
+		return false, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	data := append([]byte(strings.ReplaceAll(header.String(), oldPrefix, newRoot+string(filepath.Separator))), rest...)
+	return true, os.WriteFile(path, data, info.Mode().Perm())
+}
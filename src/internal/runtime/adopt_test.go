@@ -0,0 +1,207 @@
+//go:build !shim
+
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+)
+
+// adoptProvider runs bin/tool of an installed version as its smoke test
+type adoptProvider struct {
+	mockProvider
+}
+
+func (p *adoptProvider) ExecutablePath(version string) (string, error) {
+	return filepath.Join(config.RuntimeVersionPath(p.name, version), "bin", "tool"), nil
+}
+
+// writeAdoptSource creates an installation to adopt. bin/tool exits with
+// exitCode, and bin/script names the installation in its shebang.
+func writeAdoptSource(t *testing.T, exitCode string) string {
+	t.Helper()
+	if goruntime.GOOS == "windows" {
+		t.Skip("adopt tests use shell scripts and symbolic links")
+	}
+
+	source := filepath.Join(t.TempDir(), "20.11.0")
+	files := map[string]string{
+		"bin/tool":   "#!/bin/sh\nexit " + exitCode + "\n",
+		"bin/script": "#!" + filepath.Join(source, "bin", "tool") + "\necho hi\n",
+		"lib/data":   "data",
+	}
+	for rel, content := range files {
+		path := filepath.Join(source, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink("data", filepath.Join(source, "lib", "link")); err != nil {
+		t.Fatal(err)
+	}
+	return source
+}
+
+func TestAdopt_Copy(t *testing.T) {
+	setupInstallationTest(t)
+	source := writeAdoptSource(t, "0")
+	provider := &adoptProvider{mockProvider{name: "node", displayName: "Node.js"}}
+
+	inst, err := Adopt(provider, "20.11.0", source, false)
+	if err != nil {
+		t.Fatalf("Adopt() error = %v", err)
+	}
+	if err := inst.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	if !config.IsVersionInstalled("node", "20.11.0") {
+		t.Error("adopted version is not installed")
+	}
+
+	script, err := os.ReadFile(filepath.Join(inst.InstallPath, "bin", "script"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "#!" + filepath.Join(inst.InstallPath, "bin", "tool") + "\necho hi\n"
+	if string(script) != expected {
+		t.Errorf("bin/script = %q, want %q", script, expected)
+	}
+
+	info, err := os.Lstat(filepath.Join(inst.InstallPath, "lib"))
+	if err != nil || !info.IsDir() {
+		t.Errorf("lib was not copied as a directory")
+	}
+	if target, err := os.Readlink(filepath.Join(inst.InstallPath, "lib", "link")); err != nil || target != "data" {
+		t.Errorf("lib/link = %q, %v; want relative link to data", target, err)
+	}
+
+	// The original installation is untouched
+	original, _ := os.ReadFile(filepath.Join(source, "bin", "script"))
+	if !strings.Contains(string(original), source) {
+		t.Errorf("original bin/script was modified: %q", original)
+	}
+}
+
+func TestAdopt_Link(t *testing.T) {
+	setupInstallationTest(t)
+	source := writeAdoptSource(t, "0")
+	provider := &adoptProvider{mockProvider{name: "node", displayName: "Node.js"}}
+
+	inst, err := Adopt(provider, "20.11.0", source, true)
+	if err != nil {
+		t.Fatalf("Adopt() error = %v", err)
+	}
+	if err := inst.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	if target, err := os.Readlink(filepath.Join(inst.InstallPath, "lib")); err != nil || target != filepath.Join(source, "lib") {
+		t.Errorf("lib = %q, %v; want link to the original", target, err)
+	}
+	info, err := os.Lstat(filepath.Join(inst.InstallPath, "bin"))
+	if err != nil || !info.IsDir() {
+		t.Fatal("bin should be a real directory")
+	}
+	if target, err := os.Readlink(filepath.Join(inst.InstallPath, "bin", "tool")); err != nil || target != filepath.Join(source, "bin", "tool") {
+		t.Errorf("bin/tool = %q, %v; want link to the original", target, err)
+	}
+
+	// Removing the adopted version leaves the original in place
+	if err := RemoveInstall("node", "20.11.0"); err != nil {
+		t.Fatalf("RemoveInstall() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(source, "bin", "tool")); err != nil {
+		t.Errorf("original installation was removed: %v", err)
+	}
+}
+
+func TestAdopt_FailedSmokeTestRollsBack(t *testing.T) {
+	setupInstallationTest(t)
+	source := writeAdoptSource(t, "1")
+	provider := &adoptProvider{mockProvider{name: "node", displayName: "Node.js"}}
+
+	for _, link := range []bool{false, true} {
+		if _, err := Adopt(provider, "20.11.0", source, link); err == nil {
+			t.Fatalf("Adopt(link=%v) should fail when the executable fails", link)
+		}
+		if _, err := os.Stat(config.RuntimeVersionPath("node", "20.11.0")); !os.IsNotExist(err) {
+			t.Errorf("Adopt(link=%v) left the version directory behind", link)
+		}
+		if _, err := os.Stat(filepath.Join(source, "lib", "data")); err != nil {
+			t.Errorf("Adopt(link=%v) rollback removed the original: %v", link, err)
+		}
+	}
+}
+
+func TestRewriteShebang(t *testing.T) {
+	oldRoot := filepath.Join("/old", "python", "3.12.1")
+	newRoot := filepath.Join("/new", "python", "3.12.1")
+
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+		changed  bool
+	}{
+		{
+			name:     "shebang",
+			content:  "#!" + oldRoot + "/bin/python3.12\nimport sys\n",
+			expected: "#!" + newRoot + "/bin/python3.12\nimport sys\n",
+			changed:  true,
+		},
+		{
+			name:     "sh trampoline",
+			content:  "#!/bin/sh\n'''exec' " + oldRoot + "/bin/python3 \"$0\" \"$@\"\n' '''\n",
+			expected: "#!/bin/sh\n'''exec' " + newRoot + "/bin/python3 \"$0\" \"$@\"\n' '''\n",
+			changed:  true,
+		},
+		{
+			name:     "paths after the header are left alone",
+			content:  "#!/bin/sh\necho\n" + oldRoot + "/bin/python3\n",
+			expected: "#!/bin/sh\necho\n" + oldRoot + "/bin/python3\n",
+		},
+		{
+			name:     "other installation",
+			content:  "#!" + oldRoot + "0/bin/python3\n",
+			expected: "#!" + oldRoot + "0/bin/python3\n",
+		},
+		{
+			name:     "binary",
+			content:  "\x7fELF" + oldRoot + "/",
+			expected: "\x7fELF" + oldRoot + "/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "script")
+			if err := os.WriteFile(path, []byte(tt.content), 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			changed, err := rewriteShebang(path, oldRoot, newRoot)
+			if err != nil {
+				t.Fatalf("rewriteShebang() error = %v", err)
+			}
+			if changed != tt.changed {
+				t.Errorf("rewriteShebang() changed = %v, want %v", changed, tt.changed)
+			}
+			data, _ := os.ReadFile(path)
+			if string(data) != tt.expected {
+				t.Errorf("content = %q, want %q", data, tt.expected)
+			}
+			if info, _ := os.Stat(path); info.Mode().Perm() != 0755 {
+				t.Errorf("mode = %v, want 0755", info.Mode().Perm())
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package runtime
+
+import (
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
+)
+
+// MergeEnvironment merges provider environment variables into the base environment.
+// Provider variables are prepended to existing values (for PATH-like variables) or set directly.
+//
+// Variable names are matched case-insensitively on Windows, where the
+// environment commonly holds "Path" rather than "PATH".
+func MergeEnvironment(baseEnv []string, providerEnv map[string]string) []string {
+	if len(providerEnv) == 0 {
+		return baseEnv
+	}
+
+	// Build a map of existing environment variables for easy lookup
+	envMap := make(map[string]string)
+	for _, e := range baseEnv {
+		if idx := strings.Index(e, "="); idx != -1 {
+			key := e[:idx]
+			value := e[idx+1:]
+			envMap[key] = value
+		}
+	}
+
+	// Apply provider environment variables
+	// For PATH-like variables (LD_LIBRARY_PATH, DYLD_LIBRARY_PATH), prepend the new value
+	for key, value := range providerEnv {
+		key = existingEnvKey(envMap, key)
+		if existing, ok := envMap[key]; ok && existing != "" {
+			// Prepend new value to existing (for PATH-like variables)
+			envMap[key] = value + string(filepath.ListSeparator) + existing
+		} else {
+			envMap[key] = value
+		}
+	}
+
+	// Convert back to slice format
+	result := make([]string, 0, len(envMap))
+	for key, value := range envMap {
+		result = append(result, key+"="+value)
+	}
+
+	return result
+}
+
+// existingEnvKey returns the spelling of key already used in envMap. Only
+// Windows treats variable names case-insensitively.
+func existingEnvKey(envMap map[string]string, key string) string {
+	if goruntime.GOOS != constants.OSWindows {
+		return key
+	}
+	if _, ok := envMap[key]; ok {
+		return key
+	}
+	for existing := range envMap {
+		if strings.EqualFold(existing, key) {
+			return existing
+		}
+	}
+	return key
+}
@@ -0,0 +1,39 @@
+package runtime
+
+import (
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestMergeEnvironment(t *testing.T) {
+	sep := string(filepath.ListSeparator)
+	base := []string{"HOME=/home/dev", "LD_LIBRARY_PATH=/usr/lib"}
+
+	merged := MergeEnvironment(base, map[string]string{
+		"LD_LIBRARY_PATH": "/opt/ruby/lib",
+		"GEM_HOME":        "/opt/ruby/gems",
+	})
+	sort.Strings(merged)
+
+	expected := []string{
+		"GEM_HOME=/opt/ruby/gems",
+		"HOME=/home/dev",
+		"LD_LIBRARY_PATH=/opt/ruby/lib" + sep + "/usr/lib",
+	}
+	if len(merged) != len(expected) {
+		t.Fatalf("MergeEnvironment() = %v, want %v", merged, expected)
+	}
+	for i := range expected {
+		if merged[i] != expected[i] {
+			t.Errorf("MergeEnvironment()[%d] = %q, want %q", i, merged[i], expected[i])
+		}
+	}
+}
+
+func TestMergeEnvironment_NoProviderEnv(t *testing.T) {
+	base := []string{"A=1"}
+	if got := MergeEnvironment(base, nil); len(got) != 1 || got[0] != "A=1" {
+		t.Errorf("MergeEnvironment() = %v, want the base environment unchanged", got)
+	}
+}
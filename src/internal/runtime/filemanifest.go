@@ -0,0 +1,243 @@
+//go:build !shim
+
+package runtime
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/filelock"
+)
+
+// ErrNoFileManifest is returned for versions installed before dtvem recorded
+// file manifests
+var ErrNoFileManifest = errors.New("no file manifest recorded for this install")
+
+// FileManifest records the files of an installed version when its install
+// is committed, so dtvem verify can later tell whether anything under the
+// version directory went missing or changed.
+type FileManifest struct {
+	Runtime   string      `json:"runtime"`
+	Version   string      `json:"version"`
+	CreatedAt time.Time   `json:"createdAt"`
+	Files     []FileEntry `json:"files"`
+}
+
+// FileEntry is one file in a FileManifest. Paths are slash-separated and
+// relative to the install directory. Symbolic links record their target
+// instead of a size and checksum.
+type FileEntry struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size,omitempty"`
+	SHA256 string `json:"sha256,omitempty"`
+	Link   string `json:"link,omitempty"`
+}
+
+// FileProblemKind describes how a file differs from the manifest
+type FileProblemKind string
+
+const (
+	// FileMissing means the file no longer exists
+	FileMissing FileProblemKind = "missing"
+	// FileModified means the file's size, content or link target changed
+	FileModified FileProblemKind = "modified"
+)
+
+// FileProblem is a manifest entry that no longer matches the install
+type FileProblem struct {
+	Path string
+	Kind FileProblemKind
+}
+
+// FileCheck is the result of comparing an install against its manifest
+type FileCheck struct {
+	Checked  int // Files compared
+	Skipped  int // Files under mutable paths, not compared
+	Problems []FileProblem
+}
+
+// WriteFileManifest records every file of an installed version. The install
+// marker and the manifest itself are left out.
+func WriteFileManifest(runtimeName, version string) error {
+	installPath := config.RuntimeVersionPath(runtimeName, version)
+	files, err := scanFiles(installPath)
+	if err != nil {
+		return fmt.Errorf("failed to scan %s: %w", installPath, err)
+	}
+
+	data, err := json.Marshal(FileManifest{
+		Runtime:   runtimeName,
+		Version:   version,
+		CreatedAt: time.Now().UTC(),
+		Files:     files,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := filelock.WriteFile(filepath.Join(installPath, config.FileManifestFileName), data, 0644); err != nil {
+		return fmt.Errorf("failed to write file manifest: %w", err)
+	}
+	return nil
+}
+
+// ReadFileManifest loads the manifest of an installed version. Returns
+// ErrNoFileManifest if none was recorded.
+func ReadFileManifest(runtimeName, version string) (*FileManifest, error) {
+	manifestPath := filepath.Join(config.RuntimeVersionPath(runtimeName, version), config.FileManifestFileName)
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNoFileManifest
+		}
+		return nil, err
+	}
+
+	var m FileManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid file manifest %s: %w", manifestPath, err)
+	}
+	return &m, nil
+}
+
+// CheckFiles compares an installed version against its file manifest. Files
+// added since the install are not reported, and files matching a mutable
+// path pattern (see Verifier) are skipped.
+func CheckFiles(runtimeName, version string, mutablePaths []string) (*FileCheck, error) {
+	m, err := ReadFileManifest(runtimeName, version)
+	if err != nil {
+		return nil, err
+	}
+
+	installPath := config.RuntimeVersionPath(runtimeName, version)
+	result := &FileCheck{}
+	for _, entry := range m.Files {
+		if isMutablePath(entry.Path, mutablePaths) {
+			result.Skipped++
+			continue
+		}
+		result.Checked++
+
+		if kind, ok := checkFile(filepath.Join(installPath, filepath.FromSlash(entry.Path)), entry); !ok {
+			result.Problems = append(result.Problems, FileProblem{Path: entry.Path, Kind: kind})
+		}
+	}
+	return result, nil
+}
+
+// checkFile compares one file against its manifest entry
+func checkFile(filePath string, entry FileEntry) (FileProblemKind, bool) {
+	info, err := os.Lstat(filePath)
+	if err != nil {
+		return FileMissing, false
+	}
+
+	if entry.Link != "" {
+		target, err := os.Readlink(filePath)
+		if err != nil || target != entry.Link {
+			return FileModified, false
+		}
+		return "", true
+	}
+
+	if !info.Mode().IsRegular() || info.Size() != entry.Size {
+		return FileModified, false
+	}
+	sum, err := fileSHA256(filePath)
+	if err != nil || sum != entry.SHA256 {
+		return FileModified, false
+	}
+	return "", true
+}
+
+// scanFiles lists the regular files and symbolic links under root, sorted
+// by path
+func scanFiles(root string) ([]FileEntry, error) {
+	var files []FileEntry
+	err := filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == config.InstallMarkerFileName || rel == config.FileManifestFileName {
+			return nil
+		}
+
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			target, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			files = append(files, FileEntry{Path: rel, Link: target})
+		case info.Mode().IsRegular():
+			sum, err := fileSHA256(p)
+			if err != nil {
+				return err
+			}
+			files = append(files, FileEntry{Path: rel, Size: info.Size(), SHA256: sum})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// fileSHA256 returns the hex SHA256 of a file
+func fileSHA256(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// isMutablePath reports whether a manifest path matches one of the
+// patterns. Patterns containing a slash are matched against the path and
+// each of its parent directories; others match any single path element.
+func isMutablePath(rel string, patterns []string) bool {
+	parts := strings.Split(rel, "/")
+	for _, pattern := range patterns {
+		if strings.Contains(pattern, "/") {
+			depth := strings.Count(pattern, "/") + 1
+			if depth <= len(parts) {
+				if ok, _ := path.Match(pattern, strings.Join(parts[:depth], "/")); ok {
+					return true
+				}
+			}
+			continue
+		}
+		for _, part := range parts {
+			if ok, _ := path.Match(pattern, part); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
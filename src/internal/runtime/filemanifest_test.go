@@ -0,0 +1,157 @@
+//go:build !shim
+
+package runtime
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
+)
+
+// installWithFiles commits an install of node 22.0.0 holding the given
+// files and returns its install path
+func installWithFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	inst, err := BeginInstall("node", "22.0.0")
+	if err != nil {
+		t.Fatalf("BeginInstall failed: %v", err)
+	}
+	defer inst.Rollback()
+
+	for name, content := range files {
+		p := filepath.Join(inst.StagingDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := inst.Promote(inst.StagingDir); err != nil {
+		t.Fatalf("Promote failed: %v", err)
+	}
+	if err := inst.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	return inst.InstallPath
+}
+
+func TestCheckFiles(t *testing.T) {
+	setupInstallationTest(t)
+	installPath := installWithFiles(t, map[string]string{
+		"bin/node":                       "node",
+		"include/node/node.h":            "header",
+		"lib/node_modules/npm/index.js":  "npm",
+		"share/doc/node/gdbinit":         "gdb",
+		"lib/node_modules/npm/README.md": "readme",
+	})
+
+	check, err := CheckFiles("node", "22.0.0", []string{"node_modules"})
+	if err != nil {
+		t.Fatalf("CheckFiles failed: %v", err)
+	}
+	if check.Checked != 3 || check.Skipped != 2 || len(check.Problems) != 0 {
+		t.Fatalf("CheckFiles on an intact install = %+v, want 3 checked, 2 skipped, no problems", check)
+	}
+
+	// Same size, different content
+	if err := os.WriteFile(filepath.Join(installPath, "bin", "node"), []byte("evil"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(installPath, "include", "node", "node.h")); err != nil {
+		t.Fatal(err)
+	}
+	// Changes under mutable paths and new files are not problems
+	if err := os.WriteFile(filepath.Join(installPath, "lib", "node_modules", "npm", "index.js"), []byte("npm 11"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(installPath, "bin", "tsc"), []byte("tsc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err = CheckFiles("node", "22.0.0", []string{"node_modules"})
+	if err != nil {
+		t.Fatalf("CheckFiles failed: %v", err)
+	}
+	want := []FileProblem{
+		{Path: "bin/node", Kind: FileModified},
+		{Path: "include/node/node.h", Kind: FileMissing},
+	}
+	if len(check.Problems) != len(want) {
+		t.Fatalf("Problems = %+v, want %+v", check.Problems, want)
+	}
+	for i := range want {
+		if check.Problems[i] != want[i] {
+			t.Errorf("Problems[%d] = %+v, want %+v", i, check.Problems[i], want[i])
+		}
+	}
+}
+
+func TestCheckFiles_Symlinks(t *testing.T) {
+	if goruntime.GOOS == constants.OSWindows {
+		t.Skip("symlinks need privileges on Windows")
+	}
+	setupInstallationTest(t)
+	installPath := installWithFiles(t, map[string]string{"lib/cli.js": "cli", "lib/other.js": "other"})
+
+	link := filepath.Join(installPath, "cli")
+	if err := os.Symlink("lib/cli.js", link); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFileManifest("node", "22.0.0"); err != nil {
+		t.Fatalf("WriteFileManifest failed: %v", err)
+	}
+
+	_ = os.Remove(link)
+	if err := os.Symlink("lib/other.js", link); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := CheckFiles("node", "22.0.0", nil)
+	if err != nil {
+		t.Fatalf("CheckFiles failed: %v", err)
+	}
+	if len(check.Problems) != 1 || check.Problems[0] != (FileProblem{Path: "cli", Kind: FileModified}) {
+		t.Errorf("Problems = %+v, want cli modified", check.Problems)
+	}
+}
+
+func TestCheckFiles_NoManifest(t *testing.T) {
+	setupInstallationTest(t)
+	if err := os.MkdirAll(config.RuntimeVersionPath("node", "18.0.0"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := CheckFiles("node", "18.0.0", nil); !errors.Is(err, ErrNoFileManifest) {
+		t.Errorf("CheckFiles error = %v, want ErrNoFileManifest", err)
+	}
+}
+
+func TestIsMutablePath(t *testing.T) {
+	tests := []struct {
+		path     string
+		patterns []string
+		expected bool
+	}{
+		{"lib/node_modules/npm/index.js", []string{"node_modules"}, true},
+		{"node_modules/npm/index.js", []string{"node_modules"}, true},
+		{"bin/node", []string{"node_modules"}, false},
+		{"lib/python3.12/site-packages/pip/__init__.py", []string{"site-packages"}, true},
+		{"bin/pip3.12", []string{"bin/pip*"}, true},
+		{"bin/python3", []string{"bin/pip*"}, false},
+		{"lib/ruby/gems/3.3.0/gems/rake/rake.rb", []string{"lib/ruby/gems"}, true},
+		{"lib/ruby/3.3.0/openssl.rb", []string{"lib/ruby/gems"}, false},
+		{"lib", []string{"lib/ruby/gems"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := isMutablePath(tt.path, tt.patterns); got != tt.expected {
+			t.Errorf("isMutablePath(%q, %v) = %v, want %v", tt.path, tt.patterns, got, tt.expected)
+		}
+	}
+}
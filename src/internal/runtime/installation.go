@@ -0,0 +1,170 @@
+//go:build !shim
+
+package runtime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/filelock"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+)
+
+// Installation stages the install of a runtime version so that a failure at
+// any point never leaves a directory that looks installed.
+//
+// The archive is extracted into StagingDir, a sibling of the final location
+// (so moving it is a same-filesystem rename), then promoted into place.
+// Post-install steps such as pip bootstrapping and shim creation run against
+// the final location, between Promote and Commit, because they can record
+// absolute paths and a failure must roll the install back. Only Commit
+// writes the install marker that IsInstalled and ListInstalled look for,
+// after recording the file manifest that dtvem verify checks against.
+//
+// The installation holds the version's install lock from BeginInstall until
+// Commit or Rollback, so two processes installing the same version take
+// turns instead of extracting over each other. Rollback removes whatever the
+// installation created and is a no-op after Commit, so it can be deferred
+// unconditionally:
+//
+//	inst, err := runtime.BeginInstall("node", version)
+//	if err != nil {
+//		return err
+//	}
+//	defer inst.Rollback()
+type Installation struct {
+	Runtime     string
+	Version     string
+	StagingDir  string
+	InstallPath string
+
+	lock      *filelock.Lock
+	promoted  bool
+	committed bool
+}
+
+// BeginInstall takes the version's install lock and creates its staging
+// directory. A leftover incomplete install at the final location is removed
+// first. The installed check happens under the lock, so a process that waited
+// for a concurrent install of the same version fails here instead of
+// installing it twice.
+func BeginInstall(runtimeName, version string) (inst *Installation, err error) {
+	if err := config.EnableInstallMarkers(); err != nil {
+		return nil, fmt.Errorf("failed to prepare versions directory: %w", err)
+	}
+
+	lock, err := config.AcquireLock(config.InstallLockName(runtimeName, version))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = lock.Release()
+		}
+	}()
+
+	installPath := config.RuntimeVersionPath(runtimeName, version)
+	if config.IsVersionInstalled(runtimeName, version) {
+		return nil, fmt.Errorf("%s %s is already installed", runtimeName, version)
+	}
+	if _, err := os.Stat(installPath); err == nil {
+		ui.Debug("Removing incomplete install at %s", installPath)
+		if err := os.RemoveAll(installPath); err != nil {
+			return nil, fmt.Errorf("failed to remove incomplete install: %w", err)
+		}
+	}
+
+	parent := filepath.Dir(installPath)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create install directory: %w", err)
+	}
+
+	stagingDir, err := os.MkdirTemp(parent, config.StagingDirPrefix+version+"-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	ui.Debug("Staging %s %s in %s", runtimeName, version, stagingDir)
+
+	return &Installation{
+		Runtime:     runtimeName,
+		Version:     version,
+		StagingDir:  stagingDir,
+		InstallPath: installPath,
+		lock:        lock,
+	}, nil
+}
+
+// Promote moves sourceDir, the StagingDir or a directory inside it, to the
+// final install location
+func (i *Installation) Promote(sourceDir string) error {
+	ui.Debug("Moving files from %s to %s", sourceDir, i.InstallPath)
+	if err := os.Rename(sourceDir, i.InstallPath); err != nil {
+		return fmt.Errorf("failed to move to install location: %w", err)
+	}
+	i.promoted = true
+	return nil
+}
+
+// Commit records the file manifest and writes the install marker, making the
+// version visible as installed, then removes what is left of the staging
+// directory and releases the install lock
+func (i *Installation) Commit() error {
+	if !i.promoted {
+		return fmt.Errorf("%s %s was not moved into place", i.Runtime, i.Version)
+	}
+	if err := WriteFileManifest(i.Runtime, i.Version); err != nil {
+		return err
+	}
+	if err := config.WriteInstallMarker(i.Runtime, i.Version); err != nil {
+		return err
+	}
+	i.committed = true
+	_ = os.RemoveAll(i.StagingDir)
+	_ = i.lock.Release()
+	return nil
+}
+
+// Rollback removes the staging directory and, if the install was promoted
+// but not committed, the partially installed version, then releases the
+// install lock
+func (i *Installation) Rollback() {
+	if i.committed {
+		return
+	}
+	_ = os.RemoveAll(i.StagingDir)
+	if i.promoted {
+		ui.Debug("Rolling back incomplete install at %s", i.InstallPath)
+		_ = os.RemoveAll(i.InstallPath)
+		i.promoted = false
+	}
+	_ = i.lock.Release()
+}
+
+// RemoveInstall deletes an installed runtime version. It holds the version's
+// install lock, so it never races an install of the same version in another
+// process. The install marker goes first, so an interrupted removal leaves an
+// incomplete install (cleaned up by the next install or dtvem doctor) rather
+// than a partially deleted version that still looks installed.
+func RemoveInstall(runtimeName, version string) error {
+	lock, err := config.AcquireLock(config.InstallLockName(runtimeName, version))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Release() }()
+
+	installPath := config.RuntimeVersionPath(runtimeName, version)
+	if _, err := os.Stat(installPath); os.IsNotExist(err) {
+		return fmt.Errorf("%s %s is not installed", runtimeName, version)
+	}
+
+	markerPath := filepath.Join(installPath, config.InstallMarkerFileName)
+	if err := os.Remove(markerPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove install marker: %w", err)
+	}
+	if err := os.RemoveAll(installPath); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", installPath, err)
+	}
+	return nil
+}
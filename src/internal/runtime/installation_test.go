@@ -0,0 +1,144 @@
+//go:build !shim
+
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+)
+
+func setupInstallationTest(t *testing.T) {
+	t.Helper()
+	t.Setenv("DTVEM_ROOT", t.TempDir())
+	config.ResetPathsCache()
+	t.Cleanup(config.ResetPathsCache)
+}
+
+// stageFiles writes a file into a directory inside the staging directory,
+// as extraction would, and returns that directory
+func stageFiles(t *testing.T, inst *Installation) string {
+	t.Helper()
+	extracted := filepath.Join(inst.StagingDir, "node-v22.0.0")
+	if err := os.MkdirAll(filepath.Join(extracted, "bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(extracted, "bin", "node"), []byte("node"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return extracted
+}
+
+func TestInstallation_Commit(t *testing.T) {
+	setupInstallationTest(t)
+
+	inst, err := BeginInstall("node", "22.0.0")
+	if err != nil {
+		t.Fatalf("BeginInstall failed: %v", err)
+	}
+	defer inst.Rollback()
+
+	if err := inst.Promote(stageFiles(t, inst)); err != nil {
+		t.Fatalf("Promote failed: %v", err)
+	}
+	if config.IsVersionInstalled("node", "22.0.0") {
+		t.Error("version should not be installed before Commit")
+	}
+	if err := inst.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	inst.Rollback()
+
+	if !config.IsVersionInstalled("node", "22.0.0") {
+		t.Error("version should be installed after Commit")
+	}
+	if _, err := os.Stat(filepath.Join(inst.InstallPath, "bin", "node")); err != nil {
+		t.Errorf("promoted files missing: %v", err)
+	}
+	if _, err := os.Stat(inst.StagingDir); !os.IsNotExist(err) {
+		t.Error("staging directory should be removed after Commit")
+	}
+	if _, err := BeginInstall("node", "22.0.0"); err == nil {
+		t.Error("BeginInstall should fail for an installed version")
+	}
+}
+
+func TestInstallation_RollbackAfterPromote(t *testing.T) {
+	setupInstallationTest(t)
+
+	inst, err := BeginInstall("node", "22.0.0")
+	if err != nil {
+		t.Fatalf("BeginInstall failed: %v", err)
+	}
+	if err := inst.Promote(stageFiles(t, inst)); err != nil {
+		t.Fatalf("Promote failed: %v", err)
+	}
+
+	// A failing post-install step returns before Commit
+	inst.Rollback()
+
+	for _, dir := range []string{inst.InstallPath, inst.StagingDir} {
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed by Rollback", dir)
+		}
+	}
+	if config.IsVersionInstalled("node", "22.0.0") {
+		t.Error("version should not be installed after Rollback")
+	}
+}
+
+func TestBeginInstall_RemovesIncompleteInstall(t *testing.T) {
+	setupInstallationTest(t)
+	if err := config.EnableInstallMarkers(); err != nil {
+		t.Fatal(err)
+	}
+
+	leftover := config.RuntimeVersionPath("node", "22.0.0")
+	if err := os.MkdirAll(leftover, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(leftover, "partial"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	inst, err := BeginInstall("node", "22.0.0")
+	if err != nil {
+		t.Fatalf("BeginInstall should replace an incomplete install: %v", err)
+	}
+	defer inst.Rollback()
+
+	if _, err := os.Stat(leftover); !os.IsNotExist(err) {
+		t.Error("leftover incomplete install should be removed")
+	}
+}
+
+func TestRemoveInstall(t *testing.T) {
+	setupInstallationTest(t)
+
+	inst, err := BeginInstall("node", "22.0.0")
+	if err != nil {
+		t.Fatalf("BeginInstall failed: %v", err)
+	}
+	if err := inst.Promote(stageFiles(t, inst)); err != nil {
+		t.Fatalf("Promote failed: %v", err)
+	}
+	if err := inst.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	if err := RemoveInstall("node", "22.0.0"); err != nil {
+		t.Fatalf("RemoveInstall failed: %v", err)
+	}
+	if config.IsVersionInstalled("node", "22.0.0") {
+		t.Error("version should no longer be installed")
+	}
+	if _, err := os.Stat(config.RuntimeVersionPath("node", "22.0.0")); !os.IsNotExist(err) {
+		t.Error("install directory should be removed")
+	}
+
+	if err := RemoveInstall("node", "22.0.0"); err == nil {
+		t.Error("RemoveInstall should fail for a version that is not installed")
+	}
+}
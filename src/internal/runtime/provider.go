@@ -34,3 +34,13 @@ type ShimProvider interface {
 	// Returns an empty map if no special environment is needed.
 	GetEnvironment(version string) (map[string]string, error)
 }
+
+// AliasResolver is an optional interface for providers that understand named
+// version aliases in configuration files (e.g., Node.js "lts/iron").
+// Aliases are translated into version constraints before matching.
+type AliasResolver interface {
+	// ResolveAlias converts an alias into a version constraint understood by
+	// the version package (e.g., "lts/iron" → "20"). Returns false if the
+	// input is not a known alias.
+	ResolveAlias(alias string) (string, bool)
+}
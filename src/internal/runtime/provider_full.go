@@ -2,6 +2,8 @@
 
 package runtime
 
+import "github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+
 // Provider defines the full interface that all runtime providers must implement.
 // It embeds ShimProvider and adds operations that require heavier dependencies
 // (HTTP, archive extraction, manifest fetching). These methods are not compiled
@@ -62,3 +64,39 @@ type Provider interface {
 	// Returns empty string if the runtime doesn't support global packages
 	ManualPackageInstallCommand(packages []string) string
 }
+
+// ProgressInstaller is an optional interface for providers that can report
+// install progress to a caller-supplied reporter instead of the console.
+// Bulk installs use it to run several installs concurrently in a single
+// progress view; providers without it fall back to Install.
+type ProgressInstaller interface {
+	// InstallWithProgress installs a version like Install, sending status and
+	// download progress to reporter
+	InstallWithProgress(version string, reporter ui.ProgressReporter) error
+}
+
+// Verifier is an optional interface for providers that support dtvem verify.
+// Providers without it are checked against their file manifest only.
+type Verifier interface {
+	// SmokeTestArgs returns the arguments passed to the runtime's main
+	// executable to check that it starts and can load its core libraries
+	SmokeTestArgs() []string
+
+	// MutablePaths returns patterns for files that legitimately change after
+	// install, such as global package directories; they are not compared
+	// against the file manifest. Patterns are slash-separated and may use
+	// path.Match wildcards. Patterns containing a slash match a path
+	// relative to the install directory; others match a file or directory
+	// name at any depth.
+	MutablePaths() []string
+}
+
+// Adopter is an optional interface for providers whose installations need
+// adjusting after dtvem adopts them from another version manager (see
+// Adopt), before the adopted version is validated.
+type Adopter interface {
+	// PrepareAdopted fixes up the adopted install of version. linked is true
+	// when the install references the original tree instead of a copy of
+	// it; only the install's own bin directory may be changed then.
+	PrepareAdopted(version string, linked bool) error
+}
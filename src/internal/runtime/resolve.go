@@ -0,0 +1,61 @@
+package runtime
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/version"
+)
+
+// ResolveVersionSpec resolves a version spec against a list of concrete
+// versions and returns the highest match. The spec may be an exact version,
+// a range such as "^20.10" or ">=3.11,<3.13", "latest", or an alias the
+// provider understands via AliasResolver (e.g., "lts/iron").
+func ResolveVersionSpec(provider ShimProvider, spec string, available []string) (string, error) {
+	constraint := spec
+	if resolver, ok := provider.(AliasResolver); ok {
+		if c, ok := resolver.ResolveAlias(spec); ok {
+			constraint = c
+		}
+	}
+
+	resolved, err := version.ResolveConstraint(constraint, available)
+	if err != nil {
+		return "", fmt.Errorf("no %s version matching %q found", provider.DisplayName(), spec)
+	}
+	return resolved, nil
+}
+
+// ResolveInstalledVersion resolves a configured version spec to an installed
+// version. Exact versions are returned unchanged (minus any "v" prefix) without
+// touching the filesystem, so callers should still check IsInstalled.
+// Ranges and aliases are matched against the versions in the versions directory.
+func ResolveInstalledVersion(provider ShimProvider, spec string) (string, error) {
+	if version.IsExactVersion(spec) {
+		return strings.TrimPrefix(strings.TrimSpace(spec), "v"), nil
+	}
+
+	return ResolveVersionSpec(provider, spec, config.InstalledVersions(provider.Name()))
+}
+
+// CurrentVersion returns the version of a runtime configured for the current
+// directory. Ranges and aliases resolve to the best installed match; when
+// nothing installed satisfies them, the configured spec is returned
+// unchanged.
+func CurrentVersion(provider ShimProvider) (string, error) {
+	spec, err := config.ResolveVersion(provider.Name())
+	if err != nil {
+		return "", err
+	}
+	if version, err := ResolveInstalledVersion(provider, spec); err == nil {
+		return version, nil
+	}
+	return spec, nil
+}
+
+// IsVersionSpec reports whether a configured version is a range or alias
+// that must be resolved, rather than an exact version.
+func IsVersionSpec(spec string) bool {
+	return !version.IsExactVersion(spec)
+}
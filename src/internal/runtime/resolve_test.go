@@ -0,0 +1,122 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+)
+
+// aliasMockProvider adds AliasResolver support to mockProvider
+type aliasMockProvider struct {
+	mockProvider
+}
+
+func (m *aliasMockProvider) ResolveAlias(alias string) (string, bool) {
+	if alias == "lts/iron" {
+		return "20", true
+	}
+	return "", false
+}
+
+func TestResolveVersionSpec(t *testing.T) {
+	available := []string{"18.20.4", "20.10.0", "20.18.1", "22.11.0"}
+	plain := &mockProvider{name: "node", displayName: "Node.js"}
+	aliased := &aliasMockProvider{mockProvider{name: "node", displayName: "Node.js"}}
+
+	tests := []struct {
+		name     string
+		provider ShimProvider
+		spec     string
+		expected string
+		wantErr  bool
+	}{
+		{"caret range", plain, "^20.10", "20.18.1", false},
+		{"latest", plain, "latest", "22.11.0", false},
+		{"alias via resolver", aliased, "lts/iron", "20.18.1", false},
+		{"alias without resolver", plain, "lts/iron", "", true},
+		{"no match", plain, "^24", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveVersionSpec(tt.provider, tt.spec, available)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ResolveVersionSpec(%q) = %q, expected error", tt.spec, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveVersionSpec(%q) error: %v", tt.spec, err)
+			}
+			if got != tt.expected {
+				t.Errorf("ResolveVersionSpec(%q) = %q, want %q", tt.spec, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveInstalledVersion(t *testing.T) {
+	t.Setenv("DTVEM_ROOT", t.TempDir())
+	config.ResetPathsCache()
+	defer config.ResetPathsCache()
+
+	for _, v := range []string{"3.11.9", "3.12.8"} {
+		if err := os.MkdirAll(filepath.Join(config.DefaultPaths().Versions, "python", v), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	provider := &mockProvider{name: "python", displayName: "Python"}
+
+	t.Run("exact version is returned without lookup", func(t *testing.T) {
+		got, err := ResolveInstalledVersion(provider, "v3.10.0")
+		if err != nil || got != "3.10.0" {
+			t.Errorf("ResolveInstalledVersion() = %q, %v; want 3.10.0, nil", got, err)
+		}
+	})
+
+	t.Run("range resolves against installed versions", func(t *testing.T) {
+		got, err := ResolveInstalledVersion(provider, ">=3.11,<3.12")
+		if err != nil || got != "3.11.9" {
+			t.Errorf("ResolveInstalledVersion() = %q, %v; want 3.11.9, nil", got, err)
+		}
+	})
+
+	t.Run("range with no installed match", func(t *testing.T) {
+		if _, err := ResolveInstalledVersion(provider, "^3.13"); err == nil {
+			t.Error("ResolveInstalledVersion() expected error when nothing installed matches")
+		}
+	})
+}
+
+func TestCurrentVersion(t *testing.T) {
+	t.Setenv("DTVEM_ROOT", t.TempDir())
+	config.ResetPathsCache()
+	defer config.ResetPathsCache()
+
+	if err := os.MkdirAll(filepath.Join(config.DefaultPaths().Versions, "python", "3.12.8"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	provider := &mockProvider{name: "python", displayName: "Python"}
+
+	tests := []struct {
+		configured string
+		expected   string
+	}{
+		{"3.12", "3.12.8"},
+		{"3.11.9", "3.11.9"},
+		{"^3.13", "^3.13"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.configured, func(t *testing.T) {
+			t.Setenv(config.VersionEnvVar("python"), tt.configured)
+			got, err := CurrentVersion(provider)
+			if err != nil || got != tt.expected {
+				t.Errorf("CurrentVersion() = %q, %v; want %q, nil", got, err, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+//go:build !shim
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+)
+
+// smokeTestTimeout bounds a smoke test, so a hung interpreter fails the
+// check instead of hanging dtvem verify
+const smokeTestTimeout = 30 * time.Second
+
+// SmokeTest runs an installed version's main executable with args, in the
+// environment the shims would give it. The error includes the command's
+// output when it fails.
+func SmokeTest(provider ShimProvider, version string, args []string) error {
+	execPath, err := provider.ExecutablePath(version)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), smokeTestTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, execPath, args...)
+	env, err := provider.GetEnvironment(version)
+	if err != nil {
+		ui.Debug("Failed to get %s environment: %v", provider.Name(), err)
+	}
+	cmd.Env = MergeEnvironment(os.Environ(), env)
+
+	ui.Debug("Smoke test: %s %s", execPath, strings.Join(args, " "))
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%s %s did not finish within %s", filepath.Base(execPath), strings.Join(args, " "), smokeTestTimeout)
+		}
+		if detail := strings.TrimSpace(string(out)); detail != "" {
+			return fmt.Errorf("%s %s failed: %w\n%s", filepath.Base(execPath), strings.Join(args, " "), err, detail)
+		}
+		return fmt.Errorf("%s %s failed: %w", filepath.Base(execPath), strings.Join(args, " "), err)
+	}
+	return nil
+}
+
+// Reinstall replaces an installed version with a fresh install. Install
+// takes the archive from the download cache when it is there. The old
+// install is set aside until the new one succeeds and is put back if it
+// fails, so a failed repair leaves things as they were.
+func Reinstall(provider Provider, version string) error {
+	runtimeName := provider.Name()
+	installPath := config.RuntimeVersionPath(runtimeName, version)
+	asidePath := filepath.Join(filepath.Dir(installPath), config.RepairDirPrefix+version)
+
+	lock, err := config.AcquireLock(config.InstallLockName(runtimeName, version))
+	if err != nil {
+		return err
+	}
+	_ = os.RemoveAll(asidePath)
+	err = os.Rename(installPath, asidePath)
+	_ = lock.Release()
+	if err != nil {
+		return fmt.Errorf("failed to move %s aside: %w", installPath, err)
+	}
+
+	if installErr := provider.Install(version); installErr != nil {
+		if err := restoreInstall(runtimeName, version, asidePath); err != nil {
+			return fmt.Errorf("%w (restoring the previous install also failed: %v; it is in %s)", installErr, err, asidePath)
+		}
+		return installErr
+	}
+
+	if err := os.RemoveAll(asidePath); err != nil {
+		ui.Debug("Failed to remove %s: %v", asidePath, err)
+	}
+	return nil
+}
+
+// restoreInstall moves an install set aside by Reinstall back into place,
+// unless another process installed the version in the meantime
+func restoreInstall(runtimeName, version, asidePath string) error {
+	lock, err := config.AcquireLock(config.InstallLockName(runtimeName, version))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Release() }()
+
+	if config.IsVersionInstalled(runtimeName, version) {
+		return os.RemoveAll(asidePath)
+	}
+	installPath := config.RuntimeVersionPath(runtimeName, version)
+	if err := os.RemoveAll(installPath); err != nil {
+		return err
+	}
+	return os.Rename(asidePath, installPath)
+}
@@ -0,0 +1,70 @@
+//go:build !shim
+
+package runtime
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+)
+
+// reinstallProvider installs node versions holding a single file, or fails
+type reinstallProvider struct {
+	mockProvider
+	content    string
+	installErr error
+}
+
+func (p *reinstallProvider) Install(version string) error {
+	if p.installErr != nil {
+		return p.installErr
+	}
+	inst, err := BeginInstall(p.name, version)
+	if err != nil {
+		return err
+	}
+	defer inst.Rollback()
+
+	if err := os.WriteFile(filepath.Join(inst.StagingDir, "node"), []byte(p.content), 0755); err != nil {
+		return err
+	}
+	if err := inst.Promote(inst.StagingDir); err != nil {
+		return err
+	}
+	return inst.Commit()
+}
+
+func TestReinstall(t *testing.T) {
+	setupInstallationTest(t)
+	provider := &reinstallProvider{mockProvider: mockProvider{name: "node"}, content: "broken"}
+	if err := provider.Install("22.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	nodePath := filepath.Join(config.RuntimeVersionPath("node", "22.0.0"), "node")
+
+	// A failed reinstall puts the old install back
+	provider.installErr = errors.New("download failed")
+	if err := Reinstall(provider, "22.0.0"); err == nil {
+		t.Fatal("Reinstall should fail when the install fails")
+	}
+	if data, _ := os.ReadFile(nodePath); string(data) != "broken" || !config.IsVersionInstalled("node", "22.0.0") {
+		t.Fatalf("previous install was not restored (content %q)", data)
+	}
+
+	provider.installErr = nil
+	provider.content = "fixed"
+	if err := Reinstall(provider, "22.0.0"); err != nil {
+		t.Fatalf("Reinstall failed: %v", err)
+	}
+	if data, _ := os.ReadFile(nodePath); string(data) != "fixed" {
+		t.Errorf("node = %q after reinstall, want fixed", data)
+	}
+
+	incomplete, err := config.IncompleteInstalls(config.DefaultPaths().Versions)
+	if err != nil || len(incomplete) != 0 {
+		t.Errorf("Reinstall left %v behind (err = %v)", incomplete, err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/filelock"
 )
 
 // ShimEntry is the per-shim record stored in the shim-map cache. It binds
@@ -102,7 +103,9 @@ func schemaIsCurrent(m ShimMap) bool {
 }
 
 // SaveShimMap writes the shim-to-runtime mapping to the cache file.
-// This should be called during reshim operations.
+// This should be called during reshim operations, while holding the shims
+// lock. The file is replaced atomically, so shims running concurrently
+// always read a complete map.
 func SaveShimMap(shimMap ShimMap) error {
 	// Ensure cache directory exists
 	paths := config.DefaultPaths()
@@ -117,7 +120,7 @@ func SaveShimMap(shimMap ShimMap) error {
 		return err
 	}
 
-	return os.WriteFile(cachePath, data, 0644)
+	return filelock.WriteFile(cachePath, data, 0644)
 }
 
 // MergeShimMap merges the given entries into the on-disk shim map and persists it.
@@ -133,7 +136,16 @@ func SaveShimMap(shimMap ShimMap) error {
 // caller knows only the shims it just created and wants to register them
 // without rebuilding the entire map (which would require scanning every
 // installed runtime — `Rehash` does that).
+//
+// The read-modify-write holds the shims lock, so concurrent installs, in
+// this process or another, don't drop each other's entries.
 func MergeShimMap(entries ShimMap) error {
+	lock, err := config.AcquireLock(config.ShimsLockName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Release() }()
+
 	existing, err := loadShimMapFromDisk()
 	if err != nil || existing == nil {
 		// Cache missing, unreadable, or empty — start a fresh map.
@@ -2,10 +2,12 @@ package shim
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
 	"sort"
+	"sync"
 	"testing"
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
@@ -380,3 +382,31 @@ func TestSaveShimMap_OmitsEmptyVersions(t *testing.T) {
 		t.Errorf("expected versions field to be omitted when empty, got: %s", data)
 	}
 }
+
+func TestMergeShimMap_ConcurrentMergesKeepAllEntries(t *testing.T) {
+	_, cleanup := withTempCache(t)
+	defer cleanup()
+
+	const installs = 20
+	var wg sync.WaitGroup
+	for i := 0; i < installs; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("tool%d", i)
+			if err := MergeShimMap(ShimMap{name: entry("node", "22.0.0")}); err != nil {
+				t.Errorf("MergeShimMap(%s) error: %v", name, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	ResetShimMapCache()
+	loaded, err := LoadShimMap()
+	if err != nil {
+		t.Fatalf("LoadShimMap failed: %v", err)
+	}
+	if len(loaded) != installs {
+		t.Errorf("shim map has %d entries after %d concurrent merges, want all of them", len(loaded), installs)
+	}
+}
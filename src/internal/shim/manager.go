@@ -11,12 +11,15 @@ import (
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/filelock"
 	runtimepkg "github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
 )
 
 // Manager handles shim creation and management
 type Manager struct {
-	shimSource string // Path to the shim executable
+	shimSource string              // Path to the shim executable
+	strategy   config.ShimStrategy // How shims are laid down
 }
 
 // NewManager creates a new shim manager
@@ -24,18 +27,30 @@ func NewManager() (*Manager, error) {
 	// Find the shim executable
 	// It should be in the same directory as the dtvem executable
 	// Or we'll build it on demand
-	shimSource, err := findShimExecutable()
+	shimSource, err := FindShimExecutable()
 	if err != nil {
 		return nil, fmt.Errorf("could not find shim executable: %w", err)
 	}
 
 	return &Manager{
 		shimSource: shimSource,
+		strategy:   Strategy(),
 	}, nil
 }
 
-// findShimExecutable locates the shim executable
-func findShimExecutable() (string, error) {
+// NewManagerFromSource creates a shim manager that lays down shimSource. A
+// running shim passes its own executable, since it is the shim binary;
+// symlinks are resolved so linked shims never point at another shim.
+func NewManagerFromSource(shimSource string) *Manager {
+	if resolved, err := filepath.EvalSymlinks(shimSource); err == nil {
+		shimSource = resolved
+	}
+	return &Manager{shimSource: shimSource, strategy: Strategy()}
+}
+
+// FindShimExecutable locates the dtvem-shim executable next to the running
+// dtvem binary
+func FindShimExecutable() (string, error) {
 	// Get the directory where dtvem is installed
 	execPath, err := os.Executable()
 	if err != nil {
@@ -66,18 +81,10 @@ func findShimExecutable() (string, error) {
 func (m *Manager) CreateShim(shimName string) error {
 	shimPath := config.ShimPath(shimName)
 
-	// Copy the shim executable to the new location
-	if err := copyFile(m.shimSource, shimPath); err != nil {
+	if err := m.placeShim(shimPath); err != nil {
 		return fmt.Errorf("failed to create shim %s: %w", shimName, err)
 	}
 
-	// Make it executable on Unix systems
-	if runtime.GOOS != "windows" {
-		if err := os.Chmod(shimPath, 0755); err != nil {
-			return fmt.Errorf("failed to make shim executable: %w", err)
-		}
-	}
-
 	// On Windows, create a companion .cmd wrapper
 	if runtime.GOOS == constants.OSWindows {
 		if err := createCmdWrapper(shimName); err != nil {
@@ -88,6 +95,34 @@ func (m *Manager) CreateShim(shimName string) error {
 	return nil
 }
 
+// placeShim lays down the shim binary at shimPath using the manager's
+// strategy, replacing whatever is there
+func (m *Manager) placeShim(shimPath string) error {
+	switch m.strategy {
+	case config.ShimStrategySymlink:
+		return symlinkFile(m.shimSource, shimPath)
+	case config.ShimStrategyHardlink:
+		err := hardlinkFile(m.shimSource, shimPath)
+		if err == nil {
+			return nil
+		}
+		ui.Debug("Hard link to %s failed, copying instead: %v", m.shimSource, err)
+	}
+
+	// Copy the shim executable to the new location
+	if err := copyFile(m.shimSource, shimPath); err != nil {
+		return err
+	}
+
+	// Make it executable on Unix systems
+	if runtime.GOOS != constants.OSWindows {
+		if err := os.Chmod(shimPath, 0755); err != nil {
+			return fmt.Errorf("failed to make shim executable: %w", err)
+		}
+	}
+	return nil
+}
+
 // createCmdWrapper writes a .cmd file that forwards to the .exe shim
 func createCmdWrapper(shimName string) error {
 	// shimName is the base name (e.g., "python"), ShimPath adds .exe on Windows
@@ -96,7 +131,7 @@ func createCmdWrapper(shimName string) error {
 	cmdPath := exePath[:len(exePath)-len(constants.ExtExe)] + constants.ExtCmd
 
 	content := fmt.Sprintf("@echo off\r\n\"%%~dp0%s%s\" %%*\r\n", shimName, constants.ExtExe)
-	return os.WriteFile(cmdPath, []byte(content), 0644)
+	return filelock.WriteFile(cmdPath, []byte(content), 0644)
 }
 
 // CreateShims creates multiple shims at once
@@ -139,8 +174,129 @@ func (m *Manager) CreateShimsForRuntime(runtimeName, version string, shimNames [
 	return MergeShimMap(entries)
 }
 
+// PendingShimsForVersion returns the executables of an installed runtime
+// version that have no shim yet, or whose shim-map entry does not list the
+// version. These are what ReshimVersion would add, e.g. tsc after
+// `npm install -g typescript`.
+func PendingShimsForVersion(runtimeName, version string) []string {
+	var pending []string
+	for _, name := range DiscoverShimsForVersion(config.RuntimeVersionPath(runtimeName, version)) {
+		if _, err := os.Stat(config.ShimPath(name)); err != nil {
+			pending = append(pending, name)
+			continue
+		}
+		if entry, ok := Lookup(name); !ok || !versionListed(entry.Versions, version) {
+			pending = append(pending, name)
+		}
+	}
+	return pending
+}
+
+// ReshimVersion is the incremental counterpart of Rehash: it creates shims
+// for the executables of one installed version that have none and records
+// the version in the shim map, without rescanning other versions or
+// rewriting existing shims. Returns the names of the shims it created.
+func (m *Manager) ReshimVersion(runtimeName, version string) ([]string, error) {
+	pending := PendingShimsForVersion(runtimeName, version)
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	var created []string
+	entries := make(ShimMap, len(pending))
+	for _, name := range pending {
+		if _, err := os.Stat(config.ShimPath(name)); err != nil {
+			if err := m.CreateShim(name); err != nil {
+				return created, err
+			}
+			created = append(created, name)
+		}
+		entries[name] = ShimEntry{
+			Runtime:  runtimeName,
+			Versions: []string{version},
+		}
+	}
+
+	return created, MergeShimMap(entries)
+}
+
+// UnregisterVersion updates the shims after a runtime version was
+// uninstalled: each of the runtime's shim-map entries is re-pointed at the
+// installed versions that still provide the executable, and shims no
+// installed version provides anymore are deleted along with their entries.
+// Returns the names of the deleted shims.
+func UnregisterVersion(runtimeName, version string) ([]string, error) {
+	lock, err := config.AcquireLock(config.ShimsLockName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = lock.Release() }()
+
+	// Which remaining versions provide each executable, from disk rather
+	// than the map, so legacy entries without version data are handled too.
+	// Versions another process is installing count as well, so their
+	// shims survive.
+	provided := make(map[string][]string)
+	for _, v := range config.LiveVersions(runtimeName) {
+		if v == version {
+			continue
+		}
+		for _, name := range DiscoverShimsForVersion(config.RuntimeVersionPath(runtimeName, v)) {
+			provided[name] = append(provided[name], v)
+		}
+	}
+
+	shimMap, err := loadShimMapFromDisk()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var removed []string
+	for name, entry := range shimMap {
+		if entry.Runtime != runtimeName {
+			continue
+		}
+		if versions, ok := provided[name]; ok {
+			entry.Versions = versions
+			shimMap[name] = entry
+			continue
+		}
+
+		if err := removeShim(name); err != nil {
+			return removed, err
+		}
+		delete(shimMap, name)
+		removed = append(removed, name)
+	}
+	sort.Strings(removed)
+
+	ResetShimMapCache()
+	if err := SaveShimMap(shimMap); err != nil {
+		return removed, fmt.Errorf("failed to save shim map cache: %w", err)
+	}
+	return removed, nil
+}
+
+// versionListed reports whether version is in versions
+func versionListed(versions []string, version string) bool {
+	for _, v := range versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
 // RemoveShim removes a shim
 func (m *Manager) RemoveShim(shimName string) error {
+	return removeShim(shimName)
+}
+
+// removeShim deletes a shim and, on Windows, its .cmd wrapper
+func removeShim(shimName string) error {
 	shimPath := config.ShimPath(shimName)
 
 	if err := os.Remove(shimPath); err != nil && !os.IsNotExist(err) {
@@ -196,6 +352,9 @@ type RehashResult struct {
 	ShimsByRuntime map[string][]string
 	// TotalShims is the total number of shims created
 	TotalShims int
+	// Removed lists orphaned shims that were deleted because no installed
+	// version provides their executable anymore
+	Removed []string
 }
 
 // RuntimeShimInfo contains shim information for a single runtime
@@ -208,8 +367,17 @@ type RuntimeShimInfo struct {
 // runtimeName is the internal name, displayName is the user-friendly name
 type RehashCallback func(runtimeName, displayName string)
 
-// RehashWithCallback regenerates all shims, calling the callback before each runtime
+// RehashWithCallback regenerates all shims, calling the callback before each runtime.
+// Shims that no installed version provides anymore are removed.
+// The shims lock is held throughout, so concurrent reshims and installs
+// take turns updating the shims directory and the shim map.
 func (m *Manager) RehashWithCallback(callback RehashCallback) (*RehashResult, error) {
+	lock, err := config.AcquireLock(config.ShimsLockName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = lock.Release() }()
+
 	paths := config.DefaultPaths()
 	versionsDir := paths.Versions
 
@@ -244,7 +412,7 @@ func (m *Manager) RehashWithCallback(callback RehashCallback) (*RehashResult, er
 		// Skip if no versions installed
 		hasVersions := false
 		for _, ve := range versionEntries {
-			if ve.IsDir() {
+			if ve.IsDir() && config.IsLiveInstall(versionsDir, runtimeName, ve.Name()) {
 				hasVersions = true
 				break
 			}
@@ -276,7 +444,10 @@ func (m *Manager) RehashWithCallback(callback RehashCallback) (*RehashResult, er
 		}
 
 		for _, versionEntry := range versionEntries {
-			if !versionEntry.IsDir() {
+			// Skip staging directories and interrupted installs. Installs
+			// another process has not committed yet count, so their shims
+			// are not removed as orphans while it creates them.
+			if !versionEntry.IsDir() || !config.IsLiveInstall(versionsDir, runtimeName, versionEntry.Name()) {
 				continue
 			}
 
@@ -297,7 +468,21 @@ func (m *Manager) RehashWithCallback(callback RehashCallback) (*RehashResult, er
 		}
 	}
 
-	if len(shimMap) == 0 {
+	// Shims whose executables are gone from every installed version (e.g.,
+	// after npm uninstall -g or uninstalling a version) are orphans
+	existing, err := m.ListShims()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shims: %w", err)
+	}
+	var orphans []string
+	for _, shimName := range existing {
+		if _, ok := shimMap[shimName]; !ok {
+			orphans = append(orphans, shimName)
+		}
+	}
+	sort.Strings(orphans)
+
+	if len(shimMap) == 0 && len(orphans) == 0 {
 		return nil, fmt.Errorf("no runtimes installed - nothing to reshim")
 	}
 
@@ -305,6 +490,7 @@ func (m *Manager) RehashWithCallback(callback RehashCallback) (*RehashResult, er
 	if err := SaveShimMap(shimMap); err != nil {
 		return nil, fmt.Errorf("failed to save shim map cache: %w", err)
 	}
+	ResetShimMapCache()
 
 	// Create all shims
 	for shimName := range shimMap {
@@ -313,9 +499,16 @@ func (m *Manager) RehashWithCallback(callback RehashCallback) (*RehashResult, er
 		}
 	}
 
+	for _, shimName := range orphans {
+		if err := m.RemoveShim(shimName); err != nil {
+			return nil, err
+		}
+	}
+
 	return &RehashResult{
 		ShimsByRuntime: shimsByRuntime,
 		TotalShims:     len(shimMap),
+		Removed:        orphans,
 	}, nil
 }
 
@@ -334,7 +527,11 @@ func appendUnique(slice []string, s string) []string {
 	return append(slice, s)
 }
 
-// copyFile copies a file from src to dst
+// copyFile copies a file from src to dst. The copy is written to a
+// temporary file next to dst and renamed into place, so a shim that is
+// running (e.g., the npm shim that triggered a reshim) is never truncated
+// and concurrent processes never execute a half-written shim. The copy
+// keeps the permissions of src.
 func copyFile(src, dst string) error {
 	// Open source file
 	srcFile, err := os.Open(src)
@@ -343,20 +540,38 @@ func copyFile(src, dst string) error {
 	}
 	defer func() { _ = srcFile.Close() }()
 
-	// Create destination file
-	dstFile, err := os.Create(dst)
+	srcInfo, err := srcFile.Stat()
+	if err != nil {
+		return err
+	}
+
+	// Create temporary destination file
+	tmpFile, err := os.CreateTemp(filepath.Dir(dst), "."+filepath.Base(dst)+".tmp-*")
 	if err != nil {
 		return err
 	}
-	defer func() { _ = dstFile.Close() }()
+	tmpPath := tmpFile.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
 
 	// Copy contents
-	if _, err := io.Copy(dstFile, srcFile); err != nil {
+	if _, err := io.Copy(tmpFile, srcFile); err != nil {
+		_ = tmpFile.Close()
 		return err
 	}
 
 	// Sync to ensure write is complete
-	return dstFile.Sync()
+	if err := tmpFile.Sync(); err != nil {
+		_ = tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, srcInfo.Mode().Perm()); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, dst)
 }
 
 // RuntimeShims returns the list of shim names for a given runtime
@@ -404,11 +619,8 @@ func DiscoverShimsForVersion(versionDir string) []string {
 		}
 	}
 
-	scan(filepath.Join(versionDir, "bin"))
-
-	if runtime.GOOS == constants.OSWindows {
-		scan(versionDir)
-		scan(filepath.Join(versionDir, "Scripts"))
+	for _, dir := range versionExecutableDirs(versionDir) {
+		scan(dir)
 	}
 
 	out := make([]string, 0, len(seen))
@@ -419,6 +631,30 @@ func DiscoverShimsForVersion(versionDir string) []string {
 	return out
 }
 
+// VersionBinDirs returns the directories of an installed runtime version
+// that hold its executables, in the order they should appear on PATH:
+// `bin/`, plus the version root and `Scripts/` on Windows. Only directories
+// that exist are returned.
+func VersionBinDirs(versionDir string) []string {
+	var dirs []string
+	for _, dir := range versionExecutableDirs(versionDir) {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// versionExecutableDirs lists the directories of a version install that
+// may contain executables, whether or not they exist
+func versionExecutableDirs(versionDir string) []string {
+	dirs := []string{filepath.Join(versionDir, "bin")}
+	if runtime.GOOS == constants.OSWindows {
+		dirs = append(dirs, versionDir, filepath.Join(versionDir, "Scripts"))
+	}
+	return dirs
+}
+
 // findExecutables scans a directory for executable files and returns their base names
 func findExecutables(dir string) ([]string, error) {
 	entries, err := os.ReadDir(dir)
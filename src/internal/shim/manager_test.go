@@ -5,8 +5,10 @@ import (
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"sort"
 	"testing"
 
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
 	runtimepkg "github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
 )
@@ -603,3 +605,304 @@ func TestRuntimeShims_AllKnownRuntimes(t *testing.T) {
 		}
 	}
 }
+
+func TestManager_ReshimVersion(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("DTVEM_ROOT", root)
+	config.ResetPathsCache()
+	ResetShimMapCache()
+	t.Cleanup(func() {
+		config.ResetPathsCache()
+		ResetShimMapCache()
+	})
+
+	shimSource := filepath.Join(root, platformExeName("dtvem-shim"))
+	writeExecutable(t, shimSource)
+	if err := os.MkdirAll(config.DefaultPaths().Shims, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	binDir := filepath.Join(config.RuntimeVersionPath("node", "20.0.0"), "bin")
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeExecutable(t, filepath.Join(binDir, platformExeName("node")))
+
+	m := NewManagerFromSource(shimSource)
+	if err := m.CreateShimsForRuntime("node", "20.0.0", []string{"node"}); err != nil {
+		t.Fatalf("CreateShimsForRuntime failed: %v", err)
+	}
+	if pending := PendingShimsForVersion("node", "20.0.0"); len(pending) != 0 {
+		t.Fatalf("PendingShimsForVersion = %v, want none", pending)
+	}
+
+	// A global package install adds an executable
+	writeExecutable(t, filepath.Join(binDir, platformExeName("tsc")))
+	if pending := PendingShimsForVersion("node", "20.0.0"); !reflect.DeepEqual(pending, []string{"tsc"}) {
+		t.Fatalf("PendingShimsForVersion = %v, want [tsc]", pending)
+	}
+
+	created, err := m.ReshimVersion("node", "20.0.0")
+	if err != nil {
+		t.Fatalf("ReshimVersion failed: %v", err)
+	}
+	if !reflect.DeepEqual(created, []string{"tsc"}) {
+		t.Errorf("ReshimVersion created %v, want [tsc]", created)
+	}
+	if _, err := os.Stat(config.ShimPath("tsc")); err != nil {
+		t.Errorf("tsc shim was not created: %v", err)
+	}
+	if entry, ok := Lookup("tsc"); !ok || entry.Runtime != "node" || !reflect.DeepEqual(entry.Versions, []string{"20.0.0"}) {
+		t.Errorf("Lookup(tsc) = %+v, %v; want node 20.0.0", entry, ok)
+	}
+
+	created, err = m.ReshimVersion("node", "20.0.0")
+	if err != nil || len(created) != 0 {
+		t.Errorf("second ReshimVersion = %v, %v; want nothing created", created, err)
+	}
+}
+
+// rehashLayout points DTVEM_ROOT at a temporary directory with a fake
+// dtvem-shim binary and a node version whose bin/ holds the given
+// executables. Returns the shim source path and the bin directory.
+func rehashLayout(t *testing.T, execs ...string) (string, string) {
+	t.Helper()
+	root := t.TempDir()
+	t.Setenv("DTVEM_ROOT", root)
+	t.Setenv(config.ShimStrategyEnvVar, "")
+	config.ResetPathsCache()
+	ResetShimMapCache()
+	t.Cleanup(func() {
+		config.ResetPathsCache()
+		ResetShimMapCache()
+	})
+
+	shimSource := filepath.Join(root, platformExeName("dtvem-shim"))
+	writeExecutable(t, shimSource)
+	if err := os.MkdirAll(config.DefaultPaths().Shims, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	binDir := filepath.Join(config.RuntimeVersionPath("node", "20.0.0"), "bin")
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range execs {
+		writeExecutable(t, filepath.Join(binDir, platformExeName(name)))
+	}
+	return shimSource, binDir
+}
+
+func TestManager_RehashRemovesOrphans(t *testing.T) {
+	shimSource, binDir := rehashLayout(t, "node", "npm", "tsc")
+	m := NewManagerFromSource(shimSource)
+
+	if _, err := m.Rehash(); err != nil {
+		t.Fatalf("Rehash failed: %v", err)
+	}
+
+	// npm uninstall -g typescript
+	if err := os.Remove(filepath.Join(binDir, platformExeName("tsc"))); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := m.Rehash()
+	if err != nil {
+		t.Fatalf("Rehash failed: %v", err)
+	}
+	if !reflect.DeepEqual(result.Removed, []string{"tsc"}) {
+		t.Errorf("Removed = %v, want [tsc]", result.Removed)
+	}
+	if _, err := os.Stat(config.ShimPath("tsc")); !os.IsNotExist(err) {
+		t.Errorf("orphaned tsc shim still exists (err = %v)", err)
+	}
+	if _, ok := Lookup("tsc"); ok {
+		t.Error("tsc is still in the shim map")
+	}
+
+	shims, err := m.ListShims()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(shims)
+	if !reflect.DeepEqual(shims, []string{"node", "npm"}) {
+		t.Errorf("shims after rehash = %v, want [node npm]", shims)
+	}
+}
+
+func TestManager_CreateShim_LinkStrategies(t *testing.T) {
+	if runtime.GOOS == constants.OSWindows {
+		t.Skip("shim link strategies are Unix-only")
+	}
+
+	tests := []struct {
+		strategy config.ShimStrategy
+		check    func(t *testing.T, shimPath, source string)
+	}{
+		{config.ShimStrategySymlink, func(t *testing.T, shimPath, source string) {
+			// NewManagerFromSource resolves symlinks (e.g., /var on macOS)
+			want, _ := filepath.EvalSymlinks(source)
+			target, err := os.Readlink(shimPath)
+			if err != nil || target != want {
+				t.Errorf("Readlink = %q, %v; want a symlink to %s", target, err, want)
+			}
+		}},
+		{config.ShimStrategyHardlink, func(t *testing.T, shimPath, source string) {
+			shimInfo, _ := os.Lstat(shimPath)
+			sourceInfo, _ := os.Stat(source)
+			if shimInfo == nil || shimInfo.Mode()&os.ModeSymlink != 0 || !os.SameFile(shimInfo, sourceInfo) {
+				t.Errorf("%s is not a hard link to %s", shimPath, source)
+			}
+		}},
+		{config.ShimStrategyCopy, func(t *testing.T, shimPath, source string) {
+			shimInfo, _ := os.Lstat(shimPath)
+			sourceInfo, _ := os.Stat(source)
+			if shimInfo == nil || !shimInfo.Mode().IsRegular() || os.SameFile(shimInfo, sourceInfo) {
+				t.Errorf("%s is not a separate copy of %s", shimPath, source)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			shimSource, _ := rehashLayout(t, "node")
+			t.Setenv(config.ShimStrategyEnvVar, string(tt.strategy))
+			m := NewManagerFromSource(shimSource)
+
+			// Laying a shim down twice replaces it rather than failing
+			for i := 0; i < 2; i++ {
+				if err := m.CreateShim("node"); err != nil {
+					t.Fatalf("CreateShim failed: %v", err)
+				}
+			}
+			tt.check(t, config.ShimPath("node"), shimSource)
+		})
+	}
+}
+
+func TestUnregisterVersion(t *testing.T) {
+	shimSource, _ := rehashLayout(t, "node", "npm")
+	oldBin := filepath.Join(config.RuntimeVersionPath("node", "18.0.0"), "bin")
+	if err := os.MkdirAll(oldBin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"node", "npm", "tsc"} {
+		writeExecutable(t, filepath.Join(oldBin, platformExeName(name)))
+	}
+
+	if _, err := NewManagerFromSource(shimSource).Rehash(); err != nil {
+		t.Fatalf("Rehash failed: %v", err)
+	}
+
+	// dtvem uninstall node 18.0.0 removes the directory first
+	if err := os.RemoveAll(config.RuntimeVersionPath("node", "18.0.0")); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := UnregisterVersion("node", "18.0.0")
+	if err != nil {
+		t.Fatalf("UnregisterVersion failed: %v", err)
+	}
+	if !reflect.DeepEqual(removed, []string{"tsc"}) {
+		t.Errorf("removed = %v, want [tsc]", removed)
+	}
+	if _, err := os.Stat(config.ShimPath("tsc")); !os.IsNotExist(err) {
+		t.Errorf("tsc shim still exists (err = %v)", err)
+	}
+	if _, ok := Lookup("tsc"); ok {
+		t.Error("tsc is still in the shim map")
+	}
+
+	entry, ok := Lookup("node")
+	if !ok {
+		t.Fatal("node was removed from the shim map")
+	}
+	if !reflect.DeepEqual(entry.Versions, []string{"20.0.0"}) {
+		t.Errorf("node versions = %v, want [20.0.0]", entry.Versions)
+	}
+	if _, err := os.Stat(config.ShimPath("node")); err != nil {
+		t.Errorf("node shim was removed: %v", err)
+	}
+}
+
+func TestUnregisterVersion_KeepsInstallInProgress(t *testing.T) {
+	shimSource, _ := rehashLayout(t, "node")
+	if _, err := NewManagerFromSource(shimSource).Rehash(); err != nil {
+		t.Fatalf("Rehash failed: %v", err)
+	}
+	if err := config.EnableInstallMarkers(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another process has moved 22.0.0 into place and is creating its shims
+	newBin := filepath.Join(config.RuntimeVersionPath("node", "22.0.0"), "bin")
+	if err := os.MkdirAll(newBin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeExecutable(t, filepath.Join(newBin, platformExeName("corepack")))
+	if err := NewManagerFromSource(shimSource).CreateShimsForRuntime("node", "22.0.0", []string{"corepack"}); err != nil {
+		t.Fatal(err)
+	}
+	lock, err := config.AcquireLock(config.InstallLockName("node", "22.0.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = lock.Release() }()
+
+	if err := os.RemoveAll(config.RuntimeVersionPath("node", "20.0.0")); err != nil {
+		t.Fatal(err)
+	}
+	removed, err := UnregisterVersion("node", "20.0.0")
+	if err != nil {
+		t.Fatalf("UnregisterVersion failed: %v", err)
+	}
+	if !reflect.DeepEqual(removed, []string{"node"}) {
+		t.Errorf("removed = %v, want [node]", removed)
+	}
+	if _, err := os.Stat(config.ShimPath("corepack")); err != nil {
+		t.Errorf("shim of the install in progress was removed: %v", err)
+	}
+}
+
+func TestManager_RehashKeepsInstallInProgress(t *testing.T) {
+	shimSource, _ := rehashLayout(t, "node")
+	m := NewManagerFromSource(shimSource)
+	if err := config.EnableInstallMarkers(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another process has moved 22.0.0 into place but not committed it
+	newBin := filepath.Join(config.RuntimeVersionPath("node", "22.0.0"), "bin")
+	if err := os.MkdirAll(newBin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeExecutable(t, filepath.Join(newBin, platformExeName("corepack")))
+	if err := m.CreateShimsForRuntime("node", "22.0.0", []string{"corepack"}); err != nil {
+		t.Fatal(err)
+	}
+	lock, err := config.AcquireLock(config.InstallLockName("node", "22.0.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := m.Rehash()
+	if err != nil {
+		t.Fatalf("Rehash failed: %v", err)
+	}
+	if len(result.Removed) != 0 {
+		t.Errorf("Removed = %v while 22.0.0 is being installed, want none", result.Removed)
+	}
+	if _, err := os.Stat(config.ShimPath("corepack")); err != nil {
+		t.Errorf("corepack shim was removed: %v", err)
+	}
+
+	// Once the install is abandoned, its shims are orphans
+	_ = lock.Release()
+	result, err = m.Rehash()
+	if err != nil {
+		t.Fatalf("Rehash failed: %v", err)
+	}
+	if !reflect.DeepEqual(result.Removed, []string{"corepack"}) {
+		t.Errorf("Removed = %v, want [corepack]", result.Removed)
+	}
+}
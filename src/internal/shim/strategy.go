@@ -0,0 +1,60 @@
+package shim
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
+)
+
+// Strategy returns the shim strategy in effect. Windows shims are .exe
+// copies with .cmd wrappers, and symlinks there need elevated privileges,
+// so Windows always copies.
+func Strategy() config.ShimStrategy {
+	if runtime.GOOS == constants.OSWindows {
+		return config.ShimStrategyCopy
+	}
+	return config.GetShimStrategy()
+}
+
+// symlinkFile points dst at src with a symlink. The link is created next to
+// dst and renamed into place, so dst is replaced atomically.
+func symlinkFile(src, dst string) error {
+	return replaceWith(dst, func(tmpPath string) error {
+		return os.Symlink(src, tmpPath)
+	})
+}
+
+// hardlinkFile makes dst a hard link to src. The link is created next to dst
+// and renamed into place, so dst is replaced atomically and a file that was
+// linked to src before is never written through.
+func hardlinkFile(src, dst string) error {
+	return replaceWith(dst, func(tmpPath string) error {
+		return os.Link(src, tmpPath)
+	})
+}
+
+// replaceWith creates a file at a temporary path next to dst with create,
+// then renames it over dst
+func replaceWith(dst string, create func(tmpPath string) error) error {
+	tmpFile, err := os.CreateTemp(filepath.Dir(dst), "."+filepath.Base(dst)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmpFile.Name()
+	_ = tmpFile.Close()
+	if err := os.Remove(tmpPath); err != nil {
+		return err
+	}
+
+	if err := create(tmpPath); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, dst); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
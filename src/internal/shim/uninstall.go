@@ -0,0 +1,23 @@
+//go:build !shim
+
+package shim
+
+import (
+	"fmt"
+
+	runtimepkg "github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
+)
+
+// UninstallVersion removes an installed version of a runtime, then deletes
+// the shims of executables that no other installed version provides and
+// drops the version from the shim map. Providers implement Uninstall with it.
+func UninstallVersion(provider runtimepkg.ShimProvider, version string) error {
+	if err := runtimepkg.RemoveInstall(provider.Name(), version); err != nil {
+		return err
+	}
+
+	if _, err := UnregisterVersion(provider.Name(), version); err != nil {
+		return fmt.Errorf("%s %s was removed, but updating shims failed (run 'dtvem reshim'): %w", provider.DisplayName(), version, err)
+	}
+	return nil
+}
@@ -203,8 +203,9 @@ func GetCrossMark() string {
 }
 
 // RenderLifecycleStatus renders a lifecycle status label with color coding.
-// Current → Cyan, Active LTS → Green, Maintenance LTS → Yellow, EOL → Red.
-// Unknown labels are returned unstyled.
+// Current and Prerelease → Cyan, Active LTS, Bugfix and Normal Maintenance →
+// Green, Maintenance LTS and Security → Yellow, EOL → Red. Unknown labels are
+// returned unstyled.
 func RenderLifecycleStatus(status string) string {
 	if status == "" {
 		return ""
@@ -212,11 +213,11 @@ func RenderLifecycleStatus(status string) string {
 	initStyles()
 
 	switch status {
-	case "Current":
+	case "Current", "Prerelease":
 		return lipgloss.NewStyle().Foreground(colorPrimary).Render(status)
-	case "Active LTS":
+	case "Active LTS", "Bugfix", "Normal Maintenance":
 		return lipgloss.NewStyle().Foreground(colorSuccess).Bold(true).Render(status)
-	case "Maintenance LTS":
+	case "Maintenance LTS", "Security":
 		return lipgloss.NewStyle().Foreground(colorWarning).Render(status)
 	case "EOL":
 		return lipgloss.NewStyle().Foreground(colorError).Render(status)
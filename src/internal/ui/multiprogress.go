@@ -0,0 +1,307 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+)
+
+// ProgressReporter receives progress updates for a single long-running task,
+// such as one runtime install in a MultiProgress view.
+type ProgressReporter interface {
+	// Status replaces the task's current status message
+	Status(message string)
+
+	// Bytes reports transfer progress. total is -1 when the size is unknown.
+	Bytes(current, total int64)
+}
+
+// taskState is the lifecycle state of a MultiProgress task
+type taskState int
+
+const (
+	taskPending taskState = iota
+	taskRunning
+	taskDone
+	taskFailed
+)
+
+// MultiProgress renders one status line per task and redraws them in place,
+// so several concurrent operations can share the terminal. While a
+// MultiProgress is running, messages printed through this package (Info,
+// Warning, Debug, ...) are written above the progress block instead of
+// corrupting it.
+//
+// When stdout is not a terminal, lines are printed once per state change
+// rather than redrawn.
+type MultiProgress struct {
+	mu       sync.Mutex
+	out      io.Writer
+	tty      bool
+	tasks    []*ProgressTask
+	rendered int
+	frame    int
+	stop     chan struct{}
+	stopped  chan struct{}
+}
+
+// ProgressTask is a single line in a MultiProgress view. It implements
+// ProgressReporter and is safe for use from the goroutine running the task.
+type ProgressTask struct {
+	parent  *MultiProgress
+	label   string
+	status  string
+	state   taskState
+	current int64
+	total   int64
+}
+
+// activeProgress is the running MultiProgress, if any
+var (
+	activeProgressMu sync.Mutex
+	activeProgress   *MultiProgress
+)
+
+// NewMultiProgress creates a progress view that writes to stdout
+func NewMultiProgress() *MultiProgress {
+	return &MultiProgress{
+		out: color.Output,
+		tty: IsTerminal(os.Stdout),
+	}
+}
+
+// AddTask adds a pending task line with the given label
+func (m *MultiProgress) AddTask(label string) *ProgressTask {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task := &ProgressTask{parent: m, label: label, status: "Waiting...", total: -1}
+	m.tasks = append(m.tasks, task)
+	return task
+}
+
+// Start begins rendering. Call Stop when every task has finished.
+func (m *MultiProgress) Start() {
+	m.stop = make(chan struct{})
+	m.stopped = make(chan struct{})
+
+	activeProgressMu.Lock()
+	activeProgress = m
+	activeProgressMu.Unlock()
+
+	if !m.tty {
+		close(m.stopped)
+		return
+	}
+
+	go func() {
+		defer close(m.stopped)
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				m.mu.Lock()
+				m.frame++
+				m.redraw()
+				m.mu.Unlock()
+			case <-m.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops rendering and leaves the final state of every task on screen
+func (m *MultiProgress) Stop() {
+	close(m.stop)
+	<-m.stopped
+
+	activeProgressMu.Lock()
+	activeProgress = nil
+	activeProgressMu.Unlock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.tty {
+		m.redraw()
+	}
+}
+
+// println writes a message above the progress block
+func (m *MultiProgress) println(line string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.tty {
+		m.clear()
+	}
+	_, _ = fmt.Fprintln(m.out, line)
+	if m.tty {
+		m.redraw()
+	}
+}
+
+// clear erases the previously rendered progress block. Callers hold m.mu.
+func (m *MultiProgress) clear() {
+	if m.rendered == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(m.out, "\033[%dA", m.rendered)
+	for i := 0; i < m.rendered; i++ {
+		_, _ = fmt.Fprint(m.out, "\033[2K\n")
+	}
+	_, _ = fmt.Fprintf(m.out, "\033[%dA", m.rendered)
+	m.rendered = 0
+}
+
+// redraw renders every task line in place. Callers hold m.mu.
+func (m *MultiProgress) redraw() {
+	if m.rendered > 0 {
+		_, _ = fmt.Fprintf(m.out, "\033[%dA", m.rendered)
+	}
+
+	width := 0
+	for _, task := range m.tasks {
+		if len(task.label) > width {
+			width = len(task.label)
+		}
+	}
+
+	for _, task := range m.tasks {
+		_, _ = fmt.Fprintf(m.out, "\r\033[2K%s\n", task.render(width, m.frame))
+	}
+	m.rendered = len(m.tasks)
+}
+
+// logTransition prints a task line when not drawing to a terminal.
+// Callers hold m.mu.
+func (m *MultiProgress) logTransition(task *ProgressTask) {
+	if m.tty || m.stop == nil {
+		return
+	}
+	_, _ = fmt.Fprintln(m.out, task.render(len(task.label), 0))
+}
+
+// Status implements ProgressReporter
+func (t *ProgressTask) Status(message string) {
+	t.parent.mu.Lock()
+	defer t.parent.mu.Unlock()
+
+	if t.state == taskPending {
+		t.state = taskRunning
+	}
+	if t.status == message {
+		return
+	}
+	t.status = message
+	t.current, t.total = 0, -1
+	t.parent.logTransition(t)
+}
+
+// Bytes implements ProgressReporter
+func (t *ProgressTask) Bytes(current, total int64) {
+	t.parent.mu.Lock()
+	defer t.parent.mu.Unlock()
+
+	t.current, t.total = current, total
+}
+
+// Done marks the task as successfully finished
+func (t *ProgressTask) Done(message string) {
+	t.finish(taskDone, message)
+}
+
+// Fail marks the task as failed
+func (t *ProgressTask) Fail(message string) {
+	t.finish(taskFailed, message)
+}
+
+func (t *ProgressTask) finish(state taskState, message string) {
+	t.parent.mu.Lock()
+	defer t.parent.mu.Unlock()
+
+	t.state = state
+	t.status = message
+	t.current, t.total = 0, -1
+	t.parent.logTransition(t)
+}
+
+// spinnerFrames matches the dots style used by NewSpinner
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// render formats the task as a single line, padding the label to width
+func (t *ProgressTask) render(width, frame int) string {
+	label := t.label + strings.Repeat(" ", width-len(t.label))
+
+	switch t.state {
+	case taskDone:
+		return successColor.Sprintf("%s %s  %s", successSymbol, label, t.status)
+	case taskFailed:
+		return errorColor.Sprintf("%s %s  %s", errorSymbol, label, t.status)
+	case taskPending:
+		return debugColor.Sprintf("%s %s  %s", debugSymbol, label, t.status)
+	}
+
+	line := fmt.Sprintf("%s %s  %s", progressColor.Sprint(spinnerFrames[frame%len(spinnerFrames)]), label, t.status)
+	if t.current > 0 {
+		line += "  " + formatTransfer(t.current, t.total)
+	}
+	return line
+}
+
+// formatTransfer renders download progress as a bar when the size is known,
+// or as a byte count otherwise
+func formatTransfer(current, total int64) string {
+	if total <= 0 {
+		return FormatBytes(current)
+	}
+	if current > total {
+		current = total
+	}
+
+	const barWidth = 20
+	filled := int(current * barWidth / total)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+	return fmt.Sprintf("%s %3d%% %s/%s", bar, current*100/total, FormatBytes(current), FormatBytes(total))
+}
+
+// FormatBytes renders a byte count using binary units (e.g., "12.3 MiB")
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// IsTerminal reports whether f is attached to a terminal, including Cygwin
+// and MSYS terminals on Windows
+func IsTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// printAbove writes line above the active MultiProgress block. Returns false
+// when no MultiProgress is running and the caller should print normally.
+func printAbove(line string) bool {
+	activeProgressMu.Lock()
+	m := activeProgress
+	activeProgressMu.Unlock()
+
+	if m == nil {
+		return false
+	}
+	m.println(line)
+	return true
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		input    int64
+		expected string
+	}{
+		{512, "512 B"},
+		{2048, "2.0 KiB"},
+		{5 * 1024 * 1024, "5.0 MiB"},
+		{3 * 1024 * 1024 * 1024, "3.0 GiB"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatBytes(tt.input); got != tt.expected {
+			t.Errorf("FormatBytes(%d) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestFormatTransfer(t *testing.T) {
+	if got := formatTransfer(1024, -1); got != "1.0 KiB" {
+		t.Errorf("formatTransfer with unknown total = %q, want %q", got, "1.0 KiB")
+	}
+
+	got := formatTransfer(50, 100)
+	if !strings.Contains(got, " 50%") {
+		t.Errorf("formatTransfer(50, 100) = %q, want it to contain 50%%", got)
+	}
+}
+
+func TestMultiProgress_NonTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	m := &MultiProgress{out: &buf}
+
+	node := m.AddTask("Node.js 22.0.0")
+	python := m.AddTask("Python 3.12.1")
+
+	m.Start()
+	node.Status("Downloading...")
+	node.Bytes(10, 100)
+	Info("routed above the progress block")
+	python.Fail("download failed")
+	node.Done("Installed")
+	m.Stop()
+
+	output := buf.String()
+	for _, want := range []string{
+		"Node.js 22.0.0  Downloading...",
+		"routed above the progress block",
+		"Python 3.12.1  download failed",
+		"Node.js 22.0.0  Installed",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+
+	// Byte progress is only drawn on terminals
+	if strings.Contains(output, "%") {
+		t.Errorf("non-terminal output should not include transfer progress:\n%s", output)
+	}
+}
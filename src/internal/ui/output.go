@@ -34,36 +34,40 @@ var (
 
 	// Verbose mode flag - controls debug output visibility
 	verboseMode = false
+
+	// stderrMode sends messages to stderr, keeping stdout for
+	// machine-readable command output
+	stderrMode = false
 )
 
 // Success prints a success message in green with a checkmark
 func Success(format string, args ...interface{}) {
 	message := fmt.Sprintf(format, args...)
-	_, _ = successColor.Printf("%s %s\n", successSymbol, message)
+	emit(successColor.Sprintf("%s %s", successSymbol, message))
 }
 
 // Error prints an error message in red with an X
 func Error(format string, args ...interface{}) {
 	message := fmt.Sprintf(format, args...)
-	_, _ = errorColor.Printf("%s %s\n", errorSymbol, message)
+	emit(errorColor.Sprintf("%s %s", errorSymbol, message))
 }
 
 // Warning prints a warning message in yellow with a warning symbol
 func Warning(format string, args ...interface{}) {
 	message := fmt.Sprintf(format, args...)
-	_, _ = warningColor.Printf("%s %s\n", warningSymbol, message)
+	emit(warningColor.Sprintf("%s %s", warningSymbol, message))
 }
 
 // Info prints an info message in cyan with an arrow
 func Info(format string, args ...interface{}) {
 	message := fmt.Sprintf(format, args...)
-	_, _ = infoColor.Printf("%s %s\n", infoSymbol, message)
+	emit(infoColor.Sprintf("%s %s", infoSymbol, message))
 }
 
 // Progress prints a progress message in blue with an arrow
 func Progress(format string, args ...interface{}) {
 	message := fmt.Sprintf(format, args...)
-	_, _ = progressColor.Printf("  %s %s\n", infoSymbol, message)
+	emit(progressColor.Sprintf("  %s %s", infoSymbol, message))
 }
 
 // Debug prints a debug message only when verbose mode is enabled
@@ -74,7 +78,7 @@ func Debug(format string, args ...interface{}) {
 	}
 	message := fmt.Sprintf(format, args...)
 	timestamp := time.Now().Format("15:04:05.000")
-	_, _ = debugColor.Printf("%s %s %s\n", debugSymbol, timestamp, message)
+	emit(debugColor.Sprintf("%s %s %s", debugSymbol, timestamp, message))
 }
 
 // Debugf is an alias for Debug (for consistency with fmt.Printf naming)
@@ -101,6 +105,25 @@ func CheckVerboseEnv() {
 	}
 }
 
+// SetStderrMode sends status messages to stderr instead of stdout. It is
+// enabled with --output json|yaml so that stdout holds only the result.
+func SetStderrMode(enabled bool) {
+	stderrMode = enabled
+}
+
+// emit prints a formatted line, routing it above the progress block when a
+// MultiProgress is running
+func emit(line string) {
+	if printAbove(line) {
+		return
+	}
+	if stderrMode {
+		_, _ = fmt.Fprintln(color.Error, line)
+		return
+	}
+	_, _ = fmt.Fprintln(color.Output, line)
+}
+
 // Println prints a regular message without color
 func Println(format string, args ...interface{}) {
 	fmt.Printf(format+"\n", args...)
@@ -114,8 +137,7 @@ func Printf(format string, args ...interface{}) {
 // Header prints a bold header message
 func Header(format string, args ...interface{}) {
 	message := fmt.Sprintf(format, args...)
-	bold := color.New(color.Bold)
-	_, _ = bold.Println(message)
+	emit(color.New(color.Bold).Sprint(message))
 }
 
 // Highlight prints text in a highlighted color (for emphasis)
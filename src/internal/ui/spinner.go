@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"os"
 	"time"
 
 	"github.com/briandowns/spinner"
@@ -11,13 +12,20 @@ type Spinner struct {
 	spinner *spinner.Spinner
 }
 
-// NewSpinner creates a new spinner with a message
+// NewSpinner creates a new spinner with a message. Like other messages, the
+// spinner goes to stderr in stderr mode.
 func NewSpinner(message string) *Spinner {
+	options := []spinner.Option{
+		spinner.WithColor("cyan"),
+		spinner.WithSuffix(" " + message),
+	}
+	if stderrMode {
+		options = append(options, spinner.WithWriter(os.Stderr))
+	}
 	s := spinner.New(
 		spinner.CharSets[14], // dots style
 		100*time.Millisecond,
-		spinner.WithColor("cyan"),
-		spinner.WithSuffix(" "+message),
+		options...,
 	)
 	return &Spinner{spinner: s}
 }
@@ -35,19 +43,19 @@ func (s *Spinner) Stop() {
 // Success stops the spinner and shows a success message
 func (s *Spinner) Success(message string) {
 	s.spinner.Stop()
-	_, _ = successColor.Printf("%s %s\n", successSymbol, message)
+	emit(successColor.Sprintf("%s %s", successSymbol, message))
 }
 
 // Error stops the spinner and shows an error message
 func (s *Spinner) Error(message string) {
 	s.spinner.Stop()
-	_, _ = errorColor.Printf("%s %s\n", errorSymbol, message)
+	emit(errorColor.Sprintf("%s %s", errorSymbol, message))
 }
 
 // Warning stops the spinner and shows a warning message
 func (s *Spinner) Warning(message string) {
 	s.spinner.Stop()
-	_, _ = warningColor.Printf("%s %s\n", warningSymbol, message)
+	emit(warningColor.Sprintf("%s %s", warningSymbol, message))
 }
 
 // UpdateMessage updates the spinner message while it's running
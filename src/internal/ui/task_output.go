@@ -0,0 +1,94 @@
+package ui
+
+import "fmt"
+
+// TaskOutput writes the progress of a multi-step operation such as a runtime
+// install. With a nil reporter it prints headers, progress lines and spinners
+// to the console as usual; with a reporter, each step becomes the reporter's
+// status and decorative output is suppressed.
+type TaskOutput struct {
+	reporter ProgressReporter
+}
+
+// NewTaskOutput creates a TaskOutput for the given reporter, which may be nil
+func NewTaskOutput(reporter ProgressReporter) TaskOutput {
+	return TaskOutput{reporter: reporter}
+}
+
+// Reporter returns the underlying reporter, or nil for console output
+func (o TaskOutput) Reporter() ProgressReporter {
+	return o.reporter
+}
+
+// Header prints a header on the console. Suppressed when reporting.
+func (o TaskOutput) Header(format string, args ...interface{}) {
+	if o.reporter == nil {
+		Header(format, args...)
+	}
+}
+
+// Progress prints a progress line, or sets it as the reporter's status
+func (o TaskOutput) Progress(format string, args ...interface{}) {
+	if o.reporter == nil {
+		Progress(format, args...)
+		return
+	}
+	o.reporter.Status(fmt.Sprintf(format, args...))
+}
+
+// Success prints a success message on the console. Suppressed when reporting;
+// the caller marks the task as done instead.
+func (o TaskOutput) Success(format string, args ...interface{}) {
+	if o.reporter == nil {
+		Success(format, args...)
+	}
+}
+
+// Info prints an info message on the console. Suppressed when reporting.
+func (o TaskOutput) Info(format string, args ...interface{}) {
+	if o.reporter == nil {
+		Info(format, args...)
+	}
+}
+
+// StartStep begins a step, showing a spinner on the console or setting the
+// reporter's status
+func (o TaskOutput) StartStep(message string) *Step {
+	if o.reporter != nil {
+		o.reporter.Status(message)
+		return &Step{}
+	}
+
+	s := NewSpinner(message)
+	s.Start()
+	return &Step{spinner: s}
+}
+
+// Step is a running step started by TaskOutput.StartStep
+type Step struct {
+	spinner *Spinner
+}
+
+// Success ends the step with a success message
+func (s *Step) Success(message string) {
+	if s.spinner != nil {
+		s.spinner.Success(message)
+	}
+}
+
+// Error ends the step with an error message
+func (s *Step) Error(message string) {
+	if s.spinner != nil {
+		s.spinner.Error(message)
+	}
+}
+
+// Warning ends the step with a warning message. When reporting, the warning
+// is printed above the progress view so it isn't lost.
+func (s *Step) Warning(message string) {
+	if s.spinner != nil {
+		s.spinner.Warning(message)
+		return
+	}
+	Warning("%s", message)
+}
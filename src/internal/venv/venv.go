@@ -0,0 +1,363 @@
+// Package venv manages a project's Python virtual environment.
+//
+// A project declares its venv in .dtvem/venv.json, next to the
+// runtimes.json that pins its Python version:
+//
+//	{
+//	  "path": ".venv",
+//	  "requirements": ["requirements.txt"]
+//	}
+//
+// The venv is built from the interpreter of the resolved Python version,
+// and the Python version it was built with is read back from the venv's
+// pyvenv.cfg. When the project's pinned version no longer resolves to that
+// version, the venv is stale and must be rebuilt; shims stop using it
+// until it is.
+package venv
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/filelock"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
+)
+
+// Runtime is the runtime project venvs are built with
+const Runtime = "python"
+
+// ConfigFileName is the name of the venv declaration in a project's .dtvem
+// directory
+const ConfigFileName = "venv.json"
+
+// DefaultPath is the venv directory, relative to the project, used when the
+// declaration doesn't name one
+const DefaultPath = ".venv"
+
+// SchemaURL is the URL to the venv.json schema
+const SchemaURL = "https://raw.githubusercontent.com/CodingWithCalvin/dtvem.cli/main/schemas/venv.schema.json"
+
+// pyvenvFileName is the file every venv has at its root, recording the
+// interpreter it was created from
+const pyvenvFileName = "pyvenv.cfg"
+
+// warningsDirName is the directory under Paths.Cache recording, per project,
+// the venv state shims last warned about
+const warningsDirName = "venv-warnings"
+
+// ErrNotDeclared is returned by Find when the project declares no venv
+var ErrNotDeclared = errors.New("no project venv declared")
+
+// Config is the contents of .dtvem/venv.json
+type Config struct {
+	Schema string `json:"$schema,omitempty"`
+	// Path is the venv directory, relative to the project directory
+	Path string `json:"path,omitempty"`
+	// Requirements are pip requirements files, relative to the project
+	// directory, installed into the venv when it is built or synced
+	Requirements []string `json:"requirements,omitempty"`
+}
+
+// Project is a project that declares a venv
+type Project struct {
+	Root       string // Project directory, the parent of .dtvem
+	ConfigPath string // Path of the venv.json declaration
+	Config     Config
+}
+
+// State describes a venv relative to the Python version the project pins
+type State string
+
+const (
+	// Missing means the venv has not been created yet
+	Missing State = "missing"
+	// Stale means the venv was built with another Python version, or its
+	// interpreter is gone, and must be rebuilt
+	Stale State = "stale"
+	// Ready means the venv was built with the pinned Python version
+	Ready State = "ready"
+)
+
+// Status is the result of Project.Check
+type Status struct {
+	State   State
+	Version string // Python version the venv was built with, if it exists
+	Reason  string // Why the venv is stale
+}
+
+// Find returns the project that declares a venv for dir. The venv belongs
+// to the project in ProjectRoot(dir); Find returns ErrNotDeclared if that
+// project has no venv.json.
+func Find(dir string) (*Project, error) {
+	return Load(ProjectRoot(dir))
+}
+
+// ProjectRoot returns the project directory for dir: the nearest directory
+// at or above dir with a .dtvem directory, or dir itself if there is none.
+// dtvem's own root directory (~/.dtvem) does not mark a project.
+func ProjectRoot(dir string) string {
+	dtvemRoot := filepath.Clean(config.DefaultPaths().Root)
+	for current := dir; ; {
+		configDir := filepath.Join(current, config.LocalConfigDirName)
+		if info, err := os.Stat(configDir); err == nil && info.IsDir() && configDir != dtvemRoot {
+			return current
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return dir
+		}
+		current = parent
+	}
+}
+
+// Load reads the venv declaration of the project in root. Returns
+// ErrNotDeclared if there is none.
+func Load(root string) (*Project, error) {
+	configPath := filepath.Join(root, config.LocalConfigDirName, ConfigFileName)
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotDeclared
+		}
+		return nil, err
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", configPath, err)
+	}
+	return &Project{Root: root, ConfigPath: configPath, Config: cfg}, nil
+}
+
+// Declare writes a venv declaration for the project in root
+func Declare(root string, cfg Config) (*Project, error) {
+	if cfg.Schema == "" {
+		cfg.Schema = SchemaURL
+	}
+	if cfg.Path == "" {
+		cfg.Path = DefaultPath
+	}
+	p := &Project{
+		Root:       root,
+		ConfigPath: filepath.Join(root, config.LocalConfigDirName, ConfigFileName),
+		Config:     cfg,
+	}
+	if p.Dir() == filepath.Clean(root) {
+		return nil, fmt.Errorf("venv path %q is the project directory", cfg.Path)
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(p.ConfigPath), 0755); err != nil {
+		return nil, err
+	}
+
+	lock, err := config.AcquireLock(config.FileLockName(p.ConfigPath))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = lock.Release() }()
+
+	if err := filelock.WriteFile(p.ConfigPath, data, 0644); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Undeclare removes the project's venv declaration
+func (p *Project) Undeclare() error {
+	if err := os.Remove(p.ConfigPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Dir returns the absolute path of the venv directory
+func (p *Project) Dir() string {
+	venvPath := p.Config.Path
+	if venvPath == "" {
+		venvPath = DefaultPath
+	}
+	if filepath.IsAbs(venvPath) {
+		return filepath.Clean(venvPath)
+	}
+	return filepath.Join(p.Root, venvPath)
+}
+
+// Interpreter returns the path of the venv's Python interpreter
+func (p *Project) Interpreter() string {
+	if goruntime.GOOS == constants.OSWindows {
+		return filepath.Join(p.Dir(), "Scripts", "python.exe")
+	}
+	return filepath.Join(p.Dir(), "bin", "python")
+}
+
+// BuiltVersion returns the Python version the venv was built with, read
+// from its pyvenv.cfg
+func (p *Project) BuiltVersion() (string, error) {
+	f, err := os.Open(filepath.Join(p.Dir(), pyvenvFileName))
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if ok {
+			values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	// The venv module writes "version"; virtualenv writes "version_info",
+	// e.g. "3.12.1.final.0"
+	if version := values["version"]; version != "" {
+		return version, nil
+	}
+	if parts := strings.Split(values["version_info"], "."); len(parts) >= 3 {
+		return strings.Join(parts[:3], "."), nil
+	}
+	return "", fmt.Errorf("%s does not record a Python version", filepath.Join(p.Dir(), pyvenvFileName))
+}
+
+// Check compares the venv with the Python version the project pins,
+// resolved to an installed version
+func (p *Project) Check(version string) Status {
+	built, err := p.BuiltVersion()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Status{State: Missing}
+		}
+		return Status{State: Stale, Reason: err.Error()}
+	}
+
+	if built != version {
+		return Status{State: Stale, Version: built, Reason: fmt.Sprintf("built with Python %s, the project uses %s", built, version)}
+	}
+	if _, err := os.Stat(p.Interpreter()); err != nil {
+		return Status{State: Stale, Version: built, Reason: "its Python interpreter no longer exists"}
+	}
+	return Status{State: Ready, Version: built}
+}
+
+// ShouldWarn reports whether to warn that the venv is not usable in the
+// given state. Shims run often, so each state is reported once: the state
+// is recorded, and the warning repeats only once it changes. A ready venv
+// clears the record, so a later problem is reported again.
+func (p *Project) ShouldWarn(status Status) bool {
+	sum := sha256.Sum256([]byte(p.Dir()))
+	recordPath := filepath.Join(config.DefaultPaths().Cache, warningsDirName, hex.EncodeToString(sum[:8]))
+
+	if status.State == Ready {
+		_ = os.Remove(recordPath)
+		return false
+	}
+
+	record := string(status.State) + ": " + status.Reason
+	if data, err := os.ReadFile(recordPath); err == nil && string(data) == record {
+		return false
+	}
+	if err := os.MkdirAll(filepath.Dir(recordPath), 0755); err == nil {
+		_ = filelock.WriteFile(recordPath, []byte(record), 0644)
+	}
+	return true
+}
+
+// ResolvePython returns the installed Python version the current directory
+// resolves to and the path of its interpreter, which the venv is built from
+func ResolvePython() (version, pythonPath string, err error) {
+	provider, err := runtime.GetShimProvider(Runtime)
+	if err != nil {
+		return "", "", err
+	}
+	resolved, err := config.ResolveVersionSource(Runtime)
+	if err != nil {
+		return "", "", err
+	}
+	version, err = runtime.ResolveInstalledVersion(provider, resolved.Version)
+	if err != nil {
+		return "", "", fmt.Errorf("no installed %s version satisfies %s", provider.DisplayName(), resolved.Version)
+	}
+	installed, err := provider.IsInstalled(version)
+	if err != nil {
+		return "", "", err
+	}
+	if !installed {
+		return "", "", fmt.Errorf("%s %s is configured but not installed", provider.DisplayName(), version)
+	}
+	pythonPath, err = provider.ExecutablePath(version)
+	if err != nil {
+		return "", "", err
+	}
+	return version, pythonPath, nil
+}
+
+// Build creates the venv from the interpreter at pythonPath and installs
+// its requirements, replacing an existing venv. Command output is written
+// to out.
+func (p *Project) Build(pythonPath string, out io.Writer) error {
+	if err := p.Remove(); err != nil {
+		return err
+	}
+	if err := run(out, pythonPath, "-m", "venv", p.Dir()); err != nil {
+		return fmt.Errorf("failed to create venv: %w", err)
+	}
+	return p.InstallRequirements(out)
+}
+
+// InstallRequirements installs the declared requirements files into the
+// venv with pip
+func (p *Project) InstallRequirements(out io.Writer) error {
+	for _, requirements := range p.Config.Requirements {
+		if !filepath.IsAbs(requirements) {
+			requirements = filepath.Join(p.Root, requirements)
+		}
+		if _, err := os.Stat(requirements); err != nil {
+			return fmt.Errorf("requirements file not found: %s", requirements)
+		}
+		if err := run(out, p.Interpreter(), "-m", "pip", "install", "-r", requirements); err != nil {
+			return fmt.Errorf("failed to install %s: %w", filepath.Base(requirements), err)
+		}
+	}
+	return nil
+}
+
+// Remove deletes the venv directory. A directory that isn't a venv (it has
+// no pyvenv.cfg) is never deleted.
+func (p *Project) Remove() error {
+	dir := p.Dir()
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(dir, pyvenvFileName)); err != nil {
+		return fmt.Errorf("%s is not a virtual environment; remove or rename it first", dir)
+	}
+	return os.RemoveAll(dir)
+}
+
+// run executes a command in the current directory, sending its output to out
+func run(out io.Writer, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	return cmd.Run()
+}
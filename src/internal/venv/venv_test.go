@@ -0,0 +1,234 @@
+package venv
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+)
+
+// writeVenv creates a fake venv for p with the given pyvenv.cfg contents
+// and an interpreter file
+func writeVenv(t *testing.T, p *Project, pyvenv string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(p.Interpreter()), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p.Interpreter(), []byte(""), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(p.Dir(), pyvenvFileName), []byte(pyvenv), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFind(t *testing.T) {
+	t.Setenv("DTVEM_ROOT", t.TempDir())
+	config.ResetPathsCache()
+	t.Cleanup(config.ResetPathsCache)
+
+	root := t.TempDir()
+	sub := filepath.Join(root, "src", "pkg")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Find(sub); !errors.Is(err, ErrNotDeclared) {
+		t.Fatalf("Find() without a declaration error = %v, want ErrNotDeclared", err)
+	}
+	if got := ProjectRoot(sub); got != sub {
+		t.Errorf("ProjectRoot() without .dtvem = %q, want %q", got, sub)
+	}
+
+	declared, err := Declare(root, Config{Requirements: []string{"requirements.txt"}})
+	if err != nil {
+		t.Fatalf("Declare() error = %v", err)
+	}
+	if declared.Config.Path != DefaultPath || declared.Config.Schema != SchemaURL {
+		t.Errorf("Declare() config = %+v, want default path and schema", declared.Config)
+	}
+
+	project, err := Find(sub)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if project.Root != root {
+		t.Errorf("Find() root = %q, want %q", project.Root, root)
+	}
+	if project.Dir() != filepath.Join(root, DefaultPath) {
+		t.Errorf("Dir() = %q, want %q", project.Dir(), filepath.Join(root, DefaultPath))
+	}
+	if len(project.Config.Requirements) != 1 || project.Config.Requirements[0] != "requirements.txt" {
+		t.Errorf("Find() requirements = %v", project.Config.Requirements)
+	}
+
+	// A nested .dtvem directory starts another project, which has no venv
+	if err := os.MkdirAll(filepath.Join(sub, config.LocalConfigDirName), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Find(sub); !errors.Is(err, ErrNotDeclared) {
+		t.Errorf("Find() in a nested project error = %v, want ErrNotDeclared", err)
+	}
+
+	if err := project.Undeclare(); err != nil {
+		t.Fatalf("Undeclare() error = %v", err)
+	}
+	if _, err := Find(root); !errors.Is(err, ErrNotDeclared) {
+		t.Errorf("Find() after Undeclare() error = %v, want ErrNotDeclared", err)
+	}
+}
+
+func TestProjectRoot_SkipsDtvemRoot(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("DTVEM_ROOT", filepath.Join(home, config.LocalConfigDirName))
+	config.ResetPathsCache()
+	t.Cleanup(config.ResetPathsCache)
+
+	if err := os.MkdirAll(filepath.Join(home, config.LocalConfigDirName), 0755); err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(home, "scratch")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := ProjectRoot(dir); got != dir {
+		t.Errorf("ProjectRoot() = %q, want %q; dtvem's root is not a project", got, dir)
+	}
+}
+
+func TestDeclare_ProjectDirectory(t *testing.T) {
+	if _, err := Declare(t.TempDir(), Config{Path: "."}); err == nil {
+		t.Error("Declare() with the project directory as venv path succeeded, want error")
+	}
+}
+
+func TestBuiltVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		pyvenv  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "venv module",
+			pyvenv: "home = /opt/python/bin\ninclude-system-site-packages = false\nversion = 3.12.1\n",
+			want:   "3.12.1",
+		},
+		{
+			name:   "virtualenv",
+			pyvenv: "home = /opt/python/bin\nimplementation = CPython\nversion_info = 3.11.7.final.0\n",
+			want:   "3.11.7",
+		},
+		{
+			name:    "no version",
+			pyvenv:  "home = /opt/python/bin\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Project{Root: t.TempDir()}
+			writeVenv(t, p, tt.pyvenv)
+
+			got, err := p.BuiltVersion()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuiltVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("BuiltVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	p := &Project{Root: t.TempDir()}
+
+	if got := p.Check("3.12.1"); got.State != Missing {
+		t.Errorf("Check() without a venv = %+v, want missing", got)
+	}
+
+	writeVenv(t, p, "version = 3.12.1\n")
+	if got := p.Check("3.12.1"); got.State != Ready || got.Version != "3.12.1" {
+		t.Errorf("Check() with the pinned version = %+v, want ready", got)
+	}
+
+	got := p.Check("3.13.0")
+	if got.State != Stale || got.Version != "3.12.1" || got.Reason == "" {
+		t.Errorf("Check() after a version change = %+v, want stale with a reason", got)
+	}
+
+	if err := os.Remove(p.Interpreter()); err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Check("3.12.1"); got.State != Stale {
+		t.Errorf("Check() without an interpreter = %+v, want stale", got)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	p := &Project{Root: t.TempDir()}
+
+	if err := p.Remove(); err != nil {
+		t.Errorf("Remove() without a venv error = %v", err)
+	}
+
+	// A directory that isn't a venv is left alone
+	if err := os.MkdirAll(p.Dir(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Remove(); err == nil {
+		t.Error("Remove() of a directory without pyvenv.cfg succeeded, want error")
+	}
+	if _, err := os.Stat(p.Dir()); err != nil {
+		t.Errorf("directory was removed: %v", err)
+	}
+
+	writeVenv(t, p, "version = 3.12.1\n")
+	if err := p.Remove(); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := os.Stat(p.Dir()); !os.IsNotExist(err) {
+		t.Errorf("venv still exists after Remove(): %v", err)
+	}
+}
+
+func TestInstallRequirements_MissingFile(t *testing.T) {
+	p := &Project{Root: t.TempDir(), Config: Config{Requirements: []string{"requirements.txt"}}}
+	if err := p.InstallRequirements(nil); err == nil {
+		t.Error("InstallRequirements() with a missing file succeeded, want error")
+	}
+}
+
+func TestShouldWarn(t *testing.T) {
+	t.Setenv("DTVEM_ROOT", t.TempDir())
+	config.ResetPathsCache()
+	t.Cleanup(config.ResetPathsCache)
+
+	p := &Project{Root: t.TempDir()}
+	stale := Status{State: Stale, Version: "3.11.9", Reason: "built with Python 3.11.9, the project uses 3.12.1"}
+
+	if !p.ShouldWarn(Status{State: Missing}) {
+		t.Error("ShouldWarn(missing) = false the first time, want true")
+	}
+	if p.ShouldWarn(Status{State: Missing}) {
+		t.Error("ShouldWarn(missing) = true again, want false")
+	}
+	if !p.ShouldWarn(stale) {
+		t.Error("ShouldWarn(stale) = false after the state changed, want true")
+	}
+	if p.ShouldWarn(stale) {
+		t.Error("ShouldWarn(stale) = true again, want false")
+	}
+
+	if p.ShouldWarn(Status{State: Ready, Version: "3.12.1"}) {
+		t.Error("ShouldWarn(ready) = true, want false")
+	}
+	if !p.ShouldWarn(stale) {
+		t.Error("ShouldWarn(stale) = false after the venv was ready, want true")
+	}
+}
@@ -0,0 +1,335 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AliasLatest is the alias that matches the newest stable version of any runtime.
+const AliasLatest = "latest"
+
+// Constraint is a parsed version specification that can be matched against
+// concrete version strings. Supported forms:
+//   - exact versions: "20.11.0"
+//   - partial versions: "20", "20.11", "20.x"
+//   - caret ranges: "^20.10" (>=20.10.0 <21.0.0)
+//   - tilde ranges: "~3.2" (>=3.2.0 <3.3.0)
+//   - comparisons: ">=3.11,<3.13" or ">=3.11 <3.13"
+//   - alternatives: "^18 || ^20"
+//   - wildcards: "latest", "*"
+//
+// Prerelease versions (e.g., "3.5.0-preview1") only match exact constraints.
+type Constraint struct {
+	raw          string
+	exact        string
+	alternatives [][]comparator
+}
+
+// comparator is a single "<op> <version>" condition.
+type comparator struct {
+	op    string
+	parts []int
+}
+
+// ParseConstraint parses a version specification into a Constraint.
+func ParseConstraint(spec string) (*Constraint, error) {
+	raw := strings.TrimSpace(spec)
+	c := &Constraint{raw: raw}
+
+	if IsExactVersion(raw) {
+		c.exact = strings.TrimPrefix(raw, "v")
+		return c, nil
+	}
+
+	for _, alt := range strings.Split(raw, "||") {
+		comparators, err := parseAlternative(alt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint %q: %w", spec, err)
+		}
+		c.alternatives = append(c.alternatives, comparators)
+	}
+
+	return c, nil
+}
+
+// String returns the original specification.
+func (c *Constraint) String() string {
+	return c.raw
+}
+
+// Matches reports whether a concrete version satisfies the constraint.
+func (c *Constraint) Matches(version string) bool {
+	version = strings.TrimPrefix(version, "v")
+	if c.exact != "" {
+		return version == c.exact
+	}
+
+	parts, ok := parseReleaseParts(version)
+	if !ok {
+		// Ranges never match prereleases
+		return false
+	}
+
+	for _, alt := range c.alternatives {
+		if matchesAll(alt, parts) {
+			return true
+		}
+	}
+	return false
+}
+
+// Best returns the highest version in available that satisfies the constraint.
+func (c *Constraint) Best(available []string) (string, bool) {
+	var matches []string
+	for _, v := range available {
+		if c.Matches(v) {
+			matches = append(matches, v)
+		}
+	}
+	if len(matches) == 0 {
+		return "", false
+	}
+	sortVersionsDesc(matches)
+	return matches[0], true
+}
+
+// IsExactVersion reports whether spec names a single concrete version
+// (three or more numeric components, no operators or wildcards) rather
+// than a range, partial version, or alias.
+func IsExactVersion(spec string) bool {
+	spec = strings.TrimPrefix(strings.TrimSpace(spec), "v")
+	if spec == "" || strings.ContainsAny(spec, "^~<>=*| ,/") {
+		return false
+	}
+	parts := strings.Split(spec, ".")
+	if len(parts) < 3 {
+		return false
+	}
+	for _, p := range parts[:2] {
+		if _, err := strconv.Atoi(p); err != nil {
+			return false
+		}
+	}
+	// The patch component may carry a prerelease suffix ("0-preview1")
+	return len(parts[2]) > 0 && parts[2][0] >= '0' && parts[2][0] <= '9'
+}
+
+// ResolveConstraint returns the highest version in available that satisfies spec.
+func ResolveConstraint(spec string, available []string) (string, error) {
+	c, err := ParseConstraint(spec)
+	if err != nil {
+		return "", err
+	}
+
+	best, ok := c.Best(available)
+	if !ok {
+		return "", fmt.Errorf("no version matching %q found", spec)
+	}
+	return best, nil
+}
+
+// parseAlternative parses one "||"-separated group of comparators.
+func parseAlternative(alt string) ([]comparator, error) {
+	tokens := tokenize(alt)
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty constraint")
+	}
+
+	var result []comparator
+	for _, tok := range tokens {
+		comparators, err := parseToken(tok)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, comparators...)
+	}
+	return result, nil
+}
+
+// tokenize splits a constraint group on commas and whitespace, re-attaching
+// operators that were separated from their version (">= 3.11").
+func tokenize(alt string) []string {
+	fields := strings.Fields(strings.ReplaceAll(alt, ",", " "))
+
+	var tokens []string
+	for i := 0; i < len(fields); i++ {
+		tok := fields[i]
+		if strings.Trim(tok, "<>=^~") == "" && i+1 < len(fields) {
+			tok += fields[i+1]
+			i++
+		}
+		tokens = append(tokens, tok)
+	}
+	return tokens
+}
+
+// parseToken expands a single token into one or more comparators.
+func parseToken(tok string) ([]comparator, error) {
+	if strings.EqualFold(tok, AliasLatest) || tok == "*" || tok == "x" || tok == "X" {
+		return nil, nil
+	}
+
+	op := ""
+	for _, candidate := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(tok, candidate) {
+			op = candidate
+			break
+		}
+	}
+	rest := strings.TrimPrefix(strings.TrimPrefix(tok, op), "v")
+
+	parts, wildcard, err := parseConstraintVersion(rest)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) == 0 {
+		// Bare wildcard such as "x" or ">=*"
+		return nil, nil
+	}
+
+	switch op {
+	case "^":
+		return caretRange(parts), nil
+	case "~":
+		return tildeRange(parts), nil
+	case "", "=":
+		if len(parts) < 3 || wildcard {
+			return prefixRange(parts), nil
+		}
+		return []comparator{{op: "=", parts: parts}}, nil
+	default:
+		return []comparator{{op: op, parts: padParts(parts)}}, nil
+	}
+}
+
+// parseConstraintVersion parses "20", "20.10", "20.10.1" or "20.x" into
+// numeric parts. wildcard is true when an "x" or "*" component was present.
+func parseConstraintVersion(s string) (parts []int, wildcard bool, err error) {
+	if s == "" {
+		return nil, false, fmt.Errorf("missing version")
+	}
+	for _, p := range strings.Split(s, ".") {
+		if p == "x" || p == "X" || p == "*" {
+			return parts, true, nil
+		}
+		n, convErr := strconv.Atoi(p)
+		if convErr != nil {
+			return nil, false, fmt.Errorf("invalid version component %q", p)
+		}
+		parts = append(parts, n)
+	}
+	if len(parts) > 3 {
+		return nil, false, fmt.Errorf("too many version components in %q", s)
+	}
+	return parts, false, nil
+}
+
+// caretRange allows changes that do not modify the left-most non-zero component.
+func caretRange(parts []int) []comparator {
+	lower := padParts(parts)
+	var upper []int
+	switch {
+	case lower[0] != 0 || len(parts) == 1:
+		upper = []int{lower[0] + 1, 0, 0}
+	case lower[1] != 0 || len(parts) == 2:
+		upper = []int{0, lower[1] + 1, 0}
+	default:
+		upper = []int{0, 0, lower[2] + 1}
+	}
+	return []comparator{{op: ">=", parts: lower}, {op: "<", parts: upper}}
+}
+
+// tildeRange allows patch-level changes when a minor version is specified,
+// and minor-level changes otherwise.
+func tildeRange(parts []int) []comparator {
+	lower := padParts(parts)
+	upper := []int{lower[0] + 1, 0, 0}
+	if len(parts) >= 2 {
+		upper = []int{lower[0], lower[1] + 1, 0}
+	}
+	return []comparator{{op: ">=", parts: lower}, {op: "<", parts: upper}}
+}
+
+// prefixRange matches every version that starts with the given components.
+func prefixRange(parts []int) []comparator {
+	lower := padParts(parts)
+	upper := padParts(parts)
+	upper[len(parts)-1]++
+	return []comparator{{op: ">=", parts: lower}, {op: "<", parts: upper}}
+}
+
+// padParts extends parts to three components with zeros.
+func padParts(parts []int) []int {
+	padded := make([]int, 3)
+	copy(padded, parts)
+	return padded
+}
+
+// matchesAll reports whether a version satisfies every comparator in a group.
+// A group with no comparators ("latest", "*") matches any stable release.
+func matchesAll(comparators []comparator, parts []int) bool {
+	for _, cmp := range comparators {
+		result := compareParts(parts, cmp.parts)
+		switch cmp.op {
+		case "=":
+			if result != 0 {
+				return false
+			}
+		case ">=":
+			if result < 0 {
+				return false
+			}
+		case ">":
+			if result <= 0 {
+				return false
+			}
+		case "<=":
+			if result > 0 {
+				return false
+			}
+		case "<":
+			if result >= 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// parseReleaseParts parses a stable release version ("1.2.3"). ok is false
+// for prereleases or anything with non-numeric components.
+func parseReleaseParts(version string) ([]int, bool) {
+	fields := strings.Split(version, ".")
+	parts := make([]int, 0, len(fields))
+	for _, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, false
+		}
+		parts = append(parts, n)
+	}
+	return parts, len(parts) > 0
+}
+
+// compareParts compares numeric version components, treating missing
+// components as zero.
+func compareParts(a, b []int) int {
+	maxLen := len(a)
+	if len(b) > maxLen {
+		maxLen = len(b)
+	}
+	for i := 0; i < maxLen; i++ {
+		var aVal, bVal int
+		if i < len(a) {
+			aVal = a[i]
+		}
+		if i < len(b) {
+			bVal = b[i]
+		}
+		if aVal != bVal {
+			return aVal - bVal
+		}
+	}
+	return 0
+}
@@ -0,0 +1,167 @@
+package version
+
+import (
+	"testing"
+)
+
+func TestIsExactVersion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"20.11.0", true},
+		{"v20.11.0", true},
+		{"3.5.0-preview1", true},
+		{"20", false},
+		{"20.11", false},
+		{"20.x", false},
+		{"^20.10", false},
+		{"~3.2", false},
+		{">=3.11,<3.13", false},
+		{"lts/iron", false},
+		{"latest", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := IsExactVersion(tt.input); got != tt.expected {
+				t.Errorf("IsExactVersion(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConstraint_Matches(t *testing.T) {
+	tests := []struct {
+		spec     string
+		version  string
+		expected bool
+	}{
+		// Caret
+		{"^20.10", "20.10.0", true},
+		{"^20.10", "20.18.1", true},
+		{"^20.10", "20.9.0", false},
+		{"^20.10", "21.0.0", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+
+		// Tilde
+		{"~3.2", "3.2.0", true},
+		{"~3.2", "3.2.7", true},
+		{"~3.2", "3.3.0", false},
+		{"~3.2.2", "3.2.1", false},
+		{"~3", "3.9.0", true},
+
+		// Comparisons
+		{">=3.11,<3.13", "3.11.0", true},
+		{">=3.11,<3.13", "3.12.8", true},
+		{">=3.11,<3.13", "3.13.0", false},
+		{">=3.11,<3.13", "3.10.14", false},
+		{">= 3.11 < 3.13", "3.12.0", true},
+		{">3.11.0", "3.11.0", false},
+		{"<=3.11.2", "3.11.2", true},
+
+		// Partial and wildcard
+		{"20", "20.18.1", true},
+		{"20", "21.0.0", false},
+		{"20.x", "20.1.0", true},
+		{"3.12", "3.12.4", true},
+		{"3.12", "3.13.0", false},
+
+		// Alternatives
+		{"^18 || ^20", "18.20.0", true},
+		{"^18 || ^20", "20.1.0", true},
+		{"^18 || ^20", "19.0.0", false},
+
+		// Latest
+		{"latest", "22.1.0", true},
+		{"*", "1.0.0", true},
+
+		// Prereleases only match exactly
+		{"latest", "3.5.0-preview1", false},
+		{"^3.4", "3.5.0-preview1", false},
+		{"3.5.0-preview1", "3.5.0-preview1", true},
+
+		// Exact
+		{"20.11.0", "20.11.0", true},
+		{"20.11.0", "v20.11.0", true},
+		{"20.11.0", "20.11.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec+"/"+tt.version, func(t *testing.T) {
+			c, err := ParseConstraint(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseConstraint(%q) error: %v", tt.spec, err)
+			}
+			if got := c.Matches(tt.version); got != tt.expected {
+				t.Errorf("ParseConstraint(%q).Matches(%q) = %v, want %v", tt.spec, tt.version, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseConstraint_Invalid(t *testing.T) {
+	invalid := []string{
+		"lts/iron",
+		"^abc",
+		">=",
+		"^1.2.3.4",
+		"",
+	}
+
+	for _, spec := range invalid {
+		t.Run(spec, func(t *testing.T) {
+			if _, err := ParseConstraint(spec); err == nil {
+				t.Errorf("ParseConstraint(%q) expected error", spec)
+			}
+		})
+	}
+}
+
+func TestResolveConstraint(t *testing.T) {
+	available := []string{
+		"3.10.14",
+		"3.11.0",
+		"3.11.9",
+		"3.12.1",
+		"3.12.8",
+		"3.13.0",
+		"3.14.0-preview1",
+	}
+
+	tests := []struct {
+		spec     string
+		expected string
+		wantErr  bool
+	}{
+		{">=3.11,<3.13", "3.12.8", false},
+		{"~3.11", "3.11.9", false},
+		{"^3.10", "3.13.0", false},
+		{"latest", "3.13.0", false},
+		{"3.12", "3.12.8", false},
+		{"3.12.1", "3.12.1", false},
+		{"3.14.0-preview1", "3.14.0-preview1", false},
+		{">=4", "", true},
+		{"3.12.2", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ResolveConstraint(tt.spec, available)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ResolveConstraint(%q) = %q, expected error", tt.spec, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveConstraint(%q) error: %v", tt.spec, err)
+			}
+			if got != tt.expected {
+				t.Errorf("ResolveConstraint(%q) = %q, want %q", tt.spec, got, tt.expected)
+			}
+		})
+	}
+}
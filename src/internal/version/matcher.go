@@ -94,6 +94,12 @@ func sortVersionsDesc(versions []string) {
 	})
 }
 
+// Compare compares two version strings semantically.
+// Returns >0 if a > b, <0 if a < b, 0 if equal.
+func Compare(a, b string) int {
+	return compareVersionStrings(a, b)
+}
+
 // compareVersionStrings compares two version strings semantically.
 // Returns >0 if a > b, <0 if a < b, 0 if equal.
 func compareVersionStrings(a, b string) int {
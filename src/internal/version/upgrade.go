@@ -0,0 +1,43 @@
+package version
+
+import "fmt"
+
+// UpgradeLevel limits how far an upgrade may move a version
+type UpgradeLevel string
+
+const (
+	// UpgradePatch stays within the same major.minor release line
+	UpgradePatch UpgradeLevel = "patch"
+	// UpgradeMinor stays within the same major version
+	UpgradeMinor UpgradeLevel = "minor"
+	// UpgradeMajor allows any newer version
+	UpgradeMajor UpgradeLevel = "major"
+)
+
+// Upgrade returns the newest version in available that is newer than current
+// and within level. Candidates are matched like ResolvePartialVersion: the
+// same "major.minor" prefix for patch upgrades, the same major for minor
+// upgrades. Prereleases are never chosen. Returns false if current is already
+// the newest version within level.
+func Upgrade(current string, level UpgradeLevel, available []string) (string, bool) {
+	parts := parseVersionParts(current)
+	if len(parts) < 2 {
+		return "", false
+	}
+
+	var spec string
+	switch level {
+	case UpgradePatch:
+		spec = fmt.Sprintf("%d.%d", parts[0], parts[1])
+	case UpgradeMinor:
+		spec = fmt.Sprintf("%d", parts[0])
+	default:
+		spec = AliasLatest
+	}
+
+	best, err := ResolveConstraint(spec, available)
+	if err != nil || compareVersionStrings(best, current) <= 0 {
+		return "", false
+	}
+	return best, true
+}
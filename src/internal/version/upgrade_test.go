@@ -0,0 +1,32 @@
+package version
+
+import "testing"
+
+func TestUpgrade(t *testing.T) {
+	available := []string{
+		"18.19.0", "18.20.4", "20.10.0", "20.11.1", "20.18.0", "22.0.0-rc.1", "21.7.3",
+	}
+
+	tests := []struct {
+		current  string
+		level    UpgradeLevel
+		expected string
+		ok       bool
+	}{
+		{"20.10.0", UpgradePatch, "20.10.0", false},
+		{"20.11.0", UpgradePatch, "20.11.1", true},
+		{"20.10.0", UpgradeMinor, "20.18.0", true},
+		{"18.19.0", UpgradeMinor, "18.20.4", true},
+		{"18.19.0", UpgradeMajor, "21.7.3", true},
+		{"21.7.3", UpgradeMajor, "", false},
+		{"v20.11.0", UpgradePatch, "20.11.1", true},
+		{"16.0.0", UpgradeMinor, "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Upgrade(tt.current, tt.level, available)
+		if ok != tt.ok || (ok && got != tt.expected) {
+			t.Errorf("Upgrade(%q, %s) = %q, %v; want %q, %v", tt.current, tt.level, got, ok, tt.expected, tt.ok)
+		}
+	}
+}
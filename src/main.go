@@ -9,12 +9,20 @@ import (
 	_ "github.com/CodingWithCalvin/dtvem.cli/src/runtimes/ruby"
 
 	// Import migration providers to register them
+	// Multi-runtime migration providers
+	_ "github.com/CodingWithCalvin/dtvem.cli/src/migrations/asdf"
+	_ "github.com/CodingWithCalvin/dtvem.cli/src/migrations/mise"
+
 	// Node.js migration providers
 	_ "github.com/CodingWithCalvin/dtvem.cli/src/migrations/node/fnm"
+	_ "github.com/CodingWithCalvin/dtvem.cli/src/migrations/node/n"
+	_ "github.com/CodingWithCalvin/dtvem.cli/src/migrations/node/nodenv"
 	_ "github.com/CodingWithCalvin/dtvem.cli/src/migrations/node/nvm"
 	_ "github.com/CodingWithCalvin/dtvem.cli/src/migrations/node/system"
+	_ "github.com/CodingWithCalvin/dtvem.cli/src/migrations/node/volta"
 
 	// Python migration providers
+	_ "github.com/CodingWithCalvin/dtvem.cli/src/migrations/python/conda"
 	_ "github.com/CodingWithCalvin/dtvem.cli/src/migrations/python/pyenv"
 	_ "github.com/CodingWithCalvin/dtvem.cli/src/migrations/python/system"
 
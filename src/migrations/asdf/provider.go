@@ -0,0 +1,143 @@
+// Package asdf provides migration providers for asdf, which manages every
+// runtime through plugins. One provider is registered per runtime.
+package asdf
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/migration"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/path"
+)
+
+// plugin describes how asdf installs a runtime
+type plugin struct {
+	name        string   // asdf plugin name
+	displayName string   // runtime display name
+	executables []string // executable paths relative to a version directory
+}
+
+// plugins maps dtvem runtime names to asdf plugins
+var plugins = map[string]plugin{
+	"node":   {name: "nodejs", displayName: "Node.js", executables: []string{filepath.Join("bin", "node")}},
+	"python": {name: "python", displayName: "Python", executables: []string{filepath.Join("bin", "python"), filepath.Join("bin", "python3")}},
+	"ruby":   {name: "ruby", displayName: "Ruby", executables: []string{filepath.Join("bin", "ruby")}},
+}
+
+// shellInit matches asdf.sh (or asdf.fish), its completions and, since
+// asdf 0.16, its shims directory on PATH
+var shellInit = path.ShellInit{
+	Manager: "asdf",
+	Lines: []*regexp.Regexp{
+		regexp.MustCompile(`asdf\.(sh|fish)`),
+		regexp.MustCompile(`\.asdf/completions`),
+		regexp.MustCompile(`(ASDF_DATA_DIR|\.asdf)[^ ]*/shims`),
+	},
+}
+
+// Provider implements the migration.Provider interface for one runtime
+// installed by asdf.
+type Provider struct {
+	runtime string
+	plugin  plugin
+}
+
+// NewProvider creates an asdf migration provider for a runtime
+// ("node", "python" or "ruby").
+func NewProvider(runtimeName string) *Provider {
+	return &Provider{runtime: runtimeName, plugin: plugins[runtimeName]}
+}
+
+// Name returns the identifier for this version manager and runtime.
+func (p *Provider) Name() string {
+	return "asdf-" + p.runtime
+}
+
+// DisplayName returns the human-readable name.
+func (p *Provider) DisplayName() string {
+	return fmt.Sprintf("asdf (%s)", p.plugin.displayName)
+}
+
+// Runtime returns the runtime this provider manages.
+func (p *Provider) Runtime() string {
+	return p.runtime
+}
+
+// installsDir returns the directory asdf installs the plugin's versions in,
+// honoring ASDF_DATA_DIR
+func (p *Provider) installsDir() string {
+	dataDir := migration.DirFromEnv("ASDF_DATA_DIR", ".asdf")
+	if dataDir == "" {
+		return ""
+	}
+	return filepath.Join(dataDir, "installs", p.plugin.name)
+}
+
+// IsPresent checks if asdf has installed any version of the runtime.
+func (p *Provider) IsPresent() bool {
+	dir := p.installsDir()
+	if dir == "" {
+		return false
+	}
+	_, err := os.Stat(dir)
+	return err == nil
+}
+
+// DetectVersions finds all versions of the runtime installed by asdf.
+func (p *Provider) DetectVersions() ([]migration.DetectedVersion, error) {
+	dir := p.installsDir()
+	if dir == "" {
+		return make([]migration.DetectedVersion, 0), nil
+	}
+	return migration.DetectVersionDirs(dir, "asdf", p.plugin.executables...), nil
+}
+
+// VersionFiles returns the project files asdf reads versions from.
+func (p *Provider) VersionFiles() []config.VersionFile {
+	return []config.VersionFile{config.ToolVersionsFile()}
+}
+
+// CanAutoUninstall returns true because asdf supports automatic uninstall.
+func (p *Provider) CanAutoUninstall() bool {
+	return true
+}
+
+// UninstallCommand returns the command to uninstall a specific version.
+func (p *Provider) UninstallCommand(version string) string {
+	return fmt.Sprintf("asdf uninstall %s %s", p.plugin.name, version)
+}
+
+// InstalledTools returns every asdf plugin with an installed version,
+// including plugins for runtimes dtvem doesn't manage.
+func (p *Provider) InstalledTools() ([]string, error) {
+	dataDir := migration.DirFromEnv("ASDF_DATA_DIR", ".asdf")
+	if dataDir == "" {
+		return nil, nil
+	}
+	return migration.InstalledToolDirs(filepath.Join(dataDir, "installs"))
+}
+
+// ShellInit returns the lines asdf adds to shell startup files.
+func (p *Provider) ShellInit() path.ShellInit {
+	return shellInit
+}
+
+// ManualInstructions returns instructions for manual removal.
+func (p *Provider) ManualInstructions() string {
+	return fmt.Sprintf("To manually remove an asdf-installed %s version:\n"+
+		"  1. Run: asdf uninstall %s <version>\n"+
+		"  2. Or manually delete the version directory from ~/.asdf/installs/%s/",
+		p.plugin.displayName, p.plugin.name, p.plugin.name)
+}
+
+// init registers an asdf provider for each runtime on package load.
+func init() {
+	for _, runtimeName := range []string{"node", "python", "ruby"} {
+		if err := migration.Register(NewProvider(runtimeName)); err != nil {
+			panic(fmt.Sprintf("failed to register asdf migration provider: %v", err))
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package asdf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/migration"
+)
+
+func TestProvider(t *testing.T) {
+	for _, runtimeName := range []string{"node", "python", "ruby"} {
+		t.Run(runtimeName, func(t *testing.T) {
+			harness := &migration.ProviderTestHarness{
+				Provider:     NewProvider(runtimeName),
+				ExpectedName: "asdf-" + runtimeName,
+				Runtime:      runtimeName,
+			}
+			harness.RunAll(t)
+		})
+	}
+}
+
+func TestProvider_UninstallCommand(t *testing.T) {
+	tests := []struct {
+		runtime  string
+		version  string
+		expected string
+	}{
+		{runtime: "node", version: "22.0.0", expected: "asdf uninstall nodejs 22.0.0"},
+		{runtime: "python", version: "3.12.1", expected: "asdf uninstall python 3.12.1"},
+		{runtime: "ruby", version: "3.3.0", expected: "asdf uninstall ruby 3.3.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.runtime, func(t *testing.T) {
+			result := NewProvider(tt.runtime).UninstallCommand(tt.version)
+			if result != tt.expected {
+				t.Errorf("UninstallCommand(%q) = %q, want %q", tt.version, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProvider_DetectVersions(t *testing.T) {
+	dataDir := t.TempDir()
+	t.Setenv("ASDF_DATA_DIR", dataDir)
+
+	for _, rel := range []string{
+		"installs/nodejs/20.11.0/bin/node",
+		"installs/python/3.12.1/bin/python3",
+		"installs/python/3.11.7/lib/.keep", // no interpreter
+	} {
+		path := filepath.Join(dataDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	node, _ := NewProvider("node").DetectVersions()
+	if len(node) != 1 || node[0].Version != "20.11.0" || node[0].Source != "asdf" {
+		t.Errorf("node DetectVersions() = %+v, want 20.11.0 from asdf", node)
+	}
+
+	python, _ := NewProvider("python").DetectVersions()
+	if len(python) != 1 || python[0].Version != "3.12.1" {
+		t.Errorf("python DetectVersions() = %+v, want only 3.12.1", python)
+	}
+
+	if !NewProvider("node").IsPresent() || NewProvider("ruby").IsPresent() {
+		t.Error("IsPresent() should be true for node and false for ruby")
+	}
+}
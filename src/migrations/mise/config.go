@@ -0,0 +1,147 @@
+package mise
+
+import (
+	"bufio"
+	"bytes"
+	"path/filepath"
+	"strings"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+)
+
+// configFileNames are the project config files mise reads, in its order of
+// precedence within a directory
+var configFileNames = []string{
+	"mise.local.toml",
+	"mise.toml",
+	".mise.toml",
+	filepath.Join(".config", "mise.toml"),
+	filepath.Join(".config", "mise", "config.toml"),
+	filepath.Join("mise", "config.toml"),
+	filepath.Join(".mise", "config.toml"),
+}
+
+// toolNames maps mise tool names to dtvem runtime names
+var toolNames = map[string]string{
+	"node":   "node",
+	"nodejs": "node",
+	"python": "python",
+	"ruby":   "ruby",
+}
+
+// configFile reads the [tools] table of a mise config file:
+//
+//	[tools]
+//	node = "20.11.0"
+//	python = ["3.12", "3.11"]
+//	ruby = { version = "3.3" }
+//
+// It implements config.VersionFile. Only the TOML that mise configs use for
+// tools is understood; multi-line values are skipped.
+type configFile struct {
+	name string
+}
+
+func (f configFile) Name() string {
+	return f.name
+}
+
+func (f configFile) Supports(runtimeName string) bool {
+	for _, name := range toolNames {
+		if name == runtimeName {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse returns the first version listed for the runtime's tool
+func (f configFile) Parse(data []byte, runtimeName string) (string, error) {
+	table := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := stripComment(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			table = strings.TrimSpace(strings.Trim(line, "[]"))
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = unquote(strings.TrimSpace(key))
+		switch {
+		case table == "tools":
+		case table == "" && strings.HasPrefix(key, "tools."):
+			key = unquote(strings.TrimPrefix(key, "tools."))
+		default:
+			continue
+		}
+
+		// Backends such as "core:node" name the same tool
+		if _, name, found := strings.Cut(key, ":"); found {
+			key = name
+		}
+		if toolNames[key] != runtimeName {
+			continue
+		}
+
+		if version := toolVersion(strings.TrimSpace(value)); version != "" {
+			return config.NormalizeFileVersion(runtimeName, version), nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+// toolVersion extracts the version from a tool's value: a string, the first
+// element of an array, or the version key of an inline table
+func toolVersion(value string) string {
+	switch {
+	case strings.HasPrefix(value, "["):
+		first, _, _ := strings.Cut(strings.TrimPrefix(value, "["), ",")
+		first = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(first), "]"))
+		return toolVersion(first)
+	case strings.HasPrefix(value, "{"):
+		inner := strings.TrimSuffix(strings.TrimPrefix(value, "{"), "}")
+		for _, pair := range strings.Split(inner, ",") {
+			k, v, ok := strings.Cut(pair, "=")
+			if ok && unquote(strings.TrimSpace(k)) == "version" {
+				return unquote(strings.TrimSpace(v))
+			}
+		}
+		return ""
+	default:
+		return unquote(value)
+	}
+}
+
+// unquote removes TOML basic or literal string quotes
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// stripComment removes a # comment that is not inside a string, and
+// surrounding whitespace
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return strings.TrimSpace(line[:i])
+		}
+	}
+	return strings.TrimSpace(line)
+}
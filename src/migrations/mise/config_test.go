@@ -0,0 +1,73 @@
+package mise
+
+import "testing"
+
+func TestConfigFile_Parse(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		runtime  string
+		expected string
+	}{
+		{
+			name:     "string",
+			data:     "[tools]\nnode = \"20.11.0\"\npython = '3.12'\n",
+			runtime:  "node",
+			expected: "20.11.0",
+		},
+		{
+			name:     "literal string",
+			data:     "[tools]\nnode = \"20.11.0\"\npython = '3.12'\n",
+			runtime:  "python",
+			expected: "3.12",
+		},
+		{
+			name:     "array takes the first version",
+			data:     "[tools]\npython = [\"3.12.1\", \"3.11.7\"]\n",
+			runtime:  "python",
+			expected: "3.12.1",
+		},
+		{
+			name:     "inline table",
+			data:     "[tools]\nruby = { version = \"3.3.0\", postinstall = \"gem install bundler\" }\n",
+			runtime:  "ruby",
+			expected: "3.3.0",
+		},
+		{
+			name:     "backend prefix and v prefix",
+			data:     "[tools]\n\"core:node\" = \"v22.1.0\" # LTS\n",
+			runtime:  "node",
+			expected: "22.1.0",
+		},
+		{
+			name:     "dotted key at the top level",
+			data:     "tools.ruby = \"ruby-3.2.2\"\n",
+			runtime:  "ruby",
+			expected: "3.2.2",
+		},
+		{
+			name:     "other tables are ignored",
+			data:     "[env]\nnode = \"18.0.0\"\n\n[tools]\nnodejs = \"20\"\n",
+			runtime:  "node",
+			expected: "20",
+		},
+		{
+			name:     "runtime not pinned",
+			data:     "[tools]\nnode = \"20\"\n",
+			runtime:  "python",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := configFile{name: "mise.toml"}.Parse([]byte(tt.data), tt.runtime)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Parse(%s) = %q, want %q", tt.runtime, got, tt.expected)
+			}
+		})
+	}
+}
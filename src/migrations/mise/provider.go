@@ -0,0 +1,166 @@
+// Package mise provides migration providers for mise (formerly rtx), which
+// manages every runtime. One provider is registered per runtime.
+package mise
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	goruntime "runtime"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/migration"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/path"
+)
+
+// tool describes how mise installs a runtime
+type tool struct {
+	name        string   // mise tool name
+	displayName string   // runtime display name
+	executables []string // executable paths relative to a version directory
+}
+
+// tools maps dtvem runtime names to mise tools
+var tools = map[string]tool{
+	"node": {name: "node", displayName: "Node.js", executables: []string{
+		filepath.Join("bin", "node"), "node.exe",
+	}},
+	"python": {name: "python", displayName: "Python", executables: []string{
+		filepath.Join("bin", "python"), filepath.Join("bin", "python3"), "python.exe",
+	}},
+	"ruby": {name: "ruby", displayName: "Ruby", executables: []string{
+		filepath.Join("bin", "ruby"), filepath.Join("bin", "ruby.exe"),
+	}},
+}
+
+// shellInit matches mise activate (or rtx activate, from before the rename)
+var shellInit = path.ShellInit{
+	Manager: "mise",
+	Lines: []*regexp.Regexp{
+		regexp.MustCompile(`\b(mise|rtx) activate\b`),
+	},
+}
+
+// Provider implements the migration.Provider interface for one runtime
+// installed by mise.
+type Provider struct {
+	runtime string
+	tool    tool
+}
+
+// NewProvider creates a mise migration provider for a runtime
+// ("node", "python" or "ruby").
+func NewProvider(runtimeName string) *Provider {
+	return &Provider{runtime: runtimeName, tool: tools[runtimeName]}
+}
+
+// Name returns the identifier for this version manager and runtime.
+func (p *Provider) Name() string {
+	return "mise-" + p.runtime
+}
+
+// DisplayName returns the human-readable name.
+func (p *Provider) DisplayName() string {
+	return fmt.Sprintf("mise (%s)", p.tool.displayName)
+}
+
+// Runtime returns the runtime this provider manages.
+func (p *Provider) Runtime() string {
+	return p.runtime
+}
+
+// installsDir returns the directory mise installs the tool's versions in.
+// mise honors MISE_DATA_DIR, then XDG_DATA_HOME, and defaults to
+// ~/.local/share/mise (%LOCALAPPDATA%\mise on Windows).
+func (p *Provider) installsDir() string {
+	dataDir := os.Getenv("MISE_DATA_DIR")
+	if dataDir == "" {
+		switch {
+		case goruntime.GOOS == "windows" && os.Getenv("LOCALAPPDATA") != "":
+			dataDir = filepath.Join(os.Getenv("LOCALAPPDATA"), "mise")
+		case os.Getenv("XDG_DATA_HOME") != "":
+			dataDir = filepath.Join(os.Getenv("XDG_DATA_HOME"), "mise")
+		default:
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return ""
+			}
+			dataDir = filepath.Join(home, ".local", "share", "mise")
+		}
+	}
+	return filepath.Join(dataDir, "installs", p.tool.name)
+}
+
+// IsPresent checks if mise has installed any version of the runtime.
+func (p *Provider) IsPresent() bool {
+	dir := p.installsDir()
+	if dir == "" {
+		return false
+	}
+	_, err := os.Stat(dir)
+	return err == nil
+}
+
+// DetectVersions finds all versions of the runtime installed by mise. The
+// "20" and "latest" style aliases mise creates are symbolic links and are
+// skipped.
+func (p *Provider) DetectVersions() ([]migration.DetectedVersion, error) {
+	dir := p.installsDir()
+	if dir == "" {
+		return make([]migration.DetectedVersion, 0), nil
+	}
+	return migration.DetectVersionDirs(dir, "mise", p.tool.executables...), nil
+}
+
+// VersionFiles returns the project files mise reads versions from, in its
+// order of precedence. mise also reads asdf's .tool-versions.
+func (p *Provider) VersionFiles() []config.VersionFile {
+	files := make([]config.VersionFile, 0, len(configFileNames)+1)
+	for _, name := range configFileNames {
+		files = append(files, configFile{name: name})
+	}
+	return append(files, config.ToolVersionsFile())
+}
+
+// CanAutoUninstall returns true because mise supports automatic uninstall.
+func (p *Provider) CanAutoUninstall() bool {
+	return true
+}
+
+// UninstallCommand returns the command to uninstall a specific version.
+func (p *Provider) UninstallCommand(version string) string {
+	return fmt.Sprintf("mise uninstall %s@%s", p.tool.name, version)
+}
+
+// InstalledTools returns every mise tool with an installed version,
+// including tools for runtimes dtvem doesn't manage.
+func (p *Provider) InstalledTools() ([]string, error) {
+	dir := p.installsDir()
+	if dir == "" {
+		return nil, nil
+	}
+	return migration.InstalledToolDirs(filepath.Dir(dir))
+}
+
+// ShellInit returns the lines mise adds to shell startup files.
+func (p *Provider) ShellInit() path.ShellInit {
+	return shellInit
+}
+
+// ManualInstructions returns instructions for manual removal.
+func (p *Provider) ManualInstructions() string {
+	return fmt.Sprintf("To manually remove a mise-installed %s version:\n"+
+		"  1. Run: mise uninstall %s@<version>\n"+
+		"  2. Or manually delete the version directory from ~/.local/share/mise/installs/%s/",
+		p.tool.displayName, p.tool.name, p.tool.name)
+}
+
+// init registers a mise provider for each runtime on package load.
+func init() {
+	for _, runtimeName := range []string{"node", "python", "ruby"} {
+		if err := migration.Register(NewProvider(runtimeName)); err != nil {
+			panic(fmt.Sprintf("failed to register mise migration provider: %v", err))
+		}
+	}
+}
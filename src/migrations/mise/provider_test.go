@@ -0,0 +1,70 @@
+package mise
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/migration"
+)
+
+func TestProvider(t *testing.T) {
+	for _, runtimeName := range []string{"node", "python", "ruby"} {
+		t.Run(runtimeName, func(t *testing.T) {
+			harness := &migration.ProviderTestHarness{
+				Provider:     NewProvider(runtimeName),
+				ExpectedName: "mise-" + runtimeName,
+				Runtime:      runtimeName,
+			}
+			harness.RunAll(t)
+		})
+	}
+}
+
+func TestProvider_UninstallCommand(t *testing.T) {
+	tests := []struct {
+		runtime  string
+		version  string
+		expected string
+	}{
+		{runtime: "node", version: "22.0.0", expected: "mise uninstall node@22.0.0"},
+		{runtime: "python", version: "3.12.1", expected: "mise uninstall python@3.12.1"},
+		{runtime: "ruby", version: "3.3.0", expected: "mise uninstall ruby@3.3.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.runtime, func(t *testing.T) {
+			result := NewProvider(tt.runtime).UninstallCommand(tt.version)
+			if result != tt.expected {
+				t.Errorf("UninstallCommand(%q) = %q, want %q", tt.version, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProvider_DetectVersions(t *testing.T) {
+	dataDir := t.TempDir()
+	t.Setenv("MISE_DATA_DIR", dataDir)
+
+	nodeDir := filepath.Join(dataDir, "installs", "node")
+	nodePath := filepath.Join(nodeDir, "20.11.0", "bin", "node")
+	if err := os.MkdirAll(filepath.Dir(nodePath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(nodePath, nil, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// mise links partial versions to the newest match; they are not
+	// separate installs
+	if err := os.Symlink(filepath.Join(nodeDir, "20.11.0"), filepath.Join(nodeDir, "20")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	detected, err := NewProvider("node").DetectVersions()
+	if err != nil {
+		t.Fatalf("DetectVersions() error = %v", err)
+	}
+	if len(detected) != 1 || detected[0].Version != "20.11.0" || detected[0].Path != nodePath {
+		t.Errorf("DetectVersions() = %+v, want only 20.11.0", detected)
+	}
+}
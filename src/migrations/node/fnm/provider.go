@@ -5,11 +5,23 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/migration"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/path"
 )
 
+// shellInit matches the fnm env line, and the FNM_PATH setup of fnm's install script
+var shellInit = path.ShellInit{
+	Manager: "fnm",
+	Lines: []*regexp.Regexp{
+		regexp.MustCompile(`\bfnm env\b`),
+		regexp.MustCompile(`^FNM_PATH=`),
+		regexp.MustCompile(`^# fnm$`),
+	},
+}
+
 // Provider implements the migration.Provider interface for fnm.
 type Provider struct{}
 
@@ -117,6 +129,11 @@ func (p *Provider) UninstallCommand(version string) string {
 	return fmt.Sprintf("fnm uninstall %s", version)
 }
 
+// ShellInit returns the lines fnm adds to shell startup files.
+func (p *Provider) ShellInit() path.ShellInit {
+	return shellInit
+}
+
 // ManualInstructions returns instructions for manual removal.
 func (p *Provider) ManualInstructions() string {
 	return "To manually remove an fnm-installed Node.js version:\n" +
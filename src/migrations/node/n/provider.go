@@ -0,0 +1,98 @@
+// Package n provides a migration provider for n, the Node.js version manager.
+package n
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/migration"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/path"
+)
+
+// defaultPrefix is where n caches versions when N_PREFIX is not set
+const defaultPrefix = "/usr/local"
+
+// shellInit matches n-install's N_PREFIX line
+var shellInit = path.ShellInit{
+	Manager: "n",
+	Lines: []*regexp.Regexp{
+		regexp.MustCompile(`\bN_PREFIX\b`),
+	},
+}
+
+// Provider implements the migration.Provider interface for n.
+type Provider struct{}
+
+// NewProvider creates a new n migration provider.
+func NewProvider() *Provider {
+	return &Provider{}
+}
+
+// Name returns the identifier for this version manager.
+func (p *Provider) Name() string {
+	return "n"
+}
+
+// DisplayName returns the human-readable name.
+func (p *Provider) DisplayName() string {
+	return "n"
+}
+
+// Runtime returns the runtime this provider manages.
+func (p *Provider) Runtime() string {
+	return "node"
+}
+
+// versionsDir returns the directory n caches Node.js versions in, honoring
+// N_PREFIX. The active version is copied into the prefix itself; the cache
+// holds every version n has downloaded.
+func (p *Provider) versionsDir() string {
+	prefix := os.Getenv("N_PREFIX")
+	if prefix == "" {
+		prefix = defaultPrefix
+	}
+	return filepath.Join(prefix, "n", "versions", "node")
+}
+
+// IsPresent checks if n has cached any Node.js version.
+func (p *Provider) IsPresent() bool {
+	_, err := os.Stat(p.versionsDir())
+	return err == nil
+}
+
+// DetectVersions finds all versions cached by n.
+func (p *Provider) DetectVersions() ([]migration.DetectedVersion, error) {
+	return migration.DetectVersionDirs(p.versionsDir(), "n", filepath.Join("bin", "node")), nil
+}
+
+// CanAutoUninstall returns true because n supports automatic uninstall.
+func (p *Provider) CanAutoUninstall() bool {
+	return true
+}
+
+// UninstallCommand returns the command to uninstall a specific version.
+func (p *Provider) UninstallCommand(version string) string {
+	return fmt.Sprintf("n rm %s", version)
+}
+
+// ShellInit returns the lines n-install adds to shell startup files.
+func (p *Provider) ShellInit() path.ShellInit {
+	return shellInit
+}
+
+// ManualInstructions returns instructions for manual removal.
+func (p *Provider) ManualInstructions() string {
+	return "To manually remove an n-installed Node.js version:\n" +
+		"  1. Run: n rm <version>\n" +
+		"  2. Or manually delete the version directory from $N_PREFIX/n/versions/node/ (default /usr/local)\n" +
+		"  3. The active version is also copied into $N_PREFIX/bin; remove it with: n uninstall"
+}
+
+// init registers the n provider on package load.
+func init() {
+	if err := migration.Register(NewProvider()); err != nil {
+		panic(fmt.Sprintf("failed to register n migration provider: %v", err))
+	}
+}
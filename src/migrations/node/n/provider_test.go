@@ -0,0 +1,60 @@
+package n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/migration"
+)
+
+func TestProvider(t *testing.T) {
+	harness := &migration.ProviderTestHarness{
+		Provider:     NewProvider(),
+		ExpectedName: "n",
+		Runtime:      "node",
+	}
+	harness.RunAll(t)
+}
+
+func TestProvider_UninstallCommand(t *testing.T) {
+	p := NewProvider()
+
+	tests := []struct {
+		version  string
+		expected string
+	}{
+		{version: "22.0.0", expected: "n rm 22.0.0"},
+		{version: "18.16.0", expected: "n rm 18.16.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			result := p.UninstallCommand(tt.version)
+			if result != tt.expected {
+				t.Errorf("UninstallCommand(%q) = %q, want %q", tt.version, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProvider_DetectVersions(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("N_PREFIX", root)
+
+	nodePath := filepath.Join(root, "n", "versions", "node", "18.16.0", "bin", "node")
+	if err := os.MkdirAll(filepath.Dir(nodePath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(nodePath, nil, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	detected, err := NewProvider().DetectVersions()
+	if err != nil {
+		t.Fatalf("DetectVersions() error = %v", err)
+	}
+	if len(detected) != 1 || detected[0].Version != "18.16.0" || detected[0].Source != "n" {
+		t.Errorf("DetectVersions() = %+v, want 18.16.0 from n", detected)
+	}
+}
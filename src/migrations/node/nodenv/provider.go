@@ -0,0 +1,101 @@
+// Package nodenv provides a migration provider for nodenv.
+package nodenv
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/migration"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/path"
+)
+
+// shellInit matches nodenv init and nodenv's bin directory on PATH
+var shellInit = path.ShellInit{
+	Manager: "nodenv",
+	Lines: []*regexp.Regexp{
+		regexp.MustCompile(`\bnodenv init\b`),
+		regexp.MustCompile(`\.nodenv/bin`),
+	},
+}
+
+// Provider implements the migration.Provider interface for nodenv.
+type Provider struct{}
+
+// NewProvider creates a new nodenv migration provider.
+func NewProvider() *Provider {
+	return &Provider{}
+}
+
+// Name returns the identifier for this version manager.
+func (p *Provider) Name() string {
+	return "nodenv"
+}
+
+// DisplayName returns the human-readable name.
+func (p *Provider) DisplayName() string {
+	return "nodenv"
+}
+
+// Runtime returns the runtime this provider manages.
+func (p *Provider) Runtime() string {
+	return "node"
+}
+
+// versionsDir returns nodenv's versions directory, honoring NODENV_ROOT
+func (p *Provider) versionsDir() string {
+	root := migration.DirFromEnv("NODENV_ROOT", ".nodenv")
+	if root == "" {
+		return ""
+	}
+	return filepath.Join(root, "versions")
+}
+
+// IsPresent checks if nodenv is installed on the system.
+func (p *Provider) IsPresent() bool {
+	dir := p.versionsDir()
+	if dir == "" {
+		return false
+	}
+	_, err := os.Stat(dir)
+	return err == nil
+}
+
+// DetectVersions finds all versions installed by nodenv.
+func (p *Provider) DetectVersions() ([]migration.DetectedVersion, error) {
+	dir := p.versionsDir()
+	if dir == "" {
+		return make([]migration.DetectedVersion, 0), nil
+	}
+	return migration.DetectVersionDirs(dir, "nodenv", filepath.Join("bin", "node")), nil
+}
+
+// CanAutoUninstall returns true because nodenv supports automatic uninstall.
+func (p *Provider) CanAutoUninstall() bool {
+	return true
+}
+
+// UninstallCommand returns the command to uninstall a specific version.
+func (p *Provider) UninstallCommand(version string) string {
+	return fmt.Sprintf("nodenv uninstall -f %s", version)
+}
+
+// ShellInit returns the lines nodenv adds to shell startup files.
+func (p *Provider) ShellInit() path.ShellInit {
+	return shellInit
+}
+
+// ManualInstructions returns instructions for manual removal.
+func (p *Provider) ManualInstructions() string {
+	return "To manually remove a nodenv-installed Node.js version:\n" +
+		"  1. Run: nodenv uninstall <version>\n" +
+		"  2. Or manually delete the version directory from ~/.nodenv/versions/"
+}
+
+// init registers the nodenv provider on package load.
+func init() {
+	if err := migration.Register(NewProvider()); err != nil {
+		panic(fmt.Sprintf("failed to register nodenv migration provider: %v", err))
+	}
+}
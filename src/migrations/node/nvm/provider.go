@@ -9,8 +9,18 @@ import (
 	"strings"
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/migration"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/path"
 )
 
+// shellInit matches NVM_DIR and the lines that load nvm.sh and its completion
+var shellInit = path.ShellInit{
+	Manager: "nvm",
+	Lines: []*regexp.Regexp{
+		regexp.MustCompile(`\bNVM_DIR\b`),
+		regexp.MustCompile(`nvm\.sh`),
+	},
+}
+
 // Provider implements the migration.Provider interface for nvm.
 type Provider struct{}
 
@@ -124,6 +134,11 @@ func (p *Provider) UninstallCommand(version string) string {
 	return fmt.Sprintf("nvm uninstall %s", version)
 }
 
+// ShellInit returns the lines nvm adds to shell startup files.
+func (p *Provider) ShellInit() path.ShellInit {
+	return shellInit
+}
+
 // ManualInstructions returns instructions for manual removal.
 func (p *Provider) ManualInstructions() string {
 	return "To manually remove an nvm-installed Node.js version:\n" +
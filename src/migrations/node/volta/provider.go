@@ -0,0 +1,145 @@
+// Package volta provides a migration provider for Volta.
+package volta
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	goruntime "runtime"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/migration"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/path"
+)
+
+// shellInit matches VOLTA_HOME and Volta's bin directory on PATH
+var shellInit = path.ShellInit{
+	Manager: "volta",
+	Lines: []*regexp.Regexp{
+		regexp.MustCompile(`\bVOLTA_HOME\b`),
+	},
+}
+
+// Provider implements the migration.Provider interface for Volta.
+type Provider struct{}
+
+// NewProvider creates a new Volta migration provider.
+func NewProvider() *Provider {
+	return &Provider{}
+}
+
+// Name returns the identifier for this version manager.
+func (p *Provider) Name() string {
+	return "volta"
+}
+
+// DisplayName returns the human-readable name.
+func (p *Provider) DisplayName() string {
+	return "Volta"
+}
+
+// Runtime returns the runtime this provider manages.
+func (p *Provider) Runtime() string {
+	return "node"
+}
+
+// nodeImagesDir returns the directory Volta unpacks Node.js versions into.
+// Volta honors VOLTA_HOME and defaults to ~/.volta (%LOCALAPPDATA%\Volta on
+// Windows).
+func (p *Provider) nodeImagesDir() string {
+	var voltaHome string
+	if os.Getenv("VOLTA_HOME") == "" && goruntime.GOOS == "windows" && os.Getenv("LOCALAPPDATA") != "" {
+		voltaHome = filepath.Join(os.Getenv("LOCALAPPDATA"), "Volta")
+	} else {
+		voltaHome = migration.DirFromEnv("VOLTA_HOME", ".volta")
+	}
+	if voltaHome == "" {
+		return ""
+	}
+	return filepath.Join(voltaHome, "tools", "image", "node")
+}
+
+// IsPresent checks if Volta has installed any Node.js version.
+func (p *Provider) IsPresent() bool {
+	dir := p.nodeImagesDir()
+	if dir == "" {
+		return false
+	}
+	_, err := os.Stat(dir)
+	return err == nil
+}
+
+// DetectVersions finds all Node.js versions installed by Volta.
+func (p *Provider) DetectVersions() ([]migration.DetectedVersion, error) {
+	dir := p.nodeImagesDir()
+	if dir == "" {
+		return make([]migration.DetectedVersion, 0), nil
+	}
+	return migration.DetectVersionDirs(dir, "volta", filepath.Join("bin", "node"), "node.exe"), nil
+}
+
+// VersionFiles returns the project files Volta reads versions from.
+func (p *Provider) VersionFiles() []config.VersionFile {
+	return []config.VersionFile{packageJSONFile{}}
+}
+
+// CanAutoUninstall returns false because Volta cannot uninstall Node.js
+// versions; it only removes packages.
+func (p *Provider) CanAutoUninstall() bool {
+	return false
+}
+
+// UninstallCommand returns an empty string because Volta has no uninstall
+// command for Node.js versions.
+func (p *Provider) UninstallCommand(version string) string {
+	return ""
+}
+
+// ShellInit returns the lines volta adds to shell startup files.
+func (p *Provider) ShellInit() path.ShellInit {
+	return shellInit
+}
+
+// ManualInstructions returns instructions for manual removal.
+func (p *Provider) ManualInstructions() string {
+	return "Volta cannot uninstall Node.js versions. To remove one manually:\n" +
+		"  1. Delete the version directory from ~/.volta/tools/image/node/\n" +
+		"  2. Remove the matching archive from ~/.volta/tools/inventory/node/\n" +
+		"  3. If it was your default, run: volta install node@<version> with another version"
+}
+
+// packageJSONFile reads the Node.js version Volta pins in package.json:
+//
+//	"volta": { "node": "20.11.0" }
+//
+// It implements config.VersionFile.
+type packageJSONFile struct{}
+
+func (packageJSONFile) Name() string {
+	return "package.json"
+}
+
+func (packageJSONFile) Supports(runtimeName string) bool {
+	return runtimeName == "node"
+}
+
+func (packageJSONFile) Parse(data []byte, runtimeName string) (string, error) {
+	var pkg struct {
+		Volta struct {
+			Node string `json:"node"`
+		} `json:"volta"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return "", err
+	}
+	return config.NormalizeFileVersion(runtimeName, pkg.Volta.Node), nil
+}
+
+// init registers the Volta provider on package load.
+func init() {
+	if err := migration.Register(NewProvider()); err != nil {
+		panic(fmt.Sprintf("failed to register volta migration provider: %v", err))
+	}
+}
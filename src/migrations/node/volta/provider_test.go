@@ -0,0 +1,78 @@
+package volta
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/migration"
+)
+
+func TestProvider(t *testing.T) {
+	harness := &migration.ProviderTestHarness{
+		Provider:     NewProvider(),
+		ExpectedName: "volta",
+		Runtime:      "node",
+	}
+	harness.RunAll(t)
+}
+
+func TestProvider_CannotAutoUninstall(t *testing.T) {
+	p := NewProvider()
+	if p.CanAutoUninstall() {
+		t.Error("CanAutoUninstall() = true, want false")
+	}
+	if cmd := p.UninstallCommand("20.11.0"); cmd != "" {
+		t.Errorf("UninstallCommand() = %q, want empty", cmd)
+	}
+}
+
+func TestProvider_DetectVersions(t *testing.T) {
+	voltaHome := t.TempDir()
+	t.Setenv("VOLTA_HOME", voltaHome)
+
+	nodePath := filepath.Join(voltaHome, "tools", "image", "node", "20.11.0", "bin", "node")
+	if err := os.MkdirAll(filepath.Dir(nodePath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(nodePath, nil, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	detected, err := NewProvider().DetectVersions()
+	if err != nil {
+		t.Fatalf("DetectVersions() error = %v", err)
+	}
+	if len(detected) != 1 || detected[0].Version != "20.11.0" || detected[0].Source != "volta" {
+		t.Errorf("DetectVersions() = %+v, want 20.11.0 from volta", detected)
+	}
+}
+
+func TestPackageJSONFile_Parse(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{name: "pinned", data: `{"name": "app", "volta": {"node": "20.11.0", "npm": "10.2.4"}}`, expected: "20.11.0"},
+		{name: "v prefix", data: `{"volta": {"node": "v18.19.0"}}`, expected: "18.19.0"},
+		{name: "engines are not pins", data: `{"engines": {"node": ">=18"}}`, expected: ""},
+		{name: "extends only", data: `{"volta": {"extends": "../package.json"}}`, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := packageJSONFile{}.Parse([]byte(tt.data), "node")
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Parse() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := (packageJSONFile{}).Parse([]byte("{"), "node"); err == nil {
+		t.Error("Parse() of invalid JSON should return an error")
+	}
+}
@@ -0,0 +1,208 @@
+// Package conda provides a migration provider for conda environments
+// (Anaconda, Miniconda, Miniforge and micromamba).
+package conda
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/migration"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/path"
+)
+
+// rootDirNames are the installer default directories under the home
+// directory
+var rootDirNames = []string{"miniconda3", "anaconda3", "miniforge3", "mambaforge", "micromamba"}
+
+// pythonMetaPattern matches the conda-meta record of the python package,
+// e.g. "python-3.12.1-h2755cc3_1_cpython.json"
+var pythonMetaPattern = regexp.MustCompile(`^python-(\d+\.\d+\.\d+)-.*\.json$`)
+
+// shellInit matches the blocks conda init and mamba init write
+var shellInit = path.ShellInit{
+	Manager: "conda",
+	Blocks: []path.MarkerBlock{
+		{Begin: "# >>> conda initialize >>>", End: "# <<< conda initialize <<<"},
+		{Begin: "# >>> mamba initialize >>>", End: "# <<< mamba initialize <<<"},
+	},
+}
+
+// Provider implements the migration.Provider interface for conda.
+type Provider struct{}
+
+// NewProvider creates a new conda migration provider.
+func NewProvider() *Provider {
+	return &Provider{}
+}
+
+// Name returns the identifier for this version manager.
+func (p *Provider) Name() string {
+	return "conda"
+}
+
+// DisplayName returns the human-readable name.
+func (p *Provider) DisplayName() string {
+	return "conda"
+}
+
+// Runtime returns the runtime this provider manages.
+func (p *Provider) Runtime() string {
+	return "python"
+}
+
+// IsPresent checks if a conda installation exists.
+func (p *Provider) IsPresent() bool {
+	return len(p.environments()) > 0
+}
+
+// DetectVersions finds the Python version of every conda environment.
+// Environments without Python are skipped.
+func (p *Provider) DetectVersions() ([]migration.DetectedVersion, error) {
+	detected := make([]migration.DetectedVersion, 0)
+	for _, env := range p.environments() {
+		version := pythonVersion(env)
+		if version == "" {
+			continue
+		}
+
+		for _, pythonPath := range []string{
+			filepath.Join(env, "bin", "python"),
+			filepath.Join(env, "python.exe"),
+		} {
+			if _, err := os.Stat(pythonPath); err == nil {
+				detected = append(detected, migration.DetectedVersion{
+					Version:   version,
+					Path:      pythonPath,
+					Source:    "conda",
+					Validated: false,
+				})
+				break
+			}
+		}
+	}
+	return detected, nil
+}
+
+// environments returns the conda environment directories: the base
+// environment and envs/* of every conda installation found, plus the
+// environments conda has recorded in ~/.conda/environments.txt
+func (p *Provider) environments() []string {
+	var roots []string
+	if condaExe := os.Getenv("CONDA_EXE"); condaExe != "" {
+		// <root>/bin/conda or <root>\Scripts\conda.exe
+		roots = append(roots, filepath.Dir(filepath.Dir(condaExe)))
+	}
+	if mambaRoot := os.Getenv("MAMBA_ROOT_PREFIX"); mambaRoot != "" {
+		roots = append(roots, mambaRoot)
+	}
+
+	home, homeErr := os.UserHomeDir()
+	if homeErr == nil {
+		for _, name := range rootDirNames {
+			roots = append(roots, filepath.Join(home, name))
+		}
+	}
+
+	seen := make(map[string]bool)
+	var envs []string
+	add := func(dir string) {
+		dir = filepath.Clean(dir)
+		if seen[dir] {
+			return
+		}
+		if _, err := os.Stat(filepath.Join(dir, "conda-meta")); err != nil {
+			return
+		}
+		seen[dir] = true
+		envs = append(envs, dir)
+	}
+
+	for _, root := range roots {
+		add(root)
+		entries, err := os.ReadDir(filepath.Join(root, "envs"))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				add(filepath.Join(root, "envs", entry.Name()))
+			}
+		}
+	}
+
+	if homeErr == nil {
+		for _, env := range readEnvironmentsFile(filepath.Join(home, ".conda", "environments.txt")) {
+			add(env)
+		}
+	}
+	return envs
+}
+
+// readEnvironmentsFile returns the environment paths conda records, one per
+// line
+func readEnvironmentsFile(path string) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = f.Close() }()
+
+	var envs []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			envs = append(envs, line)
+		}
+	}
+	return envs
+}
+
+// pythonVersion returns the version of the python package installed in an
+// environment, read from its conda-meta records
+func pythonVersion(env string) string {
+	entries, err := os.ReadDir(filepath.Join(env, "conda-meta"))
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		if m := pythonMetaPattern.FindStringSubmatch(entry.Name()); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// CanAutoUninstall returns false because Python can't be removed from a
+// conda environment without removing the environment and its packages.
+func (p *Provider) CanAutoUninstall() bool {
+	return false
+}
+
+// UninstallCommand returns an empty string because automatic uninstall is
+// not supported.
+func (p *Provider) UninstallCommand(version string) string {
+	return ""
+}
+
+// ShellInit returns the lines conda adds to shell startup files.
+func (p *Provider) ShellInit() path.ShellInit {
+	return shellInit
+}
+
+// ManualInstructions returns instructions for manual removal.
+func (p *Provider) ManualInstructions() string {
+	return "Python belongs to its conda environment. Once nothing needs the environment:\n" +
+		"  1. Run: conda env remove -n <name> (see 'conda env list')\n" +
+		"  2. To remove conda entirely, run 'conda init --reverse' and delete its install directory"
+}
+
+// init registers the conda provider on package load.
+func init() {
+	if err := migration.Register(NewProvider()); err != nil {
+		panic(fmt.Sprintf("failed to register conda migration provider: %v", err))
+	}
+}
@@ -0,0 +1,87 @@
+package conda
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/migration"
+)
+
+func TestProvider(t *testing.T) {
+	harness := &migration.ProviderTestHarness{
+		Provider:     NewProvider(),
+		ExpectedName: "conda",
+		Runtime:      "python",
+	}
+	harness.RunAll(t)
+}
+
+func TestProvider_CannotAutoUninstall(t *testing.T) {
+	p := NewProvider()
+	if p.CanAutoUninstall() {
+		t.Error("CanAutoUninstall() = true, want false")
+	}
+}
+
+// writeEnv creates a fake conda environment with the given python version;
+// an empty version creates an environment without python
+func writeEnv(t *testing.T, env, version string) {
+	t.Helper()
+	meta := filepath.Join(env, "conda-meta")
+	if err := os.MkdirAll(meta, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if version == "" {
+		return
+	}
+	files := map[string]string{
+		filepath.Join(meta, "python-"+version+"-h2628c8c_0_cpython.json"): "{}",
+		filepath.Join(meta, "python-dateutil-2.9.0-pyhd8ed1ab_0.json"):    "{}",
+		filepath.Join(env, "bin", "python"):                               "",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestProvider_DetectVersions(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("CONDA_EXE", "")
+	t.Setenv("MAMBA_ROOT_PREFIX", "")
+
+	root := filepath.Join(home, "miniconda3")
+	writeEnv(t, root, "3.12.1")
+	writeEnv(t, filepath.Join(root, "envs", "legacy"), "3.9.18")
+	writeEnv(t, filepath.Join(root, "envs", "tools"), "")
+	// A plain directory is not an environment
+	if err := os.MkdirAll(filepath.Join(root, "envs", "scratch"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if !NewProvider().IsPresent() {
+		t.Error("IsPresent() = false, want true")
+	}
+
+	detected, err := NewProvider().DetectVersions()
+	if err != nil {
+		t.Fatalf("DetectVersions() error = %v", err)
+	}
+	versions := make(map[string]bool)
+	for _, dv := range detected {
+		versions[dv.Version] = true
+		if dv.Source != "conda" {
+			t.Errorf("Source = %q, want conda", dv.Source)
+		}
+	}
+	if len(detected) != 2 || !versions["3.12.1"] || !versions["3.9.18"] {
+		t.Errorf("DetectVersions() = %+v, want 3.12.1 and 3.9.18", detected)
+	}
+}
@@ -8,8 +8,18 @@ import (
 	"regexp"
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/migration"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/path"
 )
 
+// shellInit matches PYENV_ROOT, pyenv init and pyenv virtualenv-init
+var shellInit = path.ShellInit{
+	Manager: "pyenv",
+	Lines: []*regexp.Regexp{
+		regexp.MustCompile(`\bPYENV_ROOT\b`),
+		regexp.MustCompile(`\bpyenv (virtualenv-)?init\b`),
+	},
+}
+
 // Provider implements the migration.Provider interface for pyenv.
 type Provider struct{}
 
@@ -128,6 +138,11 @@ func (p *Provider) UninstallCommand(version string) string {
 	return fmt.Sprintf("pyenv uninstall %s", version)
 }
 
+// ShellInit returns the lines pyenv adds to shell startup files.
+func (p *Provider) ShellInit() path.ShellInit {
+	return shellInit
+}
+
 // ManualInstructions returns instructions for manual removal.
 func (p *Provider) ManualInstructions() string {
 	return "To manually remove a pyenv-installed Python version:\n" +
@@ -8,8 +8,18 @@ import (
 	"regexp"
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/migration"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/path"
 )
 
+// shellInit matches the lines that load chruby.sh and auto.sh, and a default chruby call
+var shellInit = path.ShellInit{
+	Manager: "chruby",
+	Lines: []*regexp.Regexp{
+		regexp.MustCompile(`chruby(/auto)?\.(sh|fish)`),
+		regexp.MustCompile(`^chruby `),
+	},
+}
+
 // Provider implements the migration.Provider interface for chruby.
 type Provider struct{}
 
@@ -109,6 +119,11 @@ func (p *Provider) UninstallCommand(version string) string {
 	return ""
 }
 
+// ShellInit returns the lines chruby adds to shell startup files.
+func (p *Provider) ShellInit() path.ShellInit {
+	return shellInit
+}
+
 // ManualInstructions returns instructions for manual removal.
 func (p *Provider) ManualInstructions() string {
 	return "To manually remove a chruby-installed Ruby version:\n" +
@@ -8,8 +8,18 @@ import (
 	"regexp"
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/migration"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/path"
 )
 
+// shellInit matches rbenv init and rbenv's bin directory on PATH
+var shellInit = path.ShellInit{
+	Manager: "rbenv",
+	Lines: []*regexp.Regexp{
+		regexp.MustCompile(`\brbenv init\b`),
+		regexp.MustCompile(`\.rbenv/bin`),
+	},
+}
+
 // Provider implements the migration.Provider interface for rbenv.
 type Provider struct{}
 
@@ -91,6 +101,11 @@ func (p *Provider) UninstallCommand(version string) string {
 	return fmt.Sprintf("rbenv uninstall %s", version)
 }
 
+// ShellInit returns the lines rbenv adds to shell startup files.
+func (p *Provider) ShellInit() path.ShellInit {
+	return shellInit
+}
+
 // ManualInstructions returns instructions for manual removal.
 func (p *Provider) ManualInstructions() string {
 	return "To manually remove an rbenv-installed Ruby version:\n" +
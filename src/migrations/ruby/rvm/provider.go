@@ -8,8 +8,18 @@ import (
 	"regexp"
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/migration"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/path"
 )
 
+// shellInit matches the lines that load ~/.rvm/scripts/rvm and put ~/.rvm/bin on PATH
+var shellInit = path.ShellInit{
+	Manager: "rvm",
+	Lines: []*regexp.Regexp{
+		regexp.MustCompile(`\.rvm/`),
+		regexp.MustCompile(`^# Add RVM to PATH`),
+	},
+}
+
 // Provider implements the migration.Provider interface for rvm.
 type Provider struct{}
 
@@ -95,6 +105,11 @@ func (p *Provider) UninstallCommand(version string) string {
 	return fmt.Sprintf("rvm remove ruby-%s", version)
 }
 
+// ShellInit returns the lines rvm adds to shell startup files.
+func (p *Provider) ShellInit() path.ShellInit {
+	return shellInit
+}
+
 // ManualInstructions returns instructions for manual removal.
 func (p *Provider) ManualInstructions() string {
 	return "To manually remove an rvm-installed Ruby version:\n" +
@@ -79,6 +79,12 @@ func (p *Provider) getVersion(execPath string) string {
 	return ""
 }
 
+// IsSystem returns true; system installs share their prefix with other
+// software and can't be adopted.
+func (p *Provider) IsSystem() bool {
+	return true
+}
+
 // CanAutoUninstall returns false because system installs require manual removal.
 func (p *Provider) CanAutoUninstall() bool {
 	return false
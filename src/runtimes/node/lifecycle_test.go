@@ -1,3 +1,5 @@
+//go:build !shim
+
 package node
 
 import (
@@ -104,54 +106,10 @@ func TestLifecycleProvider_VersionStatus(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			lp := newLifecycleProviderAt(mustParseDate(tt.now))
-			got := lp.VersionStatus(tt.version)
+			got := lifecycleSchedule().StatusAt(tt.version, mustParseDate(tt.now))
 			if got != tt.want {
 				t.Errorf("VersionStatus(%q) at %s = %q, want %q", tt.version, tt.now, got, tt.want)
 			}
 		})
 	}
 }
-
-func TestExtractMajor(t *testing.T) {
-	tests := []struct {
-		version string
-		want    int
-	}{
-		{"22.14.0", 22},
-		{"v22.14.0", 22},
-		{"18.16.0", 18},
-		{"0.12.0", 0},
-		{"abc", -1},
-		{"", -1},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.version, func(t *testing.T) {
-			got := extractMajor(tt.version)
-			if got != tt.want {
-				t.Errorf("extractMajor(%q) = %d, want %d", tt.version, got, tt.want)
-			}
-		})
-	}
-}
-
-func TestParseDate(t *testing.T) {
-	tests := []struct {
-		input string
-		zero  bool
-	}{
-		{"2024-04-24", false},
-		{"", true},
-		{"invalid", true},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			got := parseDate(tt.input)
-			if got.IsZero() != tt.zero {
-				t.Errorf("parseDate(%q).IsZero() = %v, want %v", tt.input, got.IsZero(), tt.zero)
-			}
-		})
-	}
-}
@@ -2,7 +2,8 @@
 //
 // This file holds the "shim half" of the provider: the methods invoked by the
 // shim binary at runtime (Name, DisplayName, Shims, ExecutablePath, IsInstalled,
-// InstallPath, ShouldReshimAfter, GetEnvironment) plus init() registration.
+// InstallPath, ShouldReshimAfter, GetEnvironment, ResolveAlias) plus init()
+// registration.
 // The heavy install/list/migrate methods, along with their dependencies on
 // HTTP, manifests, and archive extraction, live in provider_full.go behind a
 // //go:build !shim tag so the shim binary never links them.
@@ -13,6 +14,9 @@ import (
 	"os"
 	"path/filepath"
 	goruntime "runtime"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
@@ -65,15 +69,7 @@ func (p *Provider) ExecutablePath(version string) (string, error) {
 
 // IsInstalled checks if a version is installed.
 func (p *Provider) IsInstalled(version string) (bool, error) {
-	installPath := config.RuntimeVersionPath("node", version)
-	_, err := os.Stat(installPath)
-	if os.IsNotExist(err) {
-		return false, nil
-	}
-	if err != nil {
-		return false, err
-	}
-	return true, nil
+	return config.IsVersionInstalled("node", version), nil
 }
 
 // InstallPath returns the installation directory for a version.
@@ -115,6 +111,37 @@ func (p *Provider) GetEnvironment(_ string) (map[string]string, error) {
 	return map[string]string{}, nil
 }
 
+// ResolveAlias converts nvm-style aliases into version constraints:
+// "lts/<codename>" resolves to that LTS major, "lts/*" to any line that has
+// entered LTS, and "node"/"stable"/"current" to the latest release. LTS
+// lines and codenames come from the embedded release schedule.
+func (p *Provider) ResolveAlias(alias string) (string, bool) {
+	alias = strings.ToLower(strings.TrimSpace(alias))
+
+	switch alias {
+	case "node", "stable", "current":
+		return "latest", true
+	case "lts", "lts/*":
+		majors := ltsMajors(time.Now())
+		if len(majors) == 0 {
+			return "", false
+		}
+		parts := make([]string, len(majors))
+		for i, major := range majors {
+			parts[i] = strconv.Itoa(major)
+		}
+		return strings.Join(parts, " || "), true
+	}
+
+	if codename, ok := strings.CutPrefix(alias, "lts/"); ok {
+		if major, ok := ltsMajor(codename); ok {
+			return strconv.Itoa(major), true
+		}
+	}
+
+	return "", false
+}
+
 // init registers the Node.js provider on package load.
 func init() {
 	if err := runtime.Register(NewProvider()); err != nil {
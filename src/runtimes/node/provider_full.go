@@ -15,6 +15,7 @@ import (
 	goruntime "runtime"
 	"strings"
 
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/cache"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/download"
@@ -26,6 +27,14 @@ import (
 
 // Install downloads and installs a specific version.
 func (p *Provider) Install(version string) error {
+	return p.InstallWithProgress(version, nil)
+}
+
+// InstallWithProgress installs a specific version, sending progress to
+// reporter instead of the console when reporter is non-nil.
+func (p *Provider) InstallWithProgress(version string, reporter ui.ProgressReporter) error {
+	out := ui.NewTaskOutput(reporter)
+
 	if err := config.EnsureDirectories(); err != nil {
 		return fmt.Errorf("failed to create dtvem directories: %w", err)
 	}
@@ -34,14 +43,18 @@ func (p *Provider) Install(version string) error {
 		return fmt.Errorf("Node.js %s is already installed", version)
 	}
 
-	ui.Header("Installing Node.js v%s...", version)
+	out.Header("Installing Node.js v%s...", version)
 
-	downloadURL, archiveName, err := p.getDownloadURL(version)
+	dl, archiveName, err := p.getDownload(version)
 	if err != nil {
 		return fmt.Errorf("failed to get download URL: %w", err)
 	}
 
-	ui.Progress("Downloading from %s", downloadURL)
+	inst, err := runtime.BeginInstall("node", version)
+	if err != nil {
+		return err
+	}
+	defer inst.Rollback()
 
 	tempDir := filepath.Join(os.TempDir(), fmt.Sprintf("dtvem-node-%s", version))
 	if err := os.MkdirAll(tempDir, 0755); err != nil {
@@ -49,16 +62,19 @@ func (p *Provider) Install(version string) error {
 	}
 	defer func() { _ = os.RemoveAll(tempDir) }()
 
-	archivePath := filepath.Join(tempDir, archiveName)
-	if err := download.File(downloadURL, archivePath); err != nil {
+	archivePath, err := cache.Fetch(cache.Request{
+		Runtime:  "node",
+		Version:  version,
+		URL:      dl.URL,
+		SHA256:   dl.SHA256,
+		FileName: archiveName,
+	}, tempDir, out)
+	if err != nil {
 		return fmt.Errorf("failed to download: %w", err)
 	}
 
-	installPath := config.RuntimeVersionPath("node", version)
-
-	extractDir := filepath.Join(tempDir, "extracted")
-	spinner := ui.NewSpinner("Extracting archive...")
-	spinner.Start()
+	extractDir := inst.StagingDir
+	spinner := out.StartStep("Extracting archive...")
 
 	var extractErr error
 	if strings.HasSuffix(archiveName, ".zip") {
@@ -79,44 +95,47 @@ func (p *Provider) Install(version string) error {
 	}
 	spinner.Success("Extraction complete")
 
-	if err := os.MkdirAll(filepath.Dir(installPath), 0755); err != nil {
-		return fmt.Errorf("failed to create install directory: %w", err)
-	}
-
-	if err := os.Rename(extractDir, installPath); err != nil {
-		return fmt.Errorf("failed to move to install location: %w", err)
+	if err := inst.Promote(extractDir); err != nil {
+		return err
 	}
 
-	shimSpinner := ui.NewSpinner("Creating shims...")
-	shimSpinner.Start()
+	// Shims are created before the commit, so a failure rolls the install
+	// back; the shims only this version provides are removed with it
+	shimSpinner := out.StartStep("Creating shims...")
 	if err := p.createShims(version); err != nil {
 		shimSpinner.Error("Failed to create shims")
+		_, _ = shim.UnregisterVersion("node", version)
 		return fmt.Errorf("failed to create shims: %w", err)
 	}
 	shimSpinner.Success("Shims created")
 
-	ui.Success("Node.js v%s installed successfully", version)
-	ui.Info("Location: %s", installPath)
+	if err := inst.Commit(); err != nil {
+		_, _ = shim.UnregisterVersion("node", version)
+		return fmt.Errorf("failed to complete install: %w", err)
+	}
+
+	out.Success("Node.js v%s installed successfully", version)
+	out.Info("Location: %s", inst.InstallPath)
 
 	return nil
 }
 
-// getDownloadURL returns the download URL and archive name for a given version.
-func (p *Provider) getDownloadURL(version string) (string, string, error) {
+// getDownload returns the manifest download entry and archive name for a given version.
+func (p *Provider) getDownload(version string) (*manifest.Download, string, error) {
 	m, err := manifest.DefaultSource().GetManifest("node")
 	if err != nil {
-		return "", "", fmt.Errorf("failed to load manifest: %w", err)
+		return nil, "", fmt.Errorf("failed to load manifest: %w", err)
 	}
 
 	platform := manifest.CurrentPlatform()
 	dl := m.GetDownload(version, platform)
 	if dl == nil {
-		return "", "", fmt.Errorf("Node.js %s is not available for %s", version, platform)
+		return nil, "", fmt.Errorf("Node.js %s is not available for %s", version, platform)
 	}
 
 	archiveName := filepath.Base(dl.URL)
 
-	return dl.URL, archiveName, nil
+	return dl, archiveName, nil
 }
 
 // createShims creates shims for Node.js executables and registers them in the
@@ -143,35 +162,19 @@ func (p *Provider) createShims(version string) error {
 	return manager.CreateShimsForRuntime("node", version, shimNames)
 }
 
-// Uninstall removes an installed version.
+// Uninstall removes an installed version and the shims only it provided.
 func (p *Provider) Uninstall(version string) error {
-	// TODO: Implement Node.js uninstallation
-	return fmt.Errorf("not yet implemented")
+	return shim.UninstallVersion(p, version)
 }
 
 // ListInstalled returns all installed Node.js versions.
 func (p *Provider) ListInstalled() ([]runtime.InstalledVersion, error) {
-	paths := config.DefaultPaths()
-	nodeVersionsDir := filepath.Join(paths.Versions, "node")
-
-	if _, err := os.Stat(nodeVersionsDir); os.IsNotExist(err) {
-		return []runtime.InstalledVersion{}, nil
-	}
-
-	entries, err := os.ReadDir(nodeVersionsDir)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read versions directory: %w", err)
-	}
-
 	versions := make([]runtime.InstalledVersion, 0)
-	for _, entry := range entries {
-		if entry.IsDir() {
-			versions = append(versions, runtime.InstalledVersion{
-				Version:     runtime.NewVersion(entry.Name()),
-				InstallPath: filepath.Join(nodeVersionsDir, entry.Name()),
-				IsGlobal:    false, // TODO: Check if this is the global version
-			})
-		}
+	for _, version := range config.InstalledVersions("node") {
+		versions = append(versions, runtime.InstalledVersion{
+			Version:     runtime.NewVersion(version),
+			InstallPath: config.RuntimeVersionPath("node", version),
+		})
 	}
 
 	return versions, nil
@@ -187,13 +190,13 @@ func (p *Provider) ListAvailable() ([]runtime.AvailableVersion, error) {
 	platform := manifest.CurrentPlatform()
 	versionStrings := m.ListAvailableVersions(platform)
 
-	lp := newLifecycleProvider()
+	schedule := lifecycleSchedule()
 
 	versions := make([]runtime.AvailableVersion, 0, len(versionStrings))
 	for _, v := range versionStrings {
 		versions = append(versions, runtime.AvailableVersion{
 			Version:         runtime.NewVersion(v),
-			LifecycleStatus: lp.VersionStatus(v),
+			LifecycleStatus: schedule.VersionStatus(v),
 		})
 	}
 
@@ -228,7 +231,7 @@ func (p *Provider) SetLocalVersion(version string) error {
 
 // CurrentVersion returns the currently active version.
 func (p *Provider) CurrentVersion() (string, error) {
-	return config.ResolveVersion("node")
+	return runtime.CurrentVersion(p)
 }
 
 // DetectInstalled scans the system for existing Node.js installations.
@@ -337,3 +340,20 @@ func findNpmInInstall(installDir string) string {
 
 	return ""
 }
+
+// SmokeTestArgs returns the arguments dtvem verify runs node with.
+func (p *Provider) SmokeTestArgs() []string {
+	return []string{"--version"}
+}
+
+// MutablePaths returns the files npm changes when global packages (npm
+// itself included) are installed or updated.
+func (p *Provider) MutablePaths() []string {
+	paths := []string{"node_modules"}
+	if goruntime.GOOS == constants.OSWindows {
+		// npm and npx wrappers live next to node.exe and are rewritten by
+		// npm install -g npm
+		paths = append(paths, "npm", "npm.cmd", "npm.ps1", "npx", "npx.cmd", "npx.ps1")
+	}
+	return paths
+}
@@ -2,6 +2,7 @@ package node
 
 import (
 	"testing"
+	"time"
 
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/runtime"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/testutil"
@@ -82,3 +83,63 @@ func TestNodeProvider_InstallPath(t *testing.T) {
 		}
 	}
 }
+
+// TestNodeProvider_ResolveAlias tests nvm-style alias translation
+func TestNodeProvider_ResolveAlias(t *testing.T) {
+	provider := NewProvider()
+
+	tests := []struct {
+		alias    string
+		expected string
+		ok       bool
+	}{
+		{"lts/iron", "20", true},
+		{"LTS/Hydrogen", "18", true},
+		{"lts/krypton", "24", true},
+		{"node", "latest", true},
+		{"lts/unknown", "", false},
+		{"20.11.0", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.alias, func(t *testing.T) {
+			got, ok := provider.ResolveAlias(tt.alias)
+			if ok != tt.ok || got != tt.expected {
+				t.Errorf("ResolveAlias(%q) = (%q, %v), want (%q, %v)", tt.alias, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+
+	t.Run("lts/* selects the newest LTS line", func(t *testing.T) {
+		constraint, ok := provider.ResolveAlias("lts/*")
+		if !ok {
+			t.Fatal("ResolveAlias(lts/*) not recognized")
+		}
+		got, err := runtime.ResolveVersionSpec(provider, "lts/*", []string{"20.18.1", "21.7.3", "22.11.0", "23.3.0"})
+		if err != nil {
+			t.Fatalf("ResolveVersionSpec(lts/*) error: %v (constraint %q)", err, constraint)
+		}
+		if got != "22.11.0" {
+			t.Errorf("ResolveVersionSpec(lts/*) = %q, want 22.11.0", got)
+		}
+	})
+}
+
+// TestLTSMajors tests that LTS lines come from the release schedule as they
+// enter LTS
+func TestLTSMajors(t *testing.T) {
+	before := ltsMajors(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	after := ltsMajors(time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC))
+
+	if len(before) == 0 || before[0] != 4 || before[len(before)-1] != 24 {
+		t.Errorf("ltsMajors(2026-10-01) = %v, want 4 ... 24", before)
+	}
+	if len(after) != len(before)+1 || after[len(after)-1] != 26 {
+		t.Errorf("ltsMajors(2026-11-01) = %v, want v26 added", after)
+	}
+	for _, major := range after {
+		if major%2 != 0 {
+			t.Errorf("ltsMajors() includes odd line %d", major)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package node
+
+import (
+	_ "embed"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+)
+
+// scheduleJSON is the Node.js release schedule
+// (https://github.com/nodejs/Release/blob/main/schedule.json). Shims use it
+// to resolve LTS aliases; the CLI also derives lifecycle status from it.
+//
+//go:embed data/schedule.json
+var scheduleJSON []byte
+
+// scheduleEntry represents a single major-version entry from the release
+// schedule
+type scheduleEntry struct {
+	Start       string `json:"start"`
+	LTS         string `json:"lts,omitempty"`
+	Maintenance string `json:"maintenance,omitempty"`
+	End         string `json:"end"`
+	Codename    string `json:"codename,omitempty"`
+}
+
+var (
+	schedule     map[string]scheduleEntry
+	scheduleOnce sync.Once
+)
+
+// releaseSchedule returns the embedded release schedule keyed by "v<major>",
+// parsed on first use. Returns nil if it can't be parsed.
+func releaseSchedule() map[string]scheduleEntry {
+	scheduleOnce.Do(func() {
+		if err := json.Unmarshal(scheduleJSON, &schedule); err != nil {
+			ui.Debug("Failed to parse the Node.js release schedule: %v", err)
+		}
+	})
+	return schedule
+}
+
+// ltsMajor returns the major version of the LTS line with the given
+// codename (case-insensitive), e.g. "iron" → 20
+func ltsMajor(codename string) (int, bool) {
+	for key, entry := range releaseSchedule() {
+		if entry.Codename != "" && strings.EqualFold(entry.Codename, codename) {
+			if major, err := strconv.Atoi(strings.TrimPrefix(key, "v")); err == nil {
+				return major, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// ltsMajors returns, in ascending order, the major versions of the lines
+// that have entered LTS by now
+func ltsMajors(now time.Time) []int {
+	var majors []int
+	for key, entry := range releaseSchedule() {
+		lts, err := time.Parse("2006-01-02", entry.LTS)
+		if err != nil || now.Before(lts) {
+			continue
+		}
+		if major, err := strconv.Atoi(strings.TrimPrefix(key, "v")); err == nil {
+			majors = append(majors, major)
+		}
+	}
+	sort.Ints(majors)
+	return majors
+}
@@ -0,0 +1,122 @@
+//go:build !shim
+
+// Lifecycle computation is only used by the dtvem CLI (e.g., `list-all`) and
+// embeds the devguide's release-cycle.json. Tagged !shim so neither the code
+// nor the embedded data is linked into the shim binary.
+package python
+
+import (
+	"embed"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/lifecycle"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+)
+
+//go:embed data/release-cycle.json
+var releaseCycleData embed.FS
+
+// releaseCycleEntry represents a single release line from the Python release
+// cycle (https://github.com/python/devguide/blob/main/include/release-cycle.json).
+type releaseCycleEntry struct {
+	Branch       string `json:"branch"`
+	PEP          int    `json:"pep"`
+	Status       string `json:"status"`
+	FirstRelease string `json:"first_release"`
+	EndOfLife    string `json:"end_of_life"`
+}
+
+// upstreamPhases maps the release-cycle.json status values to phases
+var upstreamPhases = map[string]lifecycle.Phase{
+	"feature":     lifecycle.PhasePrerelease,
+	"prerelease":  lifecycle.PhasePrerelease,
+	"bugfix":      lifecycle.PhaseActive,
+	"security":    lifecycle.PhaseMaintenance,
+	"end-of-life": lifecycle.PhaseEOL,
+}
+
+// statusLabels are the Python names of the lifecycle phases
+var statusLabels = map[lifecycle.Phase]lifecycle.Status{
+	lifecycle.PhasePrerelease:  lifecycle.Prerelease,
+	lifecycle.PhaseActive:      lifecycle.Bugfix,
+	lifecycle.PhaseMaintenance: lifecycle.Security,
+	lifecycle.PhaseEOL:         lifecycle.EOL,
+}
+
+// Ensure Provider satisfies the interface at compile time.
+var _ lifecycle.StatusProvider = (*Provider)(nil)
+
+// VersionStatus returns the lifecycle label for a Python version.
+func (p *Provider) VersionStatus(version string) string {
+	return lifecycleSchedule().VersionStatus(version)
+}
+
+var (
+	statusSchedule     *lifecycle.Schedule
+	statusScheduleOnce sync.Once
+)
+
+// lifecycleSchedule returns the Python release lines from the embedded
+// release cycle, parsed on first use
+func lifecycleSchedule() *lifecycle.Schedule {
+	statusScheduleOnce.Do(func() { statusSchedule = parseLifecycleSchedule() })
+	return statusSchedule
+}
+
+// parseLifecycleSchedule builds the Python release lines from the embedded
+// release cycle. Lines are empty if it can't be parsed.
+func parseLifecycleSchedule() *lifecycle.Schedule {
+	schedule := &lifecycle.Schedule{Labels: statusLabels, Key: lifecycle.MajorMinor}
+
+	data, err := releaseCycleData.ReadFile("data/release-cycle.json")
+	if err != nil {
+		ui.Debug("lifecycle: failed to read embedded release cycle: %v", err)
+		return schedule
+	}
+
+	var cycle map[string]releaseCycleEntry
+	if err := json.Unmarshal(data, &cycle); err != nil {
+		ui.Debug("lifecycle: failed to parse release cycle: %v", err)
+		return schedule
+	}
+
+	schedule.Lines = make(map[string]lifecycle.Line, len(cycle))
+	for name, e := range cycle {
+		first := lifecycle.ParseDate(e.FirstRelease)
+		starts := map[lifecycle.Phase]time.Time{
+			lifecycle.PhaseActive: first,
+			lifecycle.PhaseEOL:    parseEndOfLife(e.EndOfLife),
+		}
+		if !first.IsZero() {
+			starts[lifecycle.PhaseMaintenance] = first.AddDate(0, bugfixMonths(name), 0)
+		}
+		schedule.Lines[name] = lifecycle.Line{Upstream: upstreamPhases[e.Status], Starts: starts}
+	}
+	return schedule
+}
+
+// bugfixMonths returns how long a release line receives bugfix releases
+// before it moves to security fixes only: 18 months under PEP 602, and two
+// years for 3.13 and later, as the policy was amended
+func bugfixMonths(line string) int {
+	majorText, minorText, _ := strings.Cut(line, ".")
+	major, _ := strconv.Atoi(majorText)
+	minor, _ := strconv.Atoi(minorText)
+	if major > 3 || (major == 3 && minor >= 13) {
+		return 24
+	}
+	return 18
+}
+
+// parseEndOfLife parses an end-of-life date. Planned dates are given as
+// "YYYY-MM"; the release line is supported until the end of that month.
+func parseEndOfLife(s string) time.Time {
+	if t, err := time.Parse("2006-01", s); err == nil {
+		return t.AddDate(0, 1, 0)
+	}
+	return lifecycle.ParseDate(s)
+}
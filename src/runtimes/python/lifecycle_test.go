@@ -0,0 +1,129 @@
+//go:build !shim
+
+package python
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/lifecycle"
+)
+
+func mustParseDate(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestLifecycleProvider_VersionStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		now     string
+		version string
+		want    string
+	}{
+		// 3.15: upstream status feature, first release 2026-10-01
+		{
+			name:    "3.15 before first release is Prerelease",
+			now:     "2026-06-01",
+			version: "3.15.0a7",
+			want:    string(lifecycle.Prerelease),
+		},
+		{
+			name:    "3.15 after first release is Bugfix",
+			now:     "2026-10-02",
+			version: "3.15.0",
+			want:    string(lifecycle.Bugfix),
+		},
+		// 3.13: first release 2024-10-07, end of life 2029-10
+		{
+			name:    "3.13 after first release is Bugfix",
+			now:     "2025-06-01",
+			version: "3.13.4",
+			want:    string(lifecycle.Bugfix),
+		},
+		{
+			name:    "3.13 two years after first release is Security",
+			now:     "2026-10-08",
+			version: "3.13.9",
+			want:    string(lifecycle.Security),
+		},
+		{
+			name:    "3.13 during its end-of-life month is still Security",
+			now:     "2029-10-15",
+			version: "3.13.12",
+			want:    string(lifecycle.Security),
+		},
+		{
+			name:    "3.13 after its end-of-life month is EOL",
+			now:     "2029-11-01",
+			version: "3.13.12",
+			want:    string(lifecycle.EOL),
+		},
+		// 3.12: upstream status security, although only one year has passed
+		// by the date; the upstream status wins
+		{
+			name:    "3.12 upstream security status outranks dates",
+			now:     "2024-10-02",
+			version: "3.12.7",
+			want:    string(lifecycle.Security),
+		},
+		// 3.9: end of life 2025-10-31
+		{
+			name:    "3.9 with a full end-of-life date is EOL from that day",
+			now:     "2025-10-31",
+			version: "3.9.25",
+			want:    string(lifecycle.EOL),
+		},
+		{
+			name:    "2.7 is EOL",
+			now:     "2025-01-15",
+			version: "2.7.18",
+			want:    string(lifecycle.EOL),
+		},
+		// Edge cases
+		{
+			name:    "unknown release line returns empty",
+			now:     "2025-01-15",
+			version: "4.0.0",
+			want:    "",
+		},
+		{
+			name:    "invalid version returns empty",
+			now:     "2025-01-15",
+			version: "abc",
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lifecycleSchedule().StatusAt(tt.version, mustParseDate(tt.now))
+			if got != tt.want {
+				t.Errorf("VersionStatus(%q) at %s = %q, want %q", tt.version, tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBugfixMonths(t *testing.T) {
+	tests := []struct {
+		line string
+		want int
+	}{
+		{"3.9", 18},
+		{"3.12", 18},
+		{"3.13", 24},
+		{"3.15", 24},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			if got := bugfixMonths(tt.line); got != tt.want {
+				t.Errorf("bugfixMonths(%q) = %d, want %d", tt.line, got, tt.want)
+			}
+		})
+	}
+}
@@ -65,15 +65,7 @@ func (p *Provider) ExecutablePath(version string) (string, error) {
 
 // IsInstalled checks if a version is installed.
 func (p *Provider) IsInstalled(version string) (bool, error) {
-	installPath := config.RuntimeVersionPath("python", version)
-	_, err := os.Stat(installPath)
-	if os.IsNotExist(err) {
-		return false, nil
-	}
-	if err != nil {
-		return false, err
-	}
-	return true, nil
+	return config.IsVersionInstalled("python", version), nil
 }
 
 // InstallPath returns the installation directory for a version.
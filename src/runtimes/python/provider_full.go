@@ -16,6 +16,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/cache"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/download"
@@ -25,10 +26,14 @@ import (
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
 )
 
-// downloadAndExtract downloads and extracts the Python archive.
-func (p *Provider) downloadAndExtract(version, downloadURL, archiveName string) (extractDir string, cleanup func(), err error) {
-	ui.Progress("Downloading from %s", downloadURL)
-
+// downloadAndExtract downloads the Python archive and extracts it into extractDir
+// (the install's staging directory), returning the directory holding the
+// extracted files. A temporary directory used for the download is removed by
+// the returned cleanup function.
+// The archive comes from the download cache when present; otherwise it is
+// downloaded, verified against the manifest checksum according to the
+// configured checksum policy, and cached.
+func (p *Provider) downloadAndExtract(out ui.TaskOutput, version string, dl *manifest.Download, archiveName, extractDir string) (extractedDir string, cleanup func(), err error) {
 	tempDir := filepath.Join(os.TempDir(), fmt.Sprintf("dtvem-python-%s", version))
 	if err := os.MkdirAll(tempDir, 0755); err != nil {
 		return "", nil, fmt.Errorf("failed to create temp directory: %w", err)
@@ -36,15 +41,19 @@ func (p *Provider) downloadAndExtract(version, downloadURL, archiveName string)
 
 	cleanupFunc := func() { _ = os.RemoveAll(tempDir) }
 
-	archivePath := filepath.Join(tempDir, archiveName)
-	if err := download.File(downloadURL, archivePath); err != nil {
+	archivePath, err := cache.Fetch(cache.Request{
+		Runtime:  "python",
+		Version:  version,
+		URL:      dl.URL,
+		SHA256:   dl.SHA256,
+		FileName: archiveName,
+	}, tempDir, out)
+	if err != nil {
 		cleanupFunc()
 		return "", nil, fmt.Errorf("failed to download: %w", err)
 	}
 
-	extractDir = filepath.Join(tempDir, "extracted")
-	spinner := ui.NewSpinner("Extracting archive...")
-	spinner.Start()
+	spinner := out.StartStep("Extracting archive...")
 
 	var extractErr error
 	if strings.HasSuffix(archiveName, ".zip") {
@@ -82,25 +91,32 @@ func determineSourceDir(extractDir string) string {
 // On Windows, pip may be missing (python.org embeddable) or have broken
 // executables (python-build-standalone with embedded build paths).
 // Running ensurepip --default-pip --upgrade creates working pip executables.
-func (p *Provider) installPipIfNeeded(version string) {
+func (p *Provider) installPipIfNeeded(out ui.TaskOutput, version string) {
 	if goruntime.GOOS == constants.OSWindows {
-		pipSpinner := ui.NewSpinner("Configuring pip...")
-		pipSpinner.Start()
+		pipSpinner := out.StartStep("Configuring pip...")
 		if err := p.installPip(version); err != nil {
 			pipSpinner.Warning("Failed to configure pip")
-			ui.Info("To install pip manually, run:")
-			ui.Info("  python -m ensurepip --default-pip --upgrade")
+			out.Info("To install pip manually, run:")
+			out.Info("  python -m ensurepip --default-pip --upgrade")
 		} else {
 			pipSpinner.Success("pip configured successfully")
 		}
 	} else {
 		// python-build-standalone includes pip on Unix
-		ui.Success("pip included")
+		out.Success("pip included")
 	}
 }
 
 // Install downloads and installs a specific version.
 func (p *Provider) Install(version string) error {
+	return p.InstallWithProgress(version, nil)
+}
+
+// InstallWithProgress installs a specific version, sending progress to
+// reporter instead of the console when reporter is non-nil.
+func (p *Provider) InstallWithProgress(version string, reporter ui.ProgressReporter) error {
+	out := ui.NewTaskOutput(reporter)
+
 	ui.Debug("Starting Python installation for version %s", version)
 
 	if err := config.EnsureDirectories(); err != nil {
@@ -111,16 +127,22 @@ func (p *Provider) Install(version string) error {
 		return fmt.Errorf("Python %s is already installed", version)
 	}
 
-	ui.Header("Installing Python v%s...", version)
+	out.Header("Installing Python v%s...", version)
 
-	downloadURL, archiveName, err := p.getDownloadURL(version)
+	dl, archiveName, err := p.getDownload(version)
 	if err != nil {
 		return fmt.Errorf("failed to get download URL: %w", err)
 	}
-	ui.Debug("Download URL: %s", downloadURL)
+	ui.Debug("Download URL: %s", dl.URL)
 	ui.Debug("Archive name: %s", archiveName)
 
-	extractDir, cleanup, err := p.downloadAndExtract(version, downloadURL, archiveName)
+	inst, err := runtime.BeginInstall("python", version)
+	if err != nil {
+		return err
+	}
+	defer inst.Rollback()
+
+	extractDir, cleanup, err := p.downloadAndExtract(out, version, dl, archiveName, inst.StagingDir)
 	if err != nil {
 		return err
 	}
@@ -129,51 +151,52 @@ func (p *Provider) Install(version string) error {
 	sourceDir := determineSourceDir(extractDir)
 	ui.Debug("Source directory: %s", sourceDir)
 
-	installPath := config.RuntimeVersionPath("python", version)
-	ui.Debug("Install path: %s", installPath)
+	ui.Debug("Install path: %s", inst.InstallPath)
 
-	if err := os.MkdirAll(filepath.Dir(installPath), 0755); err != nil {
-		return fmt.Errorf("failed to create install directory: %w", err)
-	}
-
-	ui.Debug("Moving files from %s to %s", sourceDir, installPath)
-	if err := os.Rename(sourceDir, installPath); err != nil {
-		return fmt.Errorf("failed to move to install location: %w", err)
+	if err := inst.Promote(sourceDir); err != nil {
+		return err
 	}
 
 	// Install/configure pip first (so executables exist before creating shims)
-	p.installPipIfNeeded(version)
+	p.installPipIfNeeded(out, version)
 
-	shimSpinner := ui.NewSpinner("Creating shims...")
-	shimSpinner.Start()
+	// Shims are created before the commit, so a failure rolls the install
+	// back; the shims only this version provides are removed with it
+	shimSpinner := out.StartStep("Creating shims...")
 	if err := p.createShims(version); err != nil {
 		shimSpinner.Error("Failed to create shims")
+		_, _ = shim.UnregisterVersion("python", version)
 		return fmt.Errorf("failed to create shims: %w", err)
 	}
 	shimSpinner.Success("Shims created")
 
-	ui.Success("Python v%s installed successfully", version)
-	ui.Info("Location: %s", installPath)
+	if err := inst.Commit(); err != nil {
+		_, _ = shim.UnregisterVersion("python", version)
+		return fmt.Errorf("failed to complete install: %w", err)
+	}
+
+	out.Success("Python v%s installed successfully", version)
+	out.Info("Location: %s", inst.InstallPath)
 
 	return nil
 }
 
-// getDownloadURL returns the download URL and archive name for a given version.
-func (p *Provider) getDownloadURL(version string) (string, string, error) {
+// getDownload returns the manifest download entry and archive name for a given version.
+func (p *Provider) getDownload(version string) (*manifest.Download, string, error) {
 	m, err := manifest.DefaultSource().GetManifest("python")
 	if err != nil {
-		return "", "", fmt.Errorf("failed to load manifest: %w", err)
+		return nil, "", fmt.Errorf("failed to load manifest: %w", err)
 	}
 
 	platform := manifest.CurrentPlatform()
 	dl := m.GetDownload(version, platform)
 	if dl == nil {
-		return "", "", fmt.Errorf("Python %s is not available for %s", version, platform)
+		return nil, "", fmt.Errorf("Python %s is not available for %s", version, platform)
 	}
 
 	archiveName := filepath.Base(dl.URL)
 
-	return dl.URL, archiveName, nil
+	return dl, archiveName, nil
 }
 
 // createShims creates shims for Python executables and registers them in the
@@ -376,35 +399,19 @@ func (p *Provider) enableSitePackages(pthFile string) error {
 	return os.WriteFile(pthFile, []byte(newContent), 0644)
 }
 
-// Uninstall removes an installed version.
+// Uninstall removes an installed version and the shims only it provided.
 func (p *Provider) Uninstall(version string) error {
-	// TODO: Implement Python uninstallation
-	return fmt.Errorf("not yet implemented")
+	return shim.UninstallVersion(p, version)
 }
 
 // ListInstalled returns all installed Python versions.
 func (p *Provider) ListInstalled() ([]runtime.InstalledVersion, error) {
-	paths := config.DefaultPaths()
-	pythonVersionsDir := filepath.Join(paths.Versions, "python")
-
-	if _, err := os.Stat(pythonVersionsDir); os.IsNotExist(err) {
-		return []runtime.InstalledVersion{}, nil
-	}
-
-	entries, err := os.ReadDir(pythonVersionsDir)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read versions directory: %w", err)
-	}
-
 	versions := make([]runtime.InstalledVersion, 0)
-	for _, entry := range entries {
-		if entry.IsDir() {
-			versions = append(versions, runtime.InstalledVersion{
-				Version:     runtime.NewVersion(entry.Name()),
-				InstallPath: filepath.Join(pythonVersionsDir, entry.Name()),
-				IsGlobal:    false, // TODO: Check if this is the global version
-			})
-		}
+	for _, version := range config.InstalledVersions("python") {
+		versions = append(versions, runtime.InstalledVersion{
+			Version:     runtime.NewVersion(version),
+			InstallPath: config.RuntimeVersionPath("python", version),
+		})
 	}
 
 	return versions, nil
@@ -420,10 +427,13 @@ func (p *Provider) ListAvailable() ([]runtime.AvailableVersion, error) {
 	platform := manifest.CurrentPlatform()
 	versionStrings := m.ListAvailableVersions(platform)
 
+	schedule := lifecycleSchedule()
+
 	versions := make([]runtime.AvailableVersion, 0, len(versionStrings))
 	for _, v := range versionStrings {
 		versions = append(versions, runtime.AvailableVersion{
-			Version: runtime.NewVersion(v),
+			Version:         runtime.NewVersion(v),
+			LifecycleStatus: schedule.VersionStatus(v),
 		})
 	}
 
@@ -458,7 +468,7 @@ func (p *Provider) SetLocalVersion(version string) error {
 
 // CurrentVersion returns the currently active version.
 func (p *Provider) CurrentVersion() (string, error) {
-	return config.ResolveVersion("python")
+	return runtime.CurrentVersion(p)
 }
 
 // DetectInstalled scans the system for existing Python installations.
@@ -564,3 +574,33 @@ func findPipInInstall(installDir string) string {
 
 	return ""
 }
+
+// SmokeTestArgs returns the arguments dtvem verify runs python with.
+// Importing ssl catches a missing or broken OpenSSL, the most common
+// failure of an otherwise working interpreter.
+func (p *Provider) SmokeTestArgs() []string {
+	return []string{"-c", "import ssl"}
+}
+
+// MutablePaths returns the files pip and the interpreter change after
+// install: installed packages, their scripts, and bytecode caches.
+func (p *Provider) MutablePaths() []string {
+	return []string{"site-packages", "__pycache__", "Scripts", "bin/pip*"}
+}
+
+// PrepareAdopted adds the bin/python that dtvem runs to adopted installs
+// that only provide python3, as pyenv and asdf builds may.
+func (p *Provider) PrepareAdopted(version string, linked bool) error {
+	if goruntime.GOOS == constants.OSWindows {
+		return nil
+	}
+
+	binDir := filepath.Join(config.RuntimeVersionPath("python", version), "bin")
+	if _, err := os.Lstat(filepath.Join(binDir, "python")); err == nil {
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(binDir, "python3")); err != nil {
+		return fmt.Errorf("no python or python3 executable in %s", binDir)
+	}
+	return os.Symlink("python3", filepath.Join(binDir, "python"))
+}
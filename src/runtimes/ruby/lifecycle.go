@@ -0,0 +1,103 @@
+//go:build !shim
+
+// Lifecycle computation is only used by the dtvem CLI (e.g., `list-all`) and
+// embeds ruby-lang.org's branches.yml. Tagged !shim so neither the code nor
+// the embedded data is linked into the shim binary.
+package ruby
+
+import (
+	"embed"
+	"sync"
+	"time"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/lifecycle"
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/ui"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed data/branches.yml
+var branchesData embed.FS
+
+// branchEntry represents a single release line from the Ruby maintenance
+// branches (https://github.com/ruby/www.ruby-lang.org/blob/master/_data/branches.yml).
+type branchEntry struct {
+	Name                    string `yaml:"name"`
+	Status                  string `yaml:"status"`
+	Date                    string `yaml:"date"`
+	SecurityMaintenanceDate string `yaml:"security_maintenance_date"`
+	EOLDate                 string `yaml:"eol_date"`
+	ExpectedEOLDate         string `yaml:"expected_eol_date"`
+}
+
+// upstreamPhases maps the branches.yml status values to phases
+var upstreamPhases = map[string]lifecycle.Phase{
+	"preview":              lifecycle.PhasePrerelease,
+	"normal maintenance":   lifecycle.PhaseActive,
+	"security maintenance": lifecycle.PhaseMaintenance,
+	"eol":                  lifecycle.PhaseEOL,
+}
+
+// statusLabels are the Ruby names of the lifecycle phases
+var statusLabels = map[lifecycle.Phase]lifecycle.Status{
+	lifecycle.PhasePrerelease:  lifecycle.Prerelease,
+	lifecycle.PhaseActive:      lifecycle.NormalMaintenance,
+	lifecycle.PhaseMaintenance: lifecycle.Security,
+	lifecycle.PhaseEOL:         lifecycle.EOL,
+}
+
+// Ensure Provider satisfies the interface at compile time.
+var _ lifecycle.StatusProvider = (*Provider)(nil)
+
+// VersionStatus returns the lifecycle label for a Ruby version.
+func (p *Provider) VersionStatus(version string) string {
+	return lifecycleSchedule().VersionStatus(version)
+}
+
+var (
+	statusSchedule     *lifecycle.Schedule
+	statusScheduleOnce sync.Once
+)
+
+// lifecycleSchedule returns the Ruby release lines from the embedded
+// branches, parsed on first use
+func lifecycleSchedule() *lifecycle.Schedule {
+	statusScheduleOnce.Do(func() { statusSchedule = parseLifecycleSchedule() })
+	return statusSchedule
+}
+
+// parseLifecycleSchedule builds the Ruby release lines from the embedded
+// branches. Lines are empty if it can't be parsed.
+func parseLifecycleSchedule() *lifecycle.Schedule {
+	schedule := &lifecycle.Schedule{Labels: statusLabels, Key: lifecycle.MajorMinor}
+
+	data, err := branchesData.ReadFile("data/branches.yml")
+	if err != nil {
+		ui.Debug("lifecycle: failed to read embedded branches: %v", err)
+		return schedule
+	}
+
+	var entries []branchEntry
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		ui.Debug("lifecycle: failed to parse branches: %v", err)
+		return schedule
+	}
+
+	// Old branches are named by full version (e.g., "2.0.0"); key every
+	// branch by its major.minor
+	schedule.Lines = make(map[string]lifecycle.Line, len(entries))
+	for _, e := range entries {
+		end := lifecycle.ParseDate(e.EOLDate)
+		if end.IsZero() {
+			end = lifecycle.ParseDate(e.ExpectedEOLDate)
+		}
+		schedule.Lines[lifecycle.MajorMinor(e.Name)] = lifecycle.Line{
+			Upstream: upstreamPhases[e.Status],
+			Starts: map[lifecycle.Phase]time.Time{
+				lifecycle.PhaseActive:      lifecycle.ParseDate(e.Date),
+				lifecycle.PhaseMaintenance: lifecycle.ParseDate(e.SecurityMaintenanceDate),
+				lifecycle.PhaseEOL:         end,
+			},
+		}
+	}
+	return schedule
+}
@@ -0,0 +1,90 @@
+//go:build !shim
+
+package ruby
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/lifecycle"
+)
+
+func mustParseDate(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestLifecycleProvider_VersionStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		now     string
+		version string
+		want    string
+	}{
+		// 3.2: released 2022-12-25, security maintenance 2025-04-01,
+		// expected EOL 2026-03-31
+		{
+			name:    "3.2 upstream security status outranks dates",
+			now:     "2024-01-15",
+			version: "3.2.2",
+			want:    string(lifecycle.Security),
+		},
+		{
+			name:    "3.2 after expected EOL is EOL",
+			now:     "2026-04-01",
+			version: "3.2.9",
+			want:    string(lifecycle.EOL),
+		},
+		// 3.4: released 2024-12-25, expected EOL 2028-03-31
+		{
+			name:    "3.4 after release is Normal Maintenance",
+			now:     "2025-06-01",
+			version: "3.4.4",
+			want:    string(lifecycle.NormalMaintenance),
+		},
+		{
+			name:    "3.5 preview is Prerelease",
+			now:     "2025-06-01",
+			version: "3.5.0-preview1",
+			want:    string(lifecycle.Prerelease),
+		},
+		// 3.0 is named "3.0" in YAML; it must not be read as the number 3
+		{
+			name:    "3.0 is EOL",
+			now:     "2025-01-15",
+			version: "3.0.7",
+			want:    string(lifecycle.EOL),
+		},
+		{
+			name:    "2.0 branch named by full version is EOL",
+			now:     "2025-01-15",
+			version: "2.0.0-p648",
+			want:    string(lifecycle.EOL),
+		},
+		// Edge cases
+		{
+			name:    "unknown branch returns empty",
+			now:     "2025-01-15",
+			version: "9.9.0",
+			want:    "",
+		},
+		{
+			name:    "invalid version returns empty",
+			now:     "2025-01-15",
+			version: "abc",
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lifecycleSchedule().StatusAt(tt.version, mustParseDate(tt.now))
+			if got != tt.want {
+				t.Errorf("VersionStatus(%q) at %s = %q, want %q", tt.version, tt.now, got, tt.want)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 	goruntime "runtime"
 	"strings"
 
+	"github.com/CodingWithCalvin/dtvem.cli/src/internal/cache"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/config"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/constants"
 	"github.com/CodingWithCalvin/dtvem.cli/src/internal/download"
@@ -26,6 +27,14 @@ import (
 
 // Install downloads and installs a specific version.
 func (p *Provider) Install(version string) error {
+	return p.InstallWithProgress(version, nil)
+}
+
+// InstallWithProgress installs a specific version, sending progress to
+// reporter instead of the console when reporter is non-nil.
+func (p *Provider) InstallWithProgress(version string, reporter ui.ProgressReporter) error {
+	out := ui.NewTaskOutput(reporter)
+
 	ui.Debug("Starting Ruby installation for version %s", version)
 
 	if err := config.EnsureDirectories(); err != nil {
@@ -36,16 +45,22 @@ func (p *Provider) Install(version string) error {
 		return fmt.Errorf("Ruby %s is already installed", version)
 	}
 
-	ui.Header("Installing Ruby v%s...", version)
+	out.Header("Installing Ruby v%s...", version)
 
-	downloadURL, archiveName, err := p.getDownloadURL(version)
+	dl, archiveName, err := p.getDownload(version)
 	if err != nil {
 		return fmt.Errorf("failed to get download URL: %w", err)
 	}
-	ui.Debug("Download URL: %s", downloadURL)
+	ui.Debug("Download URL: %s", dl.URL)
 	ui.Debug("Archive name: %s", archiveName)
 
-	extractDir, cleanup, err := p.downloadAndExtract(version, downloadURL, archiveName)
+	inst, err := runtime.BeginInstall("ruby", version)
+	if err != nil {
+		return err
+	}
+	defer inst.Rollback()
+
+	extractDir, cleanup, err := p.downloadAndExtract(out, version, dl, archiveName, inst.StagingDir)
 	if err != nil {
 		return err
 	}
@@ -54,36 +69,41 @@ func (p *Provider) Install(version string) error {
 	sourceDir := p.determineSourceDir(extractDir)
 	ui.Debug("Source directory: %s", sourceDir)
 
-	installPath := config.RuntimeVersionPath("ruby", version)
-	ui.Debug("Install path: %s", installPath)
-
-	if err := os.MkdirAll(filepath.Dir(installPath), 0755); err != nil {
-		return fmt.Errorf("failed to create install directory: %w", err)
-	}
+	ui.Debug("Install path: %s", inst.InstallPath)
 
-	ui.Debug("Moving files from %s to %s", sourceDir, installPath)
-	if err := os.Rename(sourceDir, installPath); err != nil {
-		return fmt.Errorf("failed to move to install location: %w", err)
+	if err := inst.Promote(sourceDir); err != nil {
+		return err
 	}
 
-	shimSpinner := ui.NewSpinner("Creating shims...")
-	shimSpinner.Start()
+	// Shims are created before the commit, so a failure rolls the install
+	// back; the shims only this version provides are removed with it
+	shimSpinner := out.StartStep("Creating shims...")
 	if err := p.createShims(version); err != nil {
 		shimSpinner.Error("Failed to create shims")
+		_, _ = shim.UnregisterVersion("ruby", version)
 		return fmt.Errorf("failed to create shims: %w", err)
 	}
 	shimSpinner.Success("Shims created")
 
-	ui.Success("Ruby v%s installed successfully", version)
-	ui.Info("Location: %s", installPath)
+	if err := inst.Commit(); err != nil {
+		_, _ = shim.UnregisterVersion("ruby", version)
+		return fmt.Errorf("failed to complete install: %w", err)
+	}
+
+	out.Success("Ruby v%s installed successfully", version)
+	out.Info("Location: %s", inst.InstallPath)
 
 	return nil
 }
 
-// downloadAndExtract downloads and extracts the Ruby archive.
-func (p *Provider) downloadAndExtract(version, downloadURL, archiveName string) (extractDir string, cleanup func(), err error) {
-	ui.Progress("Downloading from %s", downloadURL)
-
+// downloadAndExtract downloads the Ruby archive and extracts it into extractDir
+// (the install's staging directory), returning the directory holding the
+// extracted files. A temporary directory used for the download is removed by
+// the returned cleanup function.
+// The archive comes from the download cache when present; otherwise it is
+// downloaded, verified against the manifest checksum according to the
+// configured checksum policy, and cached.
+func (p *Provider) downloadAndExtract(out ui.TaskOutput, version string, dl *manifest.Download, archiveName, extractDir string) (extractedDir string, cleanup func(), err error) {
 	tempDir := filepath.Join(os.TempDir(), fmt.Sprintf("dtvem-ruby-%s", version))
 	if err := os.MkdirAll(tempDir, 0755); err != nil {
 		return "", nil, fmt.Errorf("failed to create temp directory: %w", err)
@@ -91,20 +111,24 @@ func (p *Provider) downloadAndExtract(version, downloadURL, archiveName string)
 
 	cleanupFunc := func() { _ = os.RemoveAll(tempDir) }
 
-	archivePath := filepath.Join(tempDir, archiveName)
-	if err := download.File(downloadURL, archivePath); err != nil {
+	archivePath, err := cache.Fetch(cache.Request{
+		Runtime:  "ruby",
+		Version:  version,
+		URL:      dl.URL,
+		SHA256:   dl.SHA256,
+		FileName: archiveName,
+	}, tempDir, out)
+	if err != nil {
 		cleanupFunc()
 		return "", nil, fmt.Errorf("failed to download: %w", err)
 	}
 
 	// Handle .exe installer specially (Windows RubyInstaller)
 	if strings.HasSuffix(archiveName, ".exe") {
-		return p.runWindowsInstaller(version, archivePath, tempDir, cleanupFunc)
+		return p.runWindowsInstaller(out, version, archivePath, extractDir, cleanupFunc)
 	}
 
-	extractDir = filepath.Join(tempDir, "extracted")
-	spinner := ui.NewSpinner("Extracting archive...")
-	spinner.Start()
+	spinner := out.StartStep("Extracting archive...")
 
 	var extractErr error
 	if strings.HasSuffix(archiveName, ".zip") {
@@ -128,11 +152,10 @@ func (p *Provider) downloadAndExtract(version, downloadURL, archiveName string)
 }
 
 // runWindowsInstaller runs the RubyInstaller .exe in silent mode.
-func (p *Provider) runWindowsInstaller(version, installerPath, tempDir string, cleanupFunc func()) (string, func(), error) {
-	extractDir := filepath.Join(tempDir, "installed")
+func (p *Provider) runWindowsInstaller(out ui.TaskOutput, version, installerPath, stagingDir string, cleanupFunc func()) (string, func(), error) {
+	extractDir := filepath.Join(stagingDir, "installed")
 
-	spinner := ui.NewSpinner("Running installer (silent mode)...")
-	spinner.Start()
+	spinner := out.StartStep("Running installer (silent mode)...")
 
 	// /VERYSILENT, /SUPPRESSMSGBOXES, /NORESTART, /CURRENTUSER (no admin), /DIR=...,
 	// /TASKS="" (no PATH modification, no file associations).
@@ -174,22 +197,22 @@ func (p *Provider) determineSourceDir(extractDir string) string {
 	return extractDir
 }
 
-// getDownloadURL returns the download URL and archive name for a given version.
-func (p *Provider) getDownloadURL(version string) (string, string, error) {
+// getDownload returns the manifest download entry and archive name for a given version.
+func (p *Provider) getDownload(version string) (*manifest.Download, string, error) {
 	m, err := manifest.DefaultSource().GetManifest("ruby")
 	if err != nil {
-		return "", "", fmt.Errorf("failed to load manifest: %w", err)
+		return nil, "", fmt.Errorf("failed to load manifest: %w", err)
 	}
 
 	platform := manifest.CurrentPlatform()
 	dl := m.GetDownload(version, platform)
 	if dl == nil {
-		return "", "", fmt.Errorf("Ruby %s is not available for %s", version, platform)
+		return nil, "", fmt.Errorf("Ruby %s is not available for %s", version, platform)
 	}
 
 	archiveName := filepath.Base(dl.URL)
 
-	return dl.URL, archiveName, nil
+	return dl, archiveName, nil
 }
 
 // createShims creates shims for Ruby executables and registers them in the
@@ -216,34 +239,19 @@ func (p *Provider) createShims(version string) error {
 	return manager.CreateShimsForRuntime("ruby", version, shimNames)
 }
 
-// Uninstall removes an installed version.
+// Uninstall removes an installed version and the shims only it provided.
 func (p *Provider) Uninstall(version string) error {
-	return fmt.Errorf("not yet implemented")
+	return shim.UninstallVersion(p, version)
 }
 
 // ListInstalled returns all installed Ruby versions.
 func (p *Provider) ListInstalled() ([]runtime.InstalledVersion, error) {
-	paths := config.DefaultPaths()
-	rubyVersionsDir := filepath.Join(paths.Versions, "ruby")
-
-	if _, err := os.Stat(rubyVersionsDir); os.IsNotExist(err) {
-		return []runtime.InstalledVersion{}, nil
-	}
-
-	entries, err := os.ReadDir(rubyVersionsDir)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read versions directory: %w", err)
-	}
-
 	versions := make([]runtime.InstalledVersion, 0)
-	for _, entry := range entries {
-		if entry.IsDir() {
-			versions = append(versions, runtime.InstalledVersion{
-				Version:     runtime.NewVersion(entry.Name()),
-				InstallPath: filepath.Join(rubyVersionsDir, entry.Name()),
-				IsGlobal:    false,
-			})
-		}
+	for _, version := range config.InstalledVersions("ruby") {
+		versions = append(versions, runtime.InstalledVersion{
+			Version:     runtime.NewVersion(version),
+			InstallPath: config.RuntimeVersionPath("ruby", version),
+		})
 	}
 
 	return versions, nil
@@ -259,10 +267,13 @@ func (p *Provider) ListAvailable() ([]runtime.AvailableVersion, error) {
 	platform := manifest.CurrentPlatform()
 	versionStrings := m.ListAvailableVersions(platform)
 
+	schedule := lifecycleSchedule()
+
 	versions := make([]runtime.AvailableVersion, 0, len(versionStrings))
 	for _, v := range versionStrings {
 		versions = append(versions, runtime.AvailableVersion{
-			Version: runtime.NewVersion(v),
+			Version:         runtime.NewVersion(v),
+			LifecycleStatus: schedule.VersionStatus(v),
 		})
 	}
 
@@ -297,7 +308,7 @@ func (p *Provider) SetLocalVersion(version string) error {
 
 // CurrentVersion returns the currently active version.
 func (p *Provider) CurrentVersion() (string, error) {
-	return config.ResolveVersion("ruby")
+	return runtime.CurrentVersion(p)
 }
 
 // DetectInstalled scans the system for existing Ruby installations.
@@ -495,3 +506,55 @@ func findGemInInstall(installDir string) string {
 
 	return ""
 }
+
+// SmokeTestArgs returns the arguments dtvem verify runs ruby with.
+// Requiring openssl catches a missing or broken OpenSSL, which breaks gem
+// and bundler even when ruby itself starts.
+func (p *Provider) SmokeTestArgs() []string {
+	return []string{"-e", "require 'openssl'"}
+}
+
+// MutablePaths returns the files RubyGems changes after install: installed
+// gems and the RubyGems and Bundler updates of gem update --system.
+func (p *Provider) MutablePaths() []string {
+	return []string{"lib/ruby/gems", "lib/ruby/site_ruby", "bin/gem*", "bin/bundle*"}
+}
+
+// PrepareAdopted checks that a copied Ruby finds its libraries in the copy.
+// Ruby built without --enable-load-relative, the rbenv and rvm default,
+// loads them from the prefix it was configured with, so a copy would keep
+// depending on the original install and break once it is removed.
+func (p *Provider) PrepareAdopted(version string, linked bool) error {
+	if linked {
+		return nil
+	}
+
+	rubyPath, err := p.ExecutablePath(version)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(rubyPath, "-e", "print RbConfig::CONFIG['prefix']")
+	env, _ := p.GetEnvironment(version)
+	cmd.Env = runtime.MergeEnvironment(os.Environ(), env)
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("failed to run %s: %w", rubyPath, err)
+	}
+
+	prefix := strings.TrimSpace(string(out))
+	if !sameDir(prefix, config.RuntimeVersionPath("ruby", version)) {
+		return fmt.Errorf("Ruby %s was built for %s and cannot be copied; adopt it with --link instead", version, prefix)
+	}
+	return nil
+}
+
+// sameDir reports whether two paths name the same directory once symbolic
+// links are resolved
+func sameDir(a, b string) bool {
+	resolvedA, errA := filepath.EvalSymlinks(a)
+	resolvedB, errB := filepath.EvalSymlinks(b)
+	if errA != nil || errB != nil {
+		return filepath.Clean(a) == filepath.Clean(b)
+	}
+	return resolvedA == resolvedB
+}